@@ -0,0 +1,228 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	log "github.com/obalunenko/logger"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/command"
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/report"
+)
+
+var (
+	errNoPuzzles   = errors.New("no puzzles match passed years and tags")
+	errBatchFailed = errors.New("some puzzles failed")
+)
+
+func allAction(ctx context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		solvers := puzzles.Solvers(c.StringSlice(flagYear), c.StringSlice(flagTag))
+		if len(solvers) == 0 {
+			return errNoPuzzles
+		}
+
+		format, err := command.ParseFormat(c.String(flagFormat))
+		if err != nil {
+			return err
+		}
+
+		dir := c.String(flagDir)
+
+		sess, err := resolveSession(c, dir == "")
+		if err != nil {
+			return err
+		}
+
+		ctx = command.ContextWithSession(ctx, sess)
+		ctx = command.ContextWithBaseURL(ctx, c.String(flagBaseURL))
+
+		ctx, err = contextWithInputCache(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		events, closeEvents, err := openEvents(c)
+		if err != nil {
+			return err
+		}
+
+		defer closeEvents()
+
+		// Workers are stopped when results are not read till the end on error.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		results, err := command.RunBatch(ctx, solvers,
+			command.WithWorkers(c.Int(flagWorkers)),
+			command.WithTimeout(c.Duration(flagTimeout)),
+			command.WithInputDir(dir),
+			command.WithOnStart(func(s puzzles.Solver) {
+				if events == nil {
+					return
+				}
+
+				if eerr := events.Start(s.Year(), s.Day()); eerr != nil {
+					log.WithError(ctx, eerr).Warn("Failed to write event")
+				}
+			}),
+		)
+		if err != nil {
+			return err
+		}
+
+		list, cases, err := collectResults(c, format, dir, events, results)
+		if err != nil {
+			return err
+		}
+
+		if err = writeJUnit(c, cases); err != nil {
+			return err
+		}
+
+		summary := command.SummarizeBatch(list)
+
+		if format == command.FormatTable {
+			err = command.RenderBatchSummary(c.App.Writer, summary)
+		} else {
+			sortResults(list)
+
+			err = command.WriteResults(c.App.Writer, format, list)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// Days solved with wrong answers.
+		wrong := make(map[string]bool)
+
+		for _, tc := range cases {
+			if tc.Failed() {
+				wrong[tc.Year+"/"+tc.Day] = true
+			}
+		}
+
+		if failed := summary.Total.Failed + len(wrong); failed != 0 {
+			return fmt.Errorf("%w: %d of %d", errBatchFailed, failed, len(list))
+		}
+
+		return nil
+	}
+}
+
+// sortResults sorts results, which come in order of completion, by year and day.
+func sortResults(list []command.BatchResult) {
+	slices.SortFunc(list, func(a, b command.BatchResult) int {
+		if c := cmp.Compare(a.Year, b.Year); c != 0 {
+			return c
+		}
+
+		da, _ := strconv.Atoi(a.Day)
+		db, _ := strconv.Atoi(b.Day)
+
+		return cmp.Compare(da, db)
+	})
+}
+
+// collectResults collects results of batch run as they come, printing them in table format and writing events.
+func collectResults(
+	c *cli.Context,
+	format command.Format,
+	dir string,
+	events *report.EventWriter,
+	results <-chan command.BatchResult,
+) ([]command.BatchResult, []report.Case, error) {
+	var (
+		list  []command.BatchResult
+		cases []report.Case
+	)
+
+	for r := range results {
+		if format == command.FormatTable {
+			if err := command.RenderBatchResult(c.App.Writer, r); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		rc := r.Cases(command.AnswersFromDir(dir, input.Date{
+			Year: r.Year,
+			Day:  r.Day,
+		}))
+
+		if events != nil {
+			if err := events.Finish(r.Year, r.Day, r.Elapsed, rc); err != nil {
+				log.WithError(c.Context, err).Warn("Failed to write event")
+			}
+		}
+
+		cases = append(cases, rc...)
+		list = append(list, r)
+	}
+
+	return list, cases, nil
+}
+
+// openEvents opens NDJSON events output set by flag. Returns nil writer when events are not requested.
+func openEvents(c *cli.Context) (*report.EventWriter, func(), error) {
+	fpath := c.String(flagEvents)
+	if fpath == "" {
+		return nil, func() {}, nil
+	}
+
+	w, closeFn, err := createOutput(c, fpath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return report.NewEventWriter(w), closeFn, nil
+}
+
+// writeJUnit writes JUnit XML report to the file set by flag, if any.
+func writeJUnit(c *cli.Context, cases []report.Case) error {
+	fpath := c.String(flagJUnit)
+	if fpath == "" {
+		return nil
+	}
+
+	w, closeFn, err := createOutput(c, fpath)
+	if err != nil {
+		return err
+	}
+
+	defer closeFn()
+
+	if err = report.WriteJUnit(w, c.App.Name, cases); err != nil {
+		return fmt.Errorf("write junit report: %w", err)
+	}
+
+	return nil
+}
+
+// createOutput creates file for report. Dash means application output.
+func createOutput(c *cli.Context, fpath string) (io.Writer, func(), error) {
+	if fpath == "-" {
+		return c.App.Writer, func() {}, nil
+	}
+
+	f, err := os.Create(filepath.Clean(fpath))
+	if err != nil {
+		return nil, nil, fmt.Errorf("create report: %w", err)
+	}
+
+	return f, func() {
+		if err := f.Close(); err != nil {
+			log.WithError(c.Context, err).Warn("Failed to close report")
+		}
+	}, nil
+}
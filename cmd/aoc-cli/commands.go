@@ -8,7 +8,102 @@
 
 func commands(ctx context.Context) []*cli.Command {
 	const (
-		cmdRun = "run"
+		cmdRun   = "run"
+		cmdSolve = "solve"
+		cmdAll   = "all"
+		cmdSpec  = "spec"
+		cmdNew   = "new"
+		cmdWatch = "watch"
+		cmdServe = "serve"
+		cmdUI    = "ui"
+		cmdLB    = "leaderboard"
+
+		cmdDownload = "download"
+		cmdMatrix   = "matrix"
+		cmdStats    = "stats"
+
+		cmdSession = "session"
+		cmdLogin   = "login"
+		cmdStatus  = "status"
+		cmdList    = "list"
+		cmdUse     = "use"
+		cmdLogout  = "logout"
+
+		cmdPath = "path"
+		cmdGet  = "get"
+		cmdSet  = "set"
+
+		cmdReadme    = "readme"
+		cmdTemplates = "templates"
+		cmdExport    = "export"
+
+		cmdVault   = "vault"
+		cmdKeygen  = "keygen"
+		cmdEncrypt = "encrypt"
+		cmdDecrypt = "decrypt"
+
+		sessionDescription = "Stores named session profiles (e.g. work and personal accounts) in config dir.\n" +
+			"Session is taken from --session flag or AOC_SESSION env first, then from --profile or default profile."
+
+		lbDescription = "Renders private leaderboard rankings, star grid and solve time deltas between members.\n" +
+			"Leaderboard is cached and refreshed not more often than once per 15 minutes."
+
+		newDescription = "Scaffolds puzzle package with solution, tests and spec from templates and registers it:\n" +
+			"adds blank import to register_<year>.go, Year constant with its stringer and regression test case.\n" +
+			"Should be run at the repository root or with --root flag."
+
+		templatesDescription = "Scaffolding templates could be overridden by <file>.tmpl files in templates directory.\n" +
+			"Export the embedded templates there to edit them."
+
+		statusDescription = "Prints grid of days per year: not started, scaffolded only, part 1 or both parts implemented.\n" +
+			"When session is set, stars from the /{year} calendar are compared with the code and mismatches are flagged."
+
+		completionDescription = "Prints completion script of the shell. Years and days of registered puzzles and stored session\n" +
+			"profiles are completed dynamically, e.g. add to ~/.zshrc: source <(aoc-cli completion zsh)"
+
+		configDescription = "Manages config file with defaults of options: format, metrics, cache-dir, timeout, year,\n" +
+			"profile and templates. Value is taken from flag first, then from env, then from config file.\n" +
+			"Config file is $XDG_CONFIG_HOME/aoc-cli/config.yaml, or file set by AOC_CONFIG env."
+
+		watchDescription = "Watches puzzle package with testdata and on each change rebuilds aoc-cli, runs package tests\n" +
+			"with examples and solves the real input. Answers and timings are compared with the previous run,\n" +
+			"build errors are printed inline. Files are polled every --interval, go tool is required."
+
+		serveDescription = "Serves HTTP API of registered solvers: GET /years, GET /years/{year}/days,\n" +
+			"POST /solve/{year}/{day} with input as request body (?metrics=elapsed,bench,none), GET /healthz\n" +
+			"and GET /metrics in Prometheus text format.\n" +
+			"Concurrent solves, input size and solve time are limited by flags."
+
+		uiDescription = "Serves web UI on localhost: pick a puzzle, paste or upload input, run it and read its description.\n" +
+			"UI works offline, descriptions are embedded from spec.md files. API of serve command is served as well."
+
+		rpcDescription = "Speaks JSON-RPC 2.0 over stdin and stdout, a message per line, for editor integrations.\n" +
+			"Methods: listSolvers, solve (input string or path, parts, metrics), fetchInput and submit.\n" +
+			"Progress of requests is sent as progress notifications. Session is needed to fetch inputs and submit answers."
+
+		readmeDescription = "Rewrites tables between <!--- advent_readme_stars table [<year>] ---> markers with registered\n" +
+			"puzzles. Part gets a star unless its solver returns not implemented error."
+
+		downloadDescription = "Downloads inputs of all implemented puzzles respecting rate limit, e.g. to run regression offline.\n" +
+			"Already downloaded inputs are skipped, so download could be resumed. Inputs are listed in manifest.json with SHA-256 sums."
+
+		matrixDescription = "Runs solution on inputs of several accounts to catch solutions relying on quirks of one input.\n" +
+			"Answers matching known ones are marked with ✓, wrong ones with ✗. Known answers are read from --answers file\n" +
+			"and from answers.json next to inputs in account dir."
+
+		statsDescription = "Fetches personal statistics page (/{year}/leaderboard/self) and charts solve times and ranks\n" +
+			"of each day as ASCII bars. Statistics are stored locally and used when page could not be fetched."
+
+		solveDescription = "Solves puzzle without interactive menu and prints the result, e.g. in scripts and CI.\n" +
+			"Exit code is 3 for unauthorized, 4 for not found or locked puzzle, 5 for not implemented puzzle\n" +
+			"and 6 for solver failure. Without date the interactive menu is shown."
+
+		allDescription = "Runs every registered solution, optionally filtered by --year and --tag, by pool of workers.\n" +
+			"Results are printed as puzzles are solved, followed by totals per year and the slowest puzzles.\n" +
+			"Each puzzle has its own --timeout. Exit code is 6 when any puzzle failed."
+
+		vaultDescription = "Encrypts inputs with AES-GCM, so testdata/input.txt.enc could be committed instead of input.\n" +
+			"Key is taken from AOC_VAULT_KEY env or from file set in AOC_VAULT_KEY_FILE."
 	)
 
 	cmds := []*cli.Command{
@@ -20,7 +115,7 @@ func commands(ctx context.Context) []*cli.Command {
 			Description:            "",
 			ArgsUsage:              "",
 			Category:               "",
-			BashComplete:           nil,
+			BashComplete:           complete(nil),
 			Before:                 nil,
 			After:                  nil,
 			Action:                 menu(ctx),
@@ -35,6 +130,746 @@ func commands(ctx context.Context) []*cli.Command {
 			HelpName:               "",
 			CustomHelpTemplate:     "",
 		},
+		{
+			Name:                   cmdSolve,
+			Aliases:                nil,
+			Usage:                  "Solves puzzle for passed date",
+			UsageText:              "",
+			Description:            solveDescription,
+			ArgsUsage:              "[<year>/<day> | <puzzle url>]",
+			Category:               "",
+			BashComplete:           complete(completeDatePath),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 solveAction(ctx),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdSolveFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdAll,
+			Aliases:                nil,
+			Usage:                  "Runs all registered solutions",
+			UsageText:              "",
+			Description:            allDescription,
+			ArgsUsage:              "",
+			Category:               "",
+			BashComplete:           complete(nil),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 allAction(ctx),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdAllFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdNew,
+			Aliases:                nil,
+			Usage:                  "Creates boilerplate of new puzzle solution",
+			UsageText:              "",
+			Description:            newDescription,
+			ArgsUsage:              "<puzzle url> | <year> <day>",
+			Category:               "",
+			BashComplete:           complete(completeNewDate),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 newAction(ctx),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdNewFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdWatch,
+			Aliases:                nil,
+			Usage:                  "Re-runs puzzle solution on changes of its files",
+			UsageText:              "",
+			Description:            watchDescription,
+			ArgsUsage:              "<year>/<day> | <puzzle url> | <year> <day>",
+			Category:               "",
+			BashComplete:           complete(completeDatePath),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 watchAction(ctx),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdWatchFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:         cmdTemplates,
+			Aliases:      nil,
+			Usage:        "Manages templates of new puzzle solution",
+			UsageText:    "",
+			Description:  templatesDescription,
+			ArgsUsage:    "",
+			Category:     "",
+			BashComplete: nil,
+			Before:       nil,
+			After:        nil,
+			Action:       nil,
+			OnUsageError: nil,
+			Subcommands: []*cli.Command{
+				{
+					Name:                   cmdList,
+					Aliases:                nil,
+					Usage:                  "Lists templates and where they are loaded from",
+					UsageText:              "",
+					Description:            "",
+					ArgsUsage:              "",
+					Category:               "",
+					BashComplete:           complete(nil),
+					Before:                 nil,
+					After:                  nil,
+					Action:                 templatesListAction(ctx),
+					OnUsageError:           nil,
+					Subcommands:            nil,
+					Flags:                  templatesFlags(),
+					SkipFlagParsing:        false,
+					HideHelp:               false,
+					HideHelpCommand:        false,
+					Hidden:                 false,
+					UseShortOptionHandling: false,
+					HelpName:               "",
+					CustomHelpTemplate:     "",
+				},
+				{
+					Name:                   cmdExport,
+					Aliases:                nil,
+					Usage:                  "Copies embedded templates to templates directory for editing",
+					UsageText:              "",
+					Description:            "",
+					ArgsUsage:              "",
+					Category:               "",
+					BashComplete:           complete(nil),
+					Before:                 nil,
+					After:                  nil,
+					Action:                 templatesExportAction(ctx),
+					OnUsageError:           nil,
+					Subcommands:            nil,
+					Flags:                  cmdTemplatesExportFlags(),
+					SkipFlagParsing:        false,
+					HideHelp:               false,
+					HideHelpCommand:        false,
+					Hidden:                 false,
+					UseShortOptionHandling: false,
+					HelpName:               "",
+					CustomHelpTemplate:     "",
+				},
+			},
+			Flags:                  nil,
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdReadme,
+			Aliases:                nil,
+			Usage:                  "Updates tables of implemented puzzles in README",
+			UsageText:              "",
+			Description:            readmeDescription,
+			ArgsUsage:              "",
+			Category:               "",
+			BashComplete:           complete(nil),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 readmeAction(ctx),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdReadmeFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdStatus,
+			Aliases:                nil,
+			Usage:                  "Shows progress of solutions and stars per year",
+			UsageText:              "",
+			Description:            statusDescription,
+			ArgsUsage:              "",
+			Category:               "",
+			BashComplete:           complete(nil),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 statusAction(ctx),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdStatusFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdServe,
+			Aliases:                nil,
+			Usage:                  "Serves HTTP API of solvers",
+			UsageText:              "",
+			Description:            serveDescription,
+			ArgsUsage:              "",
+			Category:               "",
+			BashComplete:           complete(nil),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 serveAction(ctx, false),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdServeFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdUI,
+			Aliases:                nil,
+			Usage:                  "Serves web UI for solving puzzles in browser",
+			UsageText:              "",
+			Description:            uiDescription,
+			ArgsUsage:              "",
+			Category:               "",
+			BashComplete:           complete(nil),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 serveAction(ctx, true),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdServeFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdRPC,
+			Aliases:                nil,
+			Usage:                  "Serves solvers over JSON-RPC on stdin and stdout",
+			UsageText:              "",
+			Description:            rpcDescription,
+			ArgsUsage:              "",
+			Category:               "",
+			BashComplete:           complete(nil),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 rpcAction(ctx),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdRPCFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdSpec,
+			Aliases:                nil,
+			Usage:                  "Fetches puzzle description and converts it to spec.md",
+			UsageText:              "",
+			Description:            "Prints puzzle description in markdown. With --refresh flag rewrites spec.md of the puzzle.",
+			ArgsUsage:              "<year> <day>",
+			Category:               "",
+			BashComplete:           complete(completeDate),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 specAction(ctx),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdSpecFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdLB,
+			Aliases:                []string{"lb"},
+			Usage:                  "Shows private leaderboard",
+			UsageText:              "",
+			Description:            lbDescription,
+			ArgsUsage:              "",
+			Category:               "",
+			BashComplete:           complete(nil),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 leaderboardAction(ctx),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdLeaderboardFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdDownload,
+			Aliases:                nil,
+			Usage:                  "Downloads inputs of all implemented puzzles",
+			UsageText:              "",
+			Description:            downloadDescription,
+			ArgsUsage:              "",
+			Category:               "",
+			BashComplete:           complete(nil),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 downloadAction(ctx),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdDownloadFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdMatrix,
+			Aliases:                nil,
+			Usage:                  "Runs solution across inputs of several accounts",
+			UsageText:              "",
+			Description:            matrixDescription,
+			ArgsUsage:              "<year> <day>",
+			Category:               "",
+			BashComplete:           complete(completeDate),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 matrixAction(ctx),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdMatrixFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdStats,
+			Aliases:                nil,
+			Usage:                  "Charts personal solve times and ranks per year",
+			UsageText:              "",
+			Description:            statsDescription,
+			ArgsUsage:              "",
+			Category:               "",
+			BashComplete:           complete(nil),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 statsAction(ctx),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  cmdStatsFlags(),
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:         cmdSession,
+			Aliases:      nil,
+			Usage:        "Manages AOC session profiles",
+			UsageText:    "",
+			Description:  sessionDescription,
+			ArgsUsage:    "",
+			Category:     "",
+			BashComplete: nil,
+			Before:       nil,
+			After:        nil,
+			Action:       nil,
+			OnUsageError: nil,
+			Subcommands: []*cli.Command{
+				{
+					Name:                   cmdLogin,
+					Aliases:                nil,
+					Usage:                  "Validates session token and stores it in profile",
+					UsageText:              "",
+					Description:            "",
+					ArgsUsage:              "",
+					Category:               "",
+					BashComplete:           complete(nil),
+					Before:                 nil,
+					After:                  nil,
+					Action:                 sessionLoginAction(ctx),
+					OnUsageError:           nil,
+					Subcommands:            nil,
+					Flags:                  cmdSessionLoginFlags(),
+					SkipFlagParsing:        false,
+					HideHelp:               false,
+					HideHelpCommand:        false,
+					Hidden:                 false,
+					UseShortOptionHandling: false,
+					HelpName:               "",
+					CustomHelpTemplate:     "",
+				},
+				{
+					Name:                   cmdStatus,
+					Aliases:                nil,
+					Usage:                  "Shows which account the session belongs to",
+					UsageText:              "",
+					Description:            "",
+					ArgsUsage:              "",
+					Category:               "",
+					BashComplete:           complete(nil),
+					Before:                 nil,
+					After:                  nil,
+					Action:                 sessionStatusAction(ctx),
+					OnUsageError:           nil,
+					Subcommands:            nil,
+					Flags:                  sessionFlags(),
+					SkipFlagParsing:        false,
+					HideHelp:               false,
+					HideHelpCommand:        false,
+					Hidden:                 false,
+					UseShortOptionHandling: false,
+					HelpName:               "",
+					CustomHelpTemplate:     "",
+				},
+				{
+					Name:                   cmdList,
+					Aliases:                []string{"ls"},
+					Usage:                  "Lists stored profiles, default one is marked with *",
+					UsageText:              "",
+					Description:            "",
+					ArgsUsage:              "",
+					Category:               "",
+					BashComplete:           complete(nil),
+					Before:                 nil,
+					After:                  nil,
+					Action:                 sessionListAction(ctx),
+					OnUsageError:           nil,
+					Subcommands:            nil,
+					Flags:                  nil,
+					SkipFlagParsing:        false,
+					HideHelp:               false,
+					HideHelpCommand:        false,
+					Hidden:                 false,
+					UseShortOptionHandling: false,
+					HelpName:               "",
+					CustomHelpTemplate:     "",
+				},
+				{
+					Name:                   cmdUse,
+					Aliases:                nil,
+					Usage:                  "Makes profile default",
+					UsageText:              "",
+					Description:            "",
+					ArgsUsage:              "<profile>",
+					Category:               "",
+					BashComplete:           complete(completeProfiles),
+					Before:                 nil,
+					After:                  nil,
+					Action:                 sessionUseAction(ctx),
+					OnUsageError:           nil,
+					Subcommands:            nil,
+					Flags:                  nil,
+					SkipFlagParsing:        false,
+					HideHelp:               false,
+					HideHelpCommand:        false,
+					Hidden:                 false,
+					UseShortOptionHandling: false,
+					HelpName:               "",
+					CustomHelpTemplate:     "",
+				},
+				{
+					Name:                   cmdLogout,
+					Aliases:                nil,
+					Usage:                  "Removes stored profile",
+					UsageText:              "",
+					Description:            "",
+					ArgsUsage:              "<profile>",
+					Category:               "",
+					BashComplete:           complete(completeProfiles),
+					Before:                 nil,
+					After:                  nil,
+					Action:                 sessionLogoutAction(ctx),
+					OnUsageError:           nil,
+					Subcommands:            nil,
+					Flags:                  nil,
+					SkipFlagParsing:        false,
+					HideHelp:               false,
+					HideHelpCommand:        false,
+					Hidden:                 false,
+					UseShortOptionHandling: false,
+					HelpName:               "",
+					CustomHelpTemplate:     "",
+				},
+			},
+			Flags:                  nil,
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:                   cmdCompletion,
+			Aliases:                nil,
+			Usage:                  "Prints shell completion script",
+			UsageText:              "",
+			Description:            completionDescription,
+			ArgsUsage:              "<bash|zsh|fish>",
+			Category:               "",
+			BashComplete:           complete(completeShells),
+			Before:                 nil,
+			After:                  nil,
+			Action:                 completionAction(),
+			OnUsageError:           nil,
+			Subcommands:            nil,
+			Flags:                  nil,
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:         cmdConfig,
+			Aliases:      nil,
+			Usage:        "Manages config file with defaults of options",
+			UsageText:    "",
+			Description:  configDescription,
+			ArgsUsage:    "",
+			Category:     "",
+			BashComplete: nil,
+			Before:       nil,
+			After:        nil,
+			Action:       nil,
+			OnUsageError: nil,
+			Subcommands: []*cli.Command{
+				{
+					Name:                   cmdPath,
+					Aliases:                nil,
+					Usage:                  "Prints path of config file",
+					UsageText:              "",
+					Description:            "",
+					ArgsUsage:              "",
+					Category:               "",
+					BashComplete:           complete(nil),
+					Before:                 nil,
+					After:                  nil,
+					Action:                 configPathAction(ctx),
+					OnUsageError:           nil,
+					Subcommands:            nil,
+					Flags:                  nil,
+					SkipFlagParsing:        false,
+					HideHelp:               false,
+					HideHelpCommand:        false,
+					Hidden:                 false,
+					UseShortOptionHandling: false,
+					HelpName:               "",
+					CustomHelpTemplate:     "",
+				},
+				{
+					Name:                   cmdGet,
+					Aliases:                nil,
+					Usage:                  "Prints config values, or value of the key",
+					UsageText:              "",
+					Description:            "",
+					ArgsUsage:              "[<key>]",
+					Category:               "",
+					BashComplete:           complete(completeConfigKeys),
+					Before:                 nil,
+					After:                  nil,
+					Action:                 configGetAction(ctx),
+					OnUsageError:           nil,
+					Subcommands:            nil,
+					Flags:                  nil,
+					SkipFlagParsing:        false,
+					HideHelp:               false,
+					HideHelpCommand:        false,
+					Hidden:                 false,
+					UseShortOptionHandling: false,
+					HelpName:               "",
+					CustomHelpTemplate:     "",
+				},
+				{
+					Name:                   cmdSet,
+					Aliases:                nil,
+					Usage:                  "Sets config value of the key, empty value unsets it",
+					UsageText:              "",
+					Description:            "",
+					ArgsUsage:              "<key> [<value>]",
+					Category:               "",
+					BashComplete:           complete(completeConfigKeys),
+					Before:                 nil,
+					After:                  nil,
+					Action:                 configSetAction(ctx),
+					OnUsageError:           nil,
+					Subcommands:            nil,
+					Flags:                  nil,
+					SkipFlagParsing:        false,
+					HideHelp:               false,
+					HideHelpCommand:        false,
+					Hidden:                 false,
+					UseShortOptionHandling: false,
+					HelpName:               "",
+					CustomHelpTemplate:     "",
+				},
+			},
+			Flags:                  nil,
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
+		{
+			Name:         cmdVault,
+			Aliases:      nil,
+			Usage:        "Encrypts puzzle inputs to store them in repository",
+			UsageText:    "",
+			Description:  vaultDescription,
+			ArgsUsage:    "",
+			Category:     "",
+			BashComplete: nil,
+			Before:       nil,
+			After:        nil,
+			Action:       nil,
+			OnUsageError: nil,
+			Subcommands: []*cli.Command{
+				{
+					Name:                   cmdKeygen,
+					Aliases:                nil,
+					Usage:                  "Generates new vault key",
+					UsageText:              "",
+					Description:            "",
+					ArgsUsage:              "",
+					Category:               "",
+					BashComplete:           complete(nil),
+					Before:                 nil,
+					After:                  nil,
+					Action:                 vaultKeygenAction(ctx),
+					OnUsageError:           nil,
+					Subcommands:            nil,
+					Flags:                  nil,
+					SkipFlagParsing:        false,
+					HideHelp:               false,
+					HideHelpCommand:        false,
+					Hidden:                 false,
+					UseShortOptionHandling: false,
+					HelpName:               "",
+					CustomHelpTemplate:     "",
+				},
+				{
+					Name:                   cmdEncrypt,
+					Aliases:                nil,
+					Usage:                  "Encrypts files and writes them next to originals with .enc extension",
+					UsageText:              "",
+					Description:            "",
+					ArgsUsage:              "<file>...",
+					Category:               "",
+					BashComplete:           complete(nil),
+					Before:                 nil,
+					After:                  nil,
+					Action:                 vaultEncryptAction(ctx),
+					OnUsageError:           nil,
+					Subcommands:            nil,
+					Flags:                  nil,
+					SkipFlagParsing:        false,
+					HideHelp:               false,
+					HideHelpCommand:        false,
+					Hidden:                 false,
+					UseShortOptionHandling: false,
+					HelpName:               "",
+					CustomHelpTemplate:     "",
+				},
+				{
+					Name:                   cmdDecrypt,
+					Aliases:                nil,
+					Usage:                  "Decrypts file and prints it",
+					UsageText:              "",
+					Description:            "",
+					ArgsUsage:              "<file.enc>",
+					Category:               "",
+					BashComplete:           complete(nil),
+					Before:                 nil,
+					After:                  nil,
+					Action:                 vaultDecryptAction(ctx),
+					OnUsageError:           nil,
+					Subcommands:            nil,
+					Flags:                  nil,
+					SkipFlagParsing:        false,
+					HideHelp:               false,
+					HideHelpCommand:        false,
+					Hidden:                 false,
+					UseShortOptionHandling: false,
+					HelpName:               "",
+					CustomHelpTemplate:     "",
+				},
+			},
+			Flags:                  nil,
+			SkipFlagParsing:        false,
+			HideHelp:               false,
+			HideHelpCommand:        false,
+			Hidden:                 false,
+			UseShortOptionHandling: false,
+			HelpName:               "",
+			CustomHelpTemplate:     "",
+		},
 	}
 
 	return cmds
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+const (
+	// cmdCompletion is declared at package level as banner and exit message are not printed for it:
+	// its output is sourced by shell.
+	cmdCompletion = "completion"
+
+	shellBash = "bash"
+	shellZsh  = "zsh"
+	shellFish = "fish"
+
+	// completionFlag is passed by completion scripts as the last argument.
+	completionFlag = "--generate-bash-completion"
+)
+
+var errUnknownShell = errors.New("unknown shell")
+
+// Completion scripts call the binary with arguments typed so far and completionFlag,
+// so years, days and profiles are completed from the registry and the sessions config of the installed version.
+// Placeholders: %[1]s is a program name, %[2]s is a name of shell function.
+const (
+	bashCompletion = `# bash completion for %[1]s, generated by: %[1]s completion bash
+
+_%[2]s_complete() {
+  local cur opts
+  COMPREPLY=()
+  cur="${COMP_WORDS[COMP_CWORD]}"
+  if [[ "$cur" == "-"* ]]; then
+    opts=$( "${COMP_WORDS[@]:0:$COMP_CWORD}" "${cur}" ` + completionFlag + ` 2>/dev/null )
+  else
+    opts=$( "${COMP_WORDS[@]:0:$COMP_CWORD}" ` + completionFlag + ` 2>/dev/null )
+  fi
+  COMPREPLY=( $(compgen -W "${opts}" -- "${cur}") )
+  return 0
+}
+
+complete -o bashdefault -o default -F _%[2]s_complete %[1]s
+`
+
+	zshCompletion = `#compdef %[1]s
+# zsh completion for %[1]s, generated by: %[1]s completion zsh
+
+_%[2]s_complete() {
+  local -a opts
+  local cur
+  cur=${words[-1]}
+  if [[ "$cur" == "-"* ]]; then
+    opts=("${(@f)$(${words[@]:0:#words[@]-1} "${cur}" ` + completionFlag + ` 2>/dev/null)}")
+  else
+    opts=("${(@f)$(${words[@]:0:#words[@]-1} ` + completionFlag + ` 2>/dev/null)}")
+  fi
+
+  if [[ "${opts[1]}" != "" ]]; then
+    compadd -a opts
+  else
+    _files
+  fi
+}
+
+compdef _%[2]s_complete %[1]s
+`
+
+	fishCompletion = `# fish completion for %[1]s, generated by: %[1]s completion fish
+
+function __%[2]s_complete
+    set -l args (commandline -opc)
+    set -l cur (commandline -ct)
+    if string match -q -- '-*' $cur
+        set -a args $cur
+    end
+    $args ` + completionFlag + ` 2>/dev/null
+end
+
+complete -c %[1]s -f -a '(__%[2]s_complete)'
+`
+)
+
+func completionAction() cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("%w: expected one of %s, %s, %s", errUnknownShell, shellBash, shellZsh, shellFish)
+		}
+
+		script, err := completionScript(c.Args().First(), c.App.Name)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprint(c.App.Writer, script)
+
+		return err
+	}
+}
+
+// completionScript returns completion script of the shell for the program.
+func completionScript(shell, name string) (string, error) {
+	var tmpl string
+
+	switch shell {
+	case shellBash:
+		tmpl = bashCompletion
+	case shellZsh:
+		tmpl = zshCompletion
+	case shellFish:
+		tmpl = fishCompletion
+	default:
+		return "", fmt.Errorf("%w: %q", errUnknownShell, shell)
+	}
+
+	fn := strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+
+		return r
+	}, name)
+
+	return fmt.Sprintf(tmpl, name, fn), nil
+}
+
+// complete returns completion of command: values of --year, --day and --profile flags when one of them is typed
+// before, flags when dash is typed, and positional arguments by args otherwise (nil when command takes no arguments).
+func complete(args func(args []string) []string) cli.BashCompleteFunc {
+	return func(c *cli.Context) {
+		var values []string
+
+		switch last := lastArg(); last {
+		case "--" + flagYear, "-" + flagShortYear:
+			values = puzzles.GetYears()
+		case "--" + flagDay:
+			values = puzzles.DaysByYear(c.String(flagYear))
+		case "--" + flagProfile, "-" + flagShortProfile:
+			values = completeProfiles(nil)
+		default:
+			if args == nil || strings.HasPrefix(last, "-") {
+				cli.DefaultCompleteWithFlags(c.Command)(c)
+
+				return
+			}
+
+			values = args(c.Args().Slice())
+		}
+
+		for _, v := range values {
+			if _, err := fmt.Fprintln(c.App.Writer, v); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// lastArg returns argument typed before the completed one.
+func lastArg() string {
+	if len(os.Args) < 3 || os.Args[len(os.Args)-1] != completionFlag {
+		return ""
+	}
+
+	return os.Args[len(os.Args)-2]
+}
+
+// completeDate completes <year> <day> arguments of registered puzzles.
+func completeDate(args []string) []string {
+	switch len(args) {
+	case 0:
+		return puzzles.GetYears()
+	case 1:
+		return puzzles.DaysByYear(args[0])
+	default:
+		return nil
+	}
+}
+
+// completeDatePath completes <year>/<day> argument of registered puzzles.
+func completeDatePath(args []string) []string {
+	if len(args) != 0 {
+		return nil
+	}
+
+	var res []string
+
+	for _, year := range puzzles.GetYears() {
+		for _, day := range puzzles.DaysByYear(year) {
+			res = append(res, year+"/"+day)
+		}
+	}
+
+	return res
+}
+
+// completeNewDate completes <year> <day> arguments of puzzles not registered yet.
+func completeNewDate(args []string) []string {
+	const days = 25
+
+	switch len(args) {
+	case 0:
+		years := puzzles.GetYears()
+
+		if latest := input.LatestEvent(time.Now()); !slices.Contains(years, latest) {
+			years = append(years, latest)
+		}
+
+		return years
+	case 1:
+		registered := puzzles.DaysByYear(args[0])
+
+		var res []string
+
+		for day := 1; day <= days; day++ {
+			if d := strconv.Itoa(day); !slices.Contains(registered, d) {
+				res = append(res, d)
+			}
+		}
+
+		return res
+	default:
+		return nil
+	}
+}
+
+// completeShells completes <shell> argument of completion command.
+func completeShells(args []string) []string {
+	if len(args) != 0 {
+		return nil
+	}
+
+	return []string{shellBash, shellZsh, shellFish}
+}
+
+// completeProfiles completes <profile> argument with stored session profiles.
+func completeProfiles(args []string) []string {
+	if len(args) != 0 {
+		return nil
+	}
+
+	store, err := loadSessions()
+	if err != nil {
+		return nil
+	}
+
+	return store.Names()
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_completionScript(t *testing.T) {
+	for _, shell := range []string{shellBash, shellZsh, shellFish} {
+		t.Run(shell, func(t *testing.T) {
+			script, err := completionScript(shell, "aoc-cli")
+			require.NoError(t, err)
+
+			assert.Contains(t, script, "_aoc_cli_complete")
+			assert.Contains(t, script, completionFlag)
+			assert.NotContains(t, script, "%!")
+		})
+	}
+
+	_, err := completionScript("powershell", "aoc-cli")
+	assert.ErrorIs(t, err, errUnknownShell)
+}
+
+func Test_completeDate(t *testing.T) {
+	assert.Contains(t, completeDate(nil), "2021")
+	assert.Contains(t, completeDate([]string{"2021"}), "5")
+	assert.Empty(t, completeDate([]string{"1999"}))
+	assert.Empty(t, completeDate([]string{"2021", "5"}))
+
+	assert.Contains(t, completeDatePath(nil), "2021/5")
+	assert.Empty(t, completeDatePath([]string{"2021/5"}))
+}
+
+func Test_completeNewDate(t *testing.T) {
+	days := completeNewDate([]string{"2021"})
+
+	assert.NotContains(t, days, "1", "registered day is not completed")
+	assert.Contains(t, days, "25")
+	assert.Len(t, completeNewDate([]string{"1999"}), 25)
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	log "github.com/obalunenko/logger"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/config"
+)
+
+// cmdConfig is declared at package level as config file is not applied to it,
+// so invalid value could be fixed by config set.
+const cmdConfig = "config"
+
+// configPath returns path of config file: from AOC_CONFIG env when set, default path otherwise.
+func configPath() (string, error) {
+	if path := os.Getenv(envConfig); path != "" {
+		return path, nil
+	}
+
+	return config.DefaultPath()
+}
+
+func loadConfig() (*config.Config, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// applyConfig sets values of config file as defaults of command flags before they are parsed,
+// so flag and env variable take precedence over config, and config over built-in defaults.
+func applyConfig(_ context.Context) cli.BeforeFunc {
+	return func(c *cli.Context) error {
+		if c.Args().First() == cmdConfig {
+			return nil
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		if err = cfg.Validate(); err != nil {
+			return err
+		}
+
+		setFlagDefaults(c.App.Commands, cfg)
+
+		return nil
+	}
+}
+
+func setFlagDefaults(cmds []*cli.Command, cfg *config.Config) {
+	for _, cmd := range cmds {
+		// Year alone is not a valid date for commands taking --year with --day.
+		withDay := slices.ContainsFunc(cmd.Flags, func(f cli.Flag) bool {
+			return slices.Contains(f.Names(), flagDay)
+		})
+
+		for _, f := range cmd.Flags {
+			setFlagDefault(f, cfg, withDay)
+		}
+
+		setFlagDefaults(cmd.Subcommands, cfg)
+	}
+}
+
+func setFlagDefault(f cli.Flag, cfg *config.Config, withDay bool) {
+	switch f := f.(type) {
+	case *cli.StringFlag:
+		var value string
+
+		switch f.Name {
+		case flagFormat:
+			value = cfg.Format
+		case flagCacheDir:
+			value = cfg.CacheDir
+		case flagProfile:
+			value = cfg.Profile
+		case flagTemplates:
+			value = cfg.Templates
+		case flagYear:
+			if !withDay {
+				value = cfg.Year
+			}
+		}
+
+		if value != "" {
+			f.Value = value
+			f.DefaultText = ""
+		}
+	case *cli.StringSliceFlag:
+		if f.Name == flagYear && cfg.Year != "" {
+			f.Value = cli.NewStringSlice(cfg.Year)
+			f.DefaultText = ""
+		}
+	case *cli.BoolFlag:
+		if (f.Name == flagElapsed && slices.Contains(cfg.Metrics, config.MetricElapsed)) ||
+			(f.Name == flagBenchmark && slices.Contains(cfg.Metrics, config.MetricBench)) {
+			f.Value = true
+		}
+	case *cli.DurationFlag:
+		if f.Name == flagTimeout && cfg.Timeout != 0 {
+			f.Value = cfg.Timeout
+		}
+	}
+}
+
+func configPathAction(_ context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		path, err := configPath()
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(c.App.Writer, path)
+
+		return err
+	}
+}
+
+func configGetAction(_ context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		if c.NArg() > 1 {
+			return fmt.Errorf("expected at most 1 argument, got %d", c.NArg())
+		}
+
+		if c.NArg() == 1 {
+			value, err := cfg.Get(c.Args().First())
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(c.App.Writer, value)
+
+			return err
+		}
+
+		for _, key := range config.Keys() {
+			value, err := cfg.Get(key)
+			if err != nil {
+				return err
+			}
+
+			if _, err = fmt.Fprintf(c.App.Writer, "%s: %s\n", key, value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func configSetAction(ctx context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.NArg() != 1 && c.NArg() != 2 {
+			return fmt.Errorf("expected <key> [<value>] arguments, got %d", c.NArg())
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		key, value := c.Args().Get(0), c.Args().Get(1)
+
+		if err = cfg.Set(key, value); err != nil {
+			return err
+		}
+
+		if err = cfg.Save(); err != nil {
+			return fmt.Errorf("save config: %w", err)
+		}
+
+		log.WithFields(ctx, log.Fields{
+			"key":   key,
+			"value": value,
+			"file":  cfg.Path(),
+		}).Info("Config saved")
+
+		return nil
+	}
+}
+
+// completeConfigKeys completes <key> argument of config commands.
+func completeConfigKeys(args []string) []string {
+	if len(args) != 0 {
+		return nil
+	}
+
+	return config.Keys()
+}
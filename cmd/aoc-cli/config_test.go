@@ -0,0 +1,128 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/config"
+)
+
+func Test_setFlagDefaults(t *testing.T) {
+	cfg := config.Config{
+		Format:    "json",
+		Metrics:   []string{config.MetricElapsed},
+		CacheDir:  "/tmp/aoc",
+		Timeout:   time.Minute,
+		Year:      "2021",
+		Profile:   "",
+		Templates: "",
+	}
+
+	type values struct {
+		years    []string
+		year     string
+		format   string
+		elapsed  bool
+		bench    bool
+		timeout  time.Duration
+		cacheDir string
+	}
+
+	run := func(t *testing.T, args ...string) values {
+		t.Helper()
+
+		var got values
+
+		action := func(c *cli.Context) error {
+			got = values{
+				years:    c.StringSlice(flagYear),
+				year:     "",
+				format:   c.String(flagFormat),
+				elapsed:  false,
+				bench:    false,
+				timeout:  c.Duration(flagTimeout),
+				cacheDir: "",
+			}
+
+			return nil
+		}
+
+		cmds := []*cli.Command{
+			{
+				Name:   "all",
+				Action: action,
+				Flags:  cmdAllFlags(),
+			},
+			{
+				Name: "run",
+				Action: func(c *cli.Context) error {
+					got.elapsed, got.bench = c.Bool(flagElapsed), c.Bool(flagBenchmark)
+					got.format = c.String(flagFormat)
+
+					return nil
+				},
+				Flags: cmdRunFlags(),
+			},
+			{
+				Name: "solve",
+				Action: func(c *cli.Context) error {
+					got.year = c.String(flagYear)
+
+					return nil
+				},
+				Flags: cmdSolveFlags(),
+			},
+			{
+				Name: "download",
+				Action: func(c *cli.Context) error {
+					got.cacheDir = c.String(flagCacheDir)
+
+					return nil
+				},
+				Flags: cmdDownloadFlags(),
+			},
+		}
+
+		setFlagDefaults(cmds, &cfg)
+
+		app := cli.NewApp()
+		app.Commands = cmds
+
+		require.NoError(t, app.Run(append([]string{"aoc-cli"}, args...)))
+
+		return got
+	}
+
+	assert.Equal(t, values{
+		years:    []string{"2021"},
+		year:     "",
+		format:   "json",
+		elapsed:  false,
+		bench:    false,
+		timeout:  time.Minute,
+		cacheDir: "",
+	}, run(t, "all"), "config values are used as defaults")
+
+	assert.Equal(t, values{
+		years:    []string{"2020"},
+		year:     "",
+		format:   "csv",
+		elapsed:  false,
+		bench:    false,
+		timeout:  time.Second,
+		cacheDir: "",
+	}, run(t, "all", "--year", "2020", "--format", "csv", "--timeout", "1s"), "flags take precedence over config")
+
+	got := run(t, "run")
+	assert.True(t, got.elapsed)
+	assert.False(t, got.bench)
+	assert.Equal(t, "json", got.format, "interactive menu prints results in format from config")
+
+	assert.Empty(t, run(t, "solve").year, "year is not set for commands taking --day")
+
+	assert.Equal(t, "/tmp/aoc", run(t, "download").cacheDir, "download fills inputs cache from config")
+}
@@ -1,9 +1,17 @@
 package main
 
 import (
+	"runtime"
+	"strings"
+
 	"github.com/urfave/cli/v2"
 
+	"github.com/obalunenko/advent-of-code/internal/command"
+	"github.com/obalunenko/advent-of-code/internal/download"
 	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/server"
+	"github.com/obalunenko/advent-of-code/internal/watch"
 )
 
 const (
@@ -13,8 +21,118 @@
 	flagShortBenchmark = "b"
 	flagSession        = "session"
 	flagShortSession   = "s"
+	flagRefresh        = "refresh"
+	flagShortRefresh   = "r"
+	flagDir            = "dir"
+	flagShortDir       = "d"
+	flagWait           = "wait"
+	flagShortWait      = "w"
+	flagID             = "id"
+	flagYear           = "year"
+	flagShortYear      = "y"
+	flagCompare        = "compare"
+	flagShortCompare   = "c"
+	flagCacheDir       = "cache-dir"
+	flagProfile        = "profile"
+	flagShortProfile   = "p"
+	flagDefault        = "default"
+	flagBaseURL        = "base-url"
+	flagNoCache        = "no-cache"
+	flagInterval       = "interval"
+	flagAccount        = "account"
+	flagShortAccount   = "a"
+	flagAnswers        = "answers"
+	flagFile           = "file"
+	flagShortFile      = "f"
+	flagDay            = "day"
+	flagQuiet          = "quiet"
+	flagShortQuiet     = "q"
+	flagTag            = "tag"
+	flagShortTag       = "t"
+	flagWorkers        = "workers"
+	flagTimeout        = "timeout"
+	flagFormat         = "format"
+	flagShortFormat    = "o"
+	flagJUnit          = "junit"
+	flagEvents         = "events"
+	flagRoot           = "root"
+	flagTemplates      = "templates"
+	flagOverwrite      = "overwrite"
+	flagAddr           = "addr"
+	flagMaxInputSize   = "max-input-size"
+
+	defaultSolutionsDir = "internal/puzzles/solutions"
+
+	// envLeaderboardID env variable name for private leaderboard id.
+	envLeaderboardID = "AOC_LEADERBOARD_ID"
+	// envProfile env variable name for session profile.
+	envProfile = "AOC_PROFILE"
+	// envBaseURL env variable name for base URL of the site.
+	envBaseURL = "AOC_BASE_URL"
+	// envTemplates env variable name for directory of user templates.
+	envTemplates = "AOC_TEMPLATES_DIR"
+	// envConfig env variable name for path of config file.
+	envConfig = "AOC_CONFIG"
 )
 
+func globalFlags() []cli.Flag {
+	baseURL := cli.StringFlag{
+		Name:        flagBaseURL,
+		Aliases:     nil,
+		Usage:       "Base URL of Advent of Code site, e.g. local fake server",
+		EnvVars:     []string{envBaseURL},
+		FilePath:    "",
+		Required:    false,
+		Hidden:      true,
+		TakesFile:   false,
+		Value:       input.DefaultBaseURL,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	quiet := cli.BoolFlag{
+		Name:        flagQuiet,
+		Aliases:     []string{flagShortQuiet},
+		Usage:       "Does not print banner and exit message, e.g. for scripts",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		Value:       false,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	return []cli.Flag{&baseURL, &quiet}
+}
+
+// formatFlags returns flag of output format for commands printing results.
+func formatFlags() []cli.Flag {
+	format := cli.StringFlag{
+		Name:        flagFormat,
+		Aliases:     []string{flagShortFormat},
+		Usage:       "Output format: " + strings.Join(command.Formats(), ", "),
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       string(command.FormatTable),
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	return []cli.Flag{&format}
+}
+
+// inputOptions returns options of site fetchers set by global flags.
+func inputOptions(c *cli.Context) []input.Option {
+	return []input.Option{input.WithBaseURL(c.String(flagBaseURL))}
+}
+
 func cmdRunFlags() []cli.Flag {
 	var res []cli.Flag
 
@@ -46,22 +164,795 @@ func cmdRunFlags() []cli.Flag {
 		HasBeenSet:  false,
 	}
 
+	wait := cli.BoolFlag{
+		Name:        flagWait,
+		Aliases:     []string{flagShortWait},
+		Usage:       "Waits for puzzle unlock showing countdown, and solves it right after",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		Value:       false,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	res = append(res, &elapsed, &benchmark, &wait)
+	res = append(res, formatFlags()...)
+	res = append(res, inputCacheFlags()...)
+	res = append(res, sessionFlags()...)
+
+	return res
+}
+
+func cmdSolveFlags() []cli.Flag {
+	var res []cli.Flag
+
+	year := cli.StringFlag{
+		Name:        flagYear,
+		Aliases:     []string{flagShortYear},
+		Usage:       "Puzzle year",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       "",
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	day := cli.StringFlag{
+		Name:        flagDay,
+		Aliases:     nil,
+		Usage:       "Puzzle day",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       "",
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	res = append(res, &year, &day)
+	res = append(res, cmdRunFlags()...)
+
+	return res
+}
+
+func cmdNewFlags() []cli.Flag {
+	var res []cli.Flag
+
+	root := cli.StringFlag{
+		Name:        flagRoot,
+		Aliases:     nil,
+		Usage:       "Path to the repository root",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       ".",
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	res = append(res, &root)
+	res = append(res, templatesFlags()...)
+	res = append(res, sessionFlags()...)
+
+	return res
+}
+
+func cmdWatchFlags() []cli.Flag {
+	var res []cli.Flag
+
+	root := cli.StringFlag{
+		Name:        flagRoot,
+		Aliases:     nil,
+		Usage:       "Path to the repository root",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       ".",
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	interval := cli.DurationFlag{
+		Name:        flagInterval,
+		Aliases:     nil,
+		Usage:       "Interval of polling puzzle files for changes",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		Value:       watch.DefaultInterval,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	res = append(res, &root, &interval)
+	res = append(res, sessionFlags()...)
+	res = append(res, inputCacheFlags()...)
+
+	return res
+}
+
+func cmdServeFlags() []cli.Flag {
+	addr := cli.StringFlag{
+		Name:        flagAddr,
+		Aliases:     nil,
+		Usage:       "Address to listen on",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       "127.0.0.1:8080",
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	workers := cli.IntFlag{
+		Name:        flagWorkers,
+		Aliases:     nil,
+		Usage:       "Number of puzzles solved concurrently, other requests wait for a free slot",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		Base:        0,
+		Value:       runtime.NumCPU(),
+		DefaultText: "number of CPUs",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	timeout := cli.DurationFlag{
+		Name:        flagTimeout,
+		Aliases:     nil,
+		Usage:       "Time limit for solving one puzzle, including waiting for a free slot",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		Value:       server.DefaultTimeout,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	maxInputSize := cli.Int64Flag{
+		Name:        flagMaxInputSize,
+		Aliases:     nil,
+		Usage:       "Limit of puzzle input size in bytes",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		Base:        0,
+		Value:       server.DefaultMaxInputSize,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	res := []cli.Flag{&addr, &workers, &timeout, &maxInputSize}
+
+	res = append(res, sessionFlags()...)
+	res = append(res, inputCacheFlags()...)
+
+	return res
+}
+
+func cmdRPCFlags() []cli.Flag {
+	var res []cli.Flag
+
+	res = append(res, sessionFlags()...)
+	res = append(res, inputCacheFlags()...)
+
+	return res
+}
+
+func cmdReadmeFlags() []cli.Flag {
+	file := cli.StringFlag{
+		Name:        flagFile,
+		Aliases:     []string{flagShortFile},
+		Usage:       "Path to README to update",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       "README.md",
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	return []cli.Flag{&file}
+}
+
+func cmdSpecFlags() []cli.Flag {
+	var res []cli.Flag
+
+	refresh := cli.BoolFlag{
+		Name:        flagRefresh,
+		Aliases:     []string{flagShortRefresh},
+		Usage:       "Rewrites spec.md of the puzzle instead of printing description",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		Value:       false,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	dir := cli.StringFlag{
+		Name:        flagDir,
+		Aliases:     []string{flagShortDir},
+		Usage:       "Path to solutions directory",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       defaultSolutionsDir,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	res = append(res, &refresh, &dir)
+	res = append(res, templatesFlags()...)
+	res = append(res, sessionFlags()...)
+
+	return res
+}
+
+// templatesFlags returns flags to set directory of user templates overriding the embedded ones.
+func templatesFlags() []cli.Flag {
+	dir := cli.StringFlag{
+		Name:        flagTemplates,
+		Aliases:     nil,
+		Usage:       "Path to directory with user templates overriding the embedded ones",
+		EnvVars:     []string{envTemplates},
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       "",
+		DefaultText: "$XDG_CONFIG_HOME/aoc-cli/templates",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	return []cli.Flag{&dir}
+}
+
+func cmdTemplatesExportFlags() []cli.Flag {
+	var res []cli.Flag
+
+	overwrite := cli.BoolFlag{
+		Name:        flagOverwrite,
+		Aliases:     nil,
+		Usage:       "Overwrites already exported templates",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		Value:       false,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	res = append(res, &overwrite)
+	res = append(res, templatesFlags()...)
+
+	return res
+}
+
+// sessionFlags returns flags to pass session explicitly or choose stored session profile.
+func sessionFlags() []cli.Flag {
 	session := cli.StringFlag{
 		Name:        flagSession,
 		Aliases:     []string{flagShortSession},
 		Usage:       "AOC auth session to get inputs",
 		EnvVars:     []string{puzzles.AOCSession},
 		FilePath:    "",
-		Required:    true,
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       "",
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	profile := cli.StringFlag{
+		Name:        flagProfile,
+		Aliases:     []string{flagShortProfile},
+		Usage:       "Stored session profile to use when session is not passed",
+		EnvVars:     []string{envProfile},
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       "",
+		DefaultText: "default profile",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	return []cli.Flag{&session, &profile}
+}
+
+func cmdSessionLoginFlags() []cli.Flag {
+	var res []cli.Flag
+
+	profile := cli.StringFlag{
+		Name:        flagProfile,
+		Aliases:     []string{flagShortProfile},
+		Usage:       "Name of profile to store session in",
+		EnvVars:     []string{envProfile},
+		FilePath:    "",
+		Required:    false,
 		Hidden:      false,
 		TakesFile:   false,
+		Value:       defaultProfile,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	makeDefault := cli.BoolFlag{
+		Name:        flagDefault,
+		Aliases:     nil,
+		Usage:       "Makes profile default",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		Value:       false,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	session := cli.StringFlag{
+		Name:        flagSession,
+		Aliases:     []string{flagShortSession},
+		Usage:       "AOC auth session to store, asked interactively when not set",
+		EnvVars:     []string{puzzles.AOCSession},
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       "",
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	res = append(res, &profile, &makeDefault, &session)
+
+	return res
+}
+
+func cmdLeaderboardFlags() []cli.Flag {
+	var res []cli.Flag
+
+	id := cli.StringFlag{
+		Name:        flagID,
+		Aliases:     nil,
+		Usage:       "Private leaderboard id",
+		EnvVars:     []string{envLeaderboardID},
+		FilePath:    "",
+		Required:    true,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       "",
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	year := cli.StringFlag{
+		Name:        flagYear,
+		Aliases:     []string{flagShortYear},
+		Usage:       "Event year",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       "",
+		DefaultText: "latest event",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	compare := cli.StringSliceFlag{
+		Name:        flagCompare,
+		Aliases:     []string{flagShortCompare},
+		Usage:       "Two members (names or ids) to compare solve times, e.g. --compare Alice,Bob",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       nil,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	cacheDir := cli.StringFlag{
+		Name:        flagCacheDir,
+		Aliases:     nil,
+		Usage:       "Directory to cache leaderboards",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       "",
+		DefaultText: "user cache dir",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	res = append(res, &id, &year, &compare, &cacheDir)
+	res = append(res, sessionFlags()...)
+
+	return res
+}
+
+func cmdDownloadFlags() []cli.Flag {
+	var res []cli.Flag
+
+	dir := cli.StringFlag{
+		Name:        flagDir,
+		Aliases:     []string{flagShortDir},
+		Usage:       "Directory to download inputs to, as <dir>/<year>/day/<day>/input",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       "",
+		DefaultText: "inputs cache",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	years := cli.StringSliceFlag{
+		Name:        flagYear,
+		Aliases:     []string{flagShortYear},
+		Usage:       "Years to download, e.g. --year 2020,2021",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       nil,
+		DefaultText: "all years",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	interval := cli.DurationFlag{
+		Name:        flagInterval,
+		Aliases:     nil,
+		Usage:       "Minimal interval between requests",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		Value:       download.DefaultInterval,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	cacheDir := cli.StringFlag{
+		Name:        flagCacheDir,
+		Aliases:     nil,
+		Usage:       "Inputs cache directory to download to when --dir is not set",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       "",
+		DefaultText: "user cache dir",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	res = append(res, &dir, &years, &interval, &cacheDir)
+	res = append(res, sessionFlags()...)
+
+	return res
+}
+
+func inputCacheFlags() []cli.Flag {
+	cacheDir := cli.StringFlag{
+		Name:        flagCacheDir,
+		Aliases:     nil,
+		Usage:       "Directory to cache inputs, encrypted when vault key is set",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       "",
+		DefaultText: "user cache dir",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	noCache := cli.BoolFlag{
+		Name:        flagNoCache,
+		Aliases:     nil,
+		Usage:       "Disables inputs cache",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		Value:       false,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	return []cli.Flag{&cacheDir, &noCache}
+}
+
+func cmdMatrixFlags() []cli.Flag {
+	var res []cli.Flag
+
+	accounts := cli.StringSliceFlag{
+		Name:        flagAccount,
+		Aliases:     []string{flagShortAccount},
+		Usage:       "Account as name[=source], where source is inputs dir path with separator (./alice), session:<token>, env:<variable> with token or stored profile (name by default)",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       nil,
+		DefaultText: "all stored profiles",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	answers := cli.StringFlag{
+		Name:        flagAnswers,
+		Aliases:     nil,
+		Usage:       "JSON file with known answers by account and puzzle",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       "",
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	res = append(res, &accounts, &answers)
+	res = append(res, formatFlags()...)
+	res = append(res, inputCacheFlags()...)
+
+	return res
+}
+
+func cmdStatsFlags() []cli.Flag {
+	var res []cli.Flag
+
+	years := cli.StringSliceFlag{
+		Name:        flagYear,
+		Aliases:     []string{flagShortYear},
+		Usage:       "Event years, e.g. --year 2020,2021",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       nil,
+		DefaultText: "latest event",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	file := cli.StringFlag{
+		Name:        flagFile,
+		Aliases:     []string{flagShortFile},
+		Usage:       "Saved personal statistics page to parse instead of fetching it",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       "",
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	dir := cli.StringFlag{
+		Name:        flagDir,
+		Aliases:     []string{flagShortDir},
+		Usage:       "Directory to store statistics",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       "",
+		DefaultText: "user cache dir",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	res = append(res, &years, &file, &dir)
+	res = append(res, sessionFlags()...)
+
+	return res
+}
+
+func cmdStatusFlags() []cli.Flag {
+	var res []cli.Flag
+
+	years := cli.StringSliceFlag{
+		Name:        flagYear,
+		Aliases:     []string{flagShortYear},
+		Usage:       "Event years to show, e.g. --year 2020,2021",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       nil,
+		DefaultText: "all registered years",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	res = append(res, &years)
+	res = append(res, sessionFlags()...)
+
+	return res
+}
+
+func cmdAllFlags() []cli.Flag {
+	var res []cli.Flag
+
+	years := cli.StringSliceFlag{
+		Name:        flagYear,
+		Aliases:     []string{flagShortYear},
+		Usage:       "Years to run, e.g. --year 2020,2021",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       nil,
+		DefaultText: "all years",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	tags := cli.StringSliceFlag{
+		Name:        flagTag,
+		Aliases:     []string{flagShortTag},
+		Usage:       "Run only puzzles having any of tags, e.g. --tag slow",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   false,
+		Value:       nil,
+		DefaultText: "all puzzles",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	workers := cli.IntFlag{
+		Name:        flagWorkers,
+		Aliases:     nil,
+		Usage:       "Number of puzzles solved concurrently",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		Base:        0,
+		Value:       runtime.NumCPU(),
+		DefaultText: "number of CPUs",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	timeout := cli.DurationFlag{
+		Name:        flagTimeout,
+		Aliases:     nil,
+		Usage:       "Time limit for solving one puzzle",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		Value:       command.DefaultBatchTimeout,
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	dir := cli.StringFlag{
+		Name:        flagDir,
+		Aliases:     []string{flagShortDir},
+		Usage:       "Directory with inputs as <dir>/<year>/day/<day>/input, e.g. filled by download command",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       "",
+		DefaultText: "fetch inputs",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	junit := cli.StringFlag{
+		Name:        flagJUnit,
+		Aliases:     nil,
+		Usage:       "Writes JUnit XML report with a testcase per puzzle part to file, '-' for stdout",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
+		Value:       "",
+		DefaultText: "",
+		Destination: nil,
+		HasBeenSet:  false,
+	}
+
+	events := cli.StringFlag{
+		Name:        flagEvents,
+		Aliases:     nil,
+		Usage:       "Writes NDJSON stream of start, finish and error events to file, '-' for stdout",
+		EnvVars:     nil,
+		FilePath:    "",
+		Required:    false,
+		Hidden:      false,
+		TakesFile:   true,
 		Value:       "",
 		DefaultText: "",
 		Destination: nil,
 		HasBeenSet:  false,
 	}
 
-	res = append(res, &elapsed, &benchmark, &session)
+	res = append(res, &years, &tags, &workers, &timeout, &dir, &junit, &events)
+	res = append(res, formatFlags()...)
+	res = append(res, inputCacheFlags()...)
+	res = append(res, sessionFlags()...)
 
 	return res
 }
@@ -4,6 +4,7 @@
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"text/tabwriter"
@@ -17,11 +18,33 @@
 	"github.com/urfave/cli/v2"
 
 	"github.com/obalunenko/advent-of-code/internal/command"
+	"github.com/obalunenko/advent-of-code/internal/download"
+	"github.com/obalunenko/advent-of-code/internal/leaderboard"
 	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/solutions"
+	"github.com/obalunenko/advent-of-code/internal/vault"
 )
 
+// beforeAll runs funcs in order until the first error.
+func beforeAll(funcs ...cli.BeforeFunc) cli.BeforeFunc {
+	return func(c *cli.Context) error {
+		for _, f := range funcs {
+			if err := f(c); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
 func onExit(_ context.Context) cli.AfterFunc {
-	return func(_ *cli.Context) error {
+	return func(c *cli.Context) error {
+		if isQuiet(c) {
+			return nil
+		}
+
 		fmt.Println("Exit...")
 
 		return nil
@@ -37,6 +60,10 @@ func printHeader(_ context.Context) cli.BeforeFunc {
 	)
 
 	return func(c *cli.Context) error {
+		if isQuiet(c) {
+			return nil
+		}
+
 		w := tabwriter.NewWriter(c.App.Writer, minWidth, tabWidth, padding, padChar, tabwriter.TabIndent)
 
 		_, err := fmt.Fprintf(w, `
@@ -57,6 +84,11 @@ func printHeader(_ context.Context) cli.BeforeFunc {
 	}
 }
 
+// isQuiet reports whether banner and exit message should not be printed.
+func isQuiet(c *cli.Context) bool {
+	return c.Bool(flagQuiet) || c.Args().First() == cmdCompletion || c.Args().First() == cmdRPC
+}
+
 func notFound(ctx context.Context) cli.CommandNotFoundFunc {
 	return func(c *cli.Context, command string) {
 		if _, err := fmt.Fprintf(
@@ -71,8 +103,17 @@ func notFound(ctx context.Context) cli.CommandNotFoundFunc {
 
 func menu(ctx context.Context) cli.ActionFunc {
 	return func(c *cli.Context) error {
-		ctx = command.ContextWithOptions(ctx, optionsFromCli(c)...)
-		ctx = command.ContextWithSession(ctx, sessionFromCli(c))
+		format, err := command.ParseFormat(c.String(flagFormat))
+		if err != nil {
+			return err
+		}
+
+		ctx, err := runContext(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		ctx = contextWithFormat(ctx, format)
 
 		years := puzzles.GetYears()
 
@@ -99,6 +140,140 @@ func menu(ctx context.Context) cli.ActionFunc {
 	}
 }
 
+func specAction(ctx context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		year, day, err := dateFromArgs(c)
+		if err != nil {
+			return err
+		}
+
+		sess, err := resolveSession(c, false)
+		if err != nil {
+			return err
+		}
+
+		tmplDir, err := templatesDir(c)
+		if err != nil {
+			return err
+		}
+
+		var unlocked bool
+
+		if c.Bool(flagRefresh) {
+			unlocked, err = solutions.RefreshSpec(ctx, c.String(flagDir), tmplDir, year, day, sess, inputOptions(c)...)
+			if err != nil {
+				return fmt.Errorf("refresh spec: %w", err)
+			}
+
+			log.WithField(ctx, "puzzle", getURL(year, day)).Info("Spec refreshed")
+		} else {
+			var content []byte
+
+			content, unlocked, err = solutions.FetchSpec(ctx, tmplDir, year, day, sess, inputOptions(c)...)
+			if err != nil {
+				return fmt.Errorf("fetch spec: %w", err)
+			}
+
+			if _, err = fmt.Fprint(c.App.Writer, string(content)); err != nil {
+				return fmt.Errorf("print spec: %w", err)
+			}
+		}
+
+		if !unlocked {
+			log.Warn(ctx, "Part two is not unlocked yet: solve part one and refresh spec again")
+		}
+
+		return nil
+	}
+}
+
+func leaderboardAction(ctx context.Context) cli.ActionFunc {
+	const timeout = time.Second * 30
+
+	return func(c *cli.Context) error {
+		year := c.String(flagYear)
+		if year == "" {
+			year = input.LatestEvent(time.Now())
+		}
+
+		dir := c.String(flagCacheDir)
+		if dir == "" {
+			var err error
+
+			dir, err = leaderboard.DefaultCacheDir()
+			if err != nil {
+				return err
+			}
+		}
+
+		client := leaderboard.NewClient(input.NewLeaderboardFetcher(http.DefaultClient, timeout, inputOptions(c)...), dir)
+
+		sess, err := resolveSession(c, true)
+		if err != nil {
+			return err
+		}
+
+		lb, updated, err := client.Get(ctx, year, c.String(flagID), sess)
+		if err != nil {
+			if errors.Is(err, input.ErrUnauthorized) || errors.Is(err, leaderboard.ErrInvalidResponse) {
+				fmt.Println(termlink.Link("Authorize here", loginURL))
+			}
+
+			return fmt.Errorf("get leaderboard: %w", err)
+		}
+
+		w := c.App.Writer
+
+		if _, err = fmt.Fprintf(w, "Private leaderboard %s [%s], updated at %s\n\n",
+			c.String(flagID), lb.Event, updated.Format(time.DateTime)); err != nil {
+			return fmt.Errorf("print header: %w", err)
+		}
+
+		if err = leaderboard.Render(w, lb); err != nil {
+			return fmt.Errorf("render leaderboard: %w", err)
+		}
+
+		compare := c.StringSlice(flagCompare)
+		if len(compare) == 0 {
+			return nil
+		}
+
+		const membersNum = 2
+
+		if len(compare) != membersNum {
+			return fmt.Errorf("expected two members to compare, got %d", len(compare))
+		}
+
+		members := make([]leaderboard.Member, 0, membersNum)
+
+		for _, name := range compare {
+			m, ok := lb.Member(strings.TrimSpace(name))
+			if !ok {
+				return fmt.Errorf("member %q not found", name)
+			}
+
+			members = append(members, m)
+		}
+
+		if _, err = fmt.Fprintln(w); err != nil {
+			return fmt.Errorf("print line: %w", err)
+		}
+
+		return leaderboard.RenderDeltas(w, lb, members[0], members[1])
+	}
+}
+
+// dateFromArgs returns puzzle year and day passed as command arguments.
+func dateFromArgs(c *cli.Context) (string, string, error) {
+	const argsNum = 2
+
+	if c.NArg() != argsNum {
+		return "", "", fmt.Errorf("expected <year> <day> arguments, got %d", c.NArg())
+	}
+
+	return c.Args().Get(0), c.Args().Get(1), nil
+}
+
 func menuPuzzle(ctx context.Context, year string) error {
 	solvers := puzzles.DaysByYear(year)
 
@@ -193,18 +368,32 @@ func handlePuzzleChoices(ctx context.Context, year string, opt promptui.Select)
 			return nil
 		}
 
-		stopSpinner := setSpinner()
+		stopSpinner, setPrefix := setSpinner()
+
+		runCtx := ctx
+
+		if waitFromContext(ctx) {
+			runCtx = command.ContextWithUnlockWait(ctx, func(remaining time.Duration) {
+				setPrefix(fmt.Sprintf("Puzzle unlocks in %s...", remaining.Round(time.Second)))
+			})
+		}
 
-		res, err := command.Run(ctx, year, dayOpt)
+		res, err := command.Run(runCtx, year, dayOpt)
 		if err != nil {
 			stopSpinner()
 
 			if errors.Is(err, command.ErrUnauthorized) {
-				fmt.Println(termlink.Link("Authorize here", "https://adventofcode.com/auth/login"))
+				fmt.Println(termlink.Link("Authorize here", loginURL))
 
 				log.WithError(ctx, err).Fatal("Session expired")
 			}
 
+			if errors.Is(err, input.ErrNotYetUnlocked) {
+				log.WithError(ctx, err).Error("Puzzle is not unlocked yet, use --wait flag to solve it on unlock")
+
+				continue
+			}
+
 			log.WithError(ctx, err).Error("Puzzle run failed")
 
 			continue
@@ -214,7 +403,9 @@ func handlePuzzleChoices(ctx context.Context, year string, opt promptui.Select)
 
 		url := getURL(year, dayOpt)
 
-		fmt.Println(res.String())
+		if err = writeResult(os.Stdout, formatFromContext(ctx), year, dayOpt, res); err != nil {
+			log.WithError(ctx, err).Error("Failed to print result")
+		}
 
 		fmt.Println(termlink.Link("Enter puzzle answers here", url))
 	}
@@ -270,8 +461,9 @@ func sessionFromCli(c *cli.Context) string {
 	return ""
 }
 
-// setSpinner runs the displaying of spinner to handle long time operations. Returns stop func.
-func setSpinner() func(msg ...string) {
+// setSpinner runs the displaying of spinner to handle long time operations.
+// Returns stop func and func to update spinner prefix.
+func setSpinner() (func(msg ...string), func(prefix string)) {
 	const delayMs = 100
 
 	s := spinner.New(
@@ -287,11 +479,145 @@ func setSpinner() func(msg ...string) {
 
 	s.Start()
 
-	return func(msg ...string) {
+	stop := func(msg ...string) {
 		if len(msg) != 0 {
 			s.FinalMSG = msg[0]
 		}
 
 		s.Stop()
 	}
+
+	setPrefix := func(prefix string) {
+		s.Lock()
+		defer s.Unlock()
+
+		s.Prefix = prefix
+	}
+
+	return stop, setPrefix
+}
+
+type waitCtxKey struct{}
+
+// contextWithWait stores in context whether puzzle unlock should be awaited.
+func contextWithWait(ctx context.Context, wait bool) context.Context {
+	if !wait {
+		return ctx
+	}
+
+	return context.WithValue(ctx, waitCtxKey{}, wait)
+}
+
+// waitFromContext reports whether puzzle unlock should be awaited.
+func waitFromContext(ctx context.Context) bool {
+	wait, ok := ctx.Value(waitCtxKey{}).(bool)
+
+	return ok && wait
+}
+
+type formatCtxKey struct{}
+
+// contextWithFormat stores in context output format of results.
+func contextWithFormat(ctx context.Context, format command.Format) context.Context {
+	return context.WithValue(ctx, formatCtxKey{}, format)
+}
+
+// formatFromContext returns output format of results, table by default.
+func formatFromContext(ctx context.Context) command.Format {
+	format, ok := ctx.Value(formatCtxKey{}).(command.Format)
+	if !ok {
+		return command.FormatTable
+	}
+
+	return format
+}
+
+// runContext prepares context for solving puzzles with options set by run flags.
+func runContext(ctx context.Context, c *cli.Context) (context.Context, error) {
+	sess, err := resolveSession(c, true)
+	if err != nil {
+		return ctx, err
+	}
+
+	ctx = command.ContextWithOptions(ctx, optionsFromCli(c)...)
+	ctx = command.ContextWithSession(ctx, sess)
+	ctx = command.ContextWithBaseURL(ctx, c.String(flagBaseURL))
+	ctx = contextWithWait(ctx, c.Bool(flagWait))
+
+	return contextWithInputCache(ctx, c)
+}
+
+// contextWithInputCache enables inputs cache unless it is disabled by flag.
+func contextWithInputCache(ctx context.Context, c *cli.Context) (context.Context, error) {
+	if c.Bool(flagNoCache) {
+		return ctx, nil
+	}
+
+	dir := c.String(flagCacheDir)
+	if dir == "" {
+		var err error
+
+		dir, err = input.DefaultCacheDir()
+		if err != nil {
+			return ctx, err
+		}
+	}
+
+	return command.ContextWithInputCache(ctx, dir), nil
+}
+
+func downloadAction(ctx context.Context) cli.ActionFunc {
+	const timeout = time.Second * 30
+
+	return func(c *cli.Context) error {
+		sess, err := resolveSession(c, true)
+		if err != nil {
+			return err
+		}
+
+		dir := c.String(flagDir)
+		if dir == "" {
+			dir = c.String(flagCacheDir)
+			if dir == "" {
+				dir, err = input.DefaultCacheDir()
+				if err != nil {
+					return err
+				}
+			}
+
+			dir = input.CacheDir(dir, sess)
+		}
+
+		opts := []download.Option{download.WithInterval(c.Duration(flagInterval))}
+
+		v, err := vault.FromEnv()
+		if err != nil && !errors.Is(err, vault.ErrNoKey) {
+			return err
+		}
+
+		if v != nil {
+			opts = append(opts, download.WithVault(v))
+		}
+
+		d := download.New(input.NewFetcher(http.DefaultClient, timeout, inputOptions(c)...), dir, opts...)
+
+		report, err := d.Download(ctx, download.Dates(c.StringSlice(flagYear)...), sess)
+
+		log.WithFields(ctx, log.Fields{
+			"dir":        dir,
+			"downloaded": len(report.Downloaded),
+			"skipped":    len(report.Skipped),
+			"failed":     len(report.Failed),
+		}).Info("Download finished")
+
+		if err != nil {
+			if errors.Is(err, input.ErrUnauthorized) {
+				fmt.Println(termlink.Link("Authorize here", loginURL))
+			}
+
+			return err
+		}
+
+		return nil
+	}
 }
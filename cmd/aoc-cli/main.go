@@ -1,6 +1,8 @@
 // aoc-cli is a tool to run solutions to get answers for input on advent-of-code site.
 package main
 
+//go:generate go run . -q readme --file ../../README.md
+
 import (
 	"context"
 	"errors"
@@ -9,7 +11,10 @@
 	log "github.com/obalunenko/logger"
 	"github.com/urfave/cli/v2"
 
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
 	_ "github.com/obalunenko/advent-of-code/internal/puzzles/solutions" // register all solutions.
+	"github.com/obalunenko/advent-of-code/internal/session"
+	"github.com/obalunenko/advent-of-code/internal/vault"
 )
 
 const (
@@ -24,6 +29,16 @@
 func main() {
 	ctx := context.Background()
 
+	session.Redact(os.Getenv(puzzles.AOCSession))
+	session.Redact(os.Getenv(vault.EnvKey))
+
+	log.Init(ctx, log.Params{
+		Writer:     session.NewRedactingWriter(os.Stderr),
+		Level:      "info",
+		Format:     "text",
+		WithSource: false,
+	})
+
 	app := cli.NewApp()
 	app.Name = "aoc-cli"
 	app.Description = "Solutions of puzzles for Advent Of Code (https://adventofcode.com/)\n" +
@@ -36,10 +51,12 @@ func main() {
 			Email: "oleg.balunenko@gmail.com",
 		},
 	}
+	app.EnableBashCompletion = true
 	app.CommandNotFound = notFound(ctx)
+	app.Flags = globalFlags()
 	app.Commands = commands(ctx)
 	app.Version = printVersion(ctx)
-	app.Before = printHeader(ctx)
+	app.Before = beforeAll(applyConfig(ctx), printHeader(ctx))
 	app.After = onExit(ctx)
 
 	if err := app.Run(os.Args); err != nil {
@@ -47,6 +64,8 @@ func main() {
 			return
 		}
 
-		log.WithError(ctx, err).Fatal("Run failed")
+		log.WithError(ctx, err).Error("Run failed")
+
+		os.Exit(exitCode(err))
 	}
 }
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/command"
+	"github.com/obalunenko/advent-of-code/internal/session"
+)
+
+var errMatrixFailed = errors.New("solution failed or gave wrong answer for some accounts")
+
+func matrixAction(ctx context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		year, day, err := dateFromArgs(c)
+		if err != nil {
+			return err
+		}
+
+		format, err := command.ParseFormat(c.String(flagFormat))
+		if err != nil {
+			return err
+		}
+
+		store, err := loadSessions()
+		if err != nil {
+			return err
+		}
+
+		accounts, err := parseAccounts(c.StringSlice(flagAccount), store)
+		if err != nil {
+			return err
+		}
+
+		var known command.KnownAnswers
+
+		if fpath := c.String(flagAnswers); fpath != "" {
+			known, err = command.LoadKnownAnswers(fpath)
+			if err != nil {
+				return err
+			}
+		}
+
+		ctx = command.ContextWithBaseURL(ctx, c.String(flagBaseURL))
+
+		ctx, err = contextWithInputCache(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		rows, err := command.RunMatrix(ctx, year, day, accounts, known)
+		if err != nil {
+			return err
+		}
+
+		if err = command.WriteMatrix(c.App.Writer, format, rows); err != nil {
+			return fmt.Errorf("render matrix: %w", err)
+		}
+
+		for _, r := range rows {
+			if !r.OK() {
+				return errMatrixFailed
+			}
+		}
+
+		return nil
+	}
+}
+
+// Prefixes of account source passing session token: raw token, or name of environment variable holding it.
+const (
+	sourceSession = "session:"
+	sourceEnv     = "env:"
+)
+
+// parseAccounts parses accounts passed as name[=source], where source is either inputs dir, a path with separator
+// (./alice), session token as session:<token> or env:<variable>, or stored profile. Without source, name is used
+// as profile name. When no accounts passed, all stored profiles are used.
+func parseAccounts(specs []string, store *session.Store) ([]command.Account, error) {
+	if len(specs) == 0 {
+		specs = store.Names()
+	}
+
+	if len(specs) == 0 {
+		return nil, errNoSession
+	}
+
+	accounts := make([]command.Account, 0, len(specs))
+
+	for _, spec := range specs {
+		name, source, ok := strings.Cut(spec, "=")
+		if !ok {
+			source = name
+		}
+
+		isDir := ok && strings.ContainsAny(source, "/"+string(filepath.Separator))
+
+		acc := command.Account{
+			Name:    name,
+			Session: "",
+			Dir:     "",
+		}
+
+		token, isToken, terr := tokenSource(source)
+		if ok && terr != nil {
+			return nil, fmt.Errorf("account %q: %w", name, terr)
+		}
+
+		switch {
+		case ok && isToken:
+			session.Redact(token)
+
+			acc.Session = token
+		case isDir:
+			stat, err := os.Stat(source)
+			if err != nil {
+				return nil, fmt.Errorf("account %q: %w", name, err)
+			}
+
+			if !stat.IsDir() {
+				return nil, fmt.Errorf("account %q: %s is not a directory", name, source)
+			}
+
+			acc.Dir = source
+		default:
+			sess, err := store.Get(source)
+			if err != nil {
+				return nil, fmt.Errorf("account %q: %w", name, err)
+			}
+
+			session.Redact(sess)
+
+			acc.Session = sess
+		}
+
+		accounts = append(accounts, acc)
+	}
+
+	return accounts, nil
+}
+
+// tokenSource returns session token passed by account source as session:<token> or env:<variable>.
+func tokenSource(source string) (string, bool, error) {
+	if token, ok := strings.CutPrefix(source, sourceSession); ok {
+		if token == "" {
+			return "", true, errNoSession
+		}
+
+		return token, true, nil
+	}
+
+	if variable, ok := strings.CutPrefix(source, sourceEnv); ok {
+		token := os.Getenv(variable)
+		if token == "" {
+			return "", true, fmt.Errorf("environment variable %s is empty: %w", variable, errNoSession)
+		}
+
+		return token, true, nil
+	}
+
+	return "", false, nil
+}
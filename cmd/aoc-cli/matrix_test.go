@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/command"
+	"github.com/obalunenko/advent-of-code/internal/session"
+)
+
+func Test_parseAccounts(t *testing.T) {
+	store, err := session.Load(filepath.Join(t.TempDir(), "sessions.yaml"))
+	require.NoError(t, err)
+
+	_, err = parseAccounts(nil, store)
+	require.ErrorIs(t, err, errNoSession)
+
+	require.NoError(t, store.Set("work", "work-token"))
+	require.NoError(t, store.Set("personal", "personal-token"))
+
+	dir := t.TempDir()
+
+	got, err := parseAccounts(nil, store)
+	require.NoError(t, err)
+
+	assert.Equal(t, []command.Account{
+		{Name: "personal", Session: "personal-token", Dir: ""},
+		{Name: "work", Session: "work-token", Dir: ""},
+	}, got)
+
+	got, err = parseAccounts([]string{"work", "me=personal", "colleague=" + dir}, store)
+	require.NoError(t, err)
+
+	assert.Equal(t, []command.Account{
+		{Name: "work", Session: "work-token", Dir: ""},
+		{Name: "me", Session: "personal-token", Dir: ""},
+		{Name: "colleague", Session: "", Dir: dir},
+	}, got)
+
+	_, err = parseAccounts([]string{"unknown"}, store)
+	assert.ErrorIs(t, err, session.ErrProfileNotFound)
+
+	t.Setenv("AOC_TEST_TOKEN", "env-token")
+
+	got, err = parseAccounts([]string{"raw=session:raw-token", "env=env:AOC_TEST_TOKEN"}, store)
+	require.NoError(t, err)
+
+	assert.Equal(t, []command.Account{
+		{Name: "raw", Session: "raw-token", Dir: ""},
+		{Name: "env", Session: "env-token", Dir: ""},
+	}, got, "session token is passed as is")
+
+	_, err = parseAccounts([]string{"raw=session:"}, store)
+	require.ErrorIs(t, err, errNoSession)
+
+	_, err = parseAccounts([]string{"env=env:AOC_TEST_UNSET"}, store)
+	require.ErrorIs(t, err, errNoSession)
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(wd))
+	})
+
+	require.NoError(t, os.Mkdir("work", os.ModePerm))
+
+	got, err = parseAccounts([]string{"work", "me=work", "local=./work"}, store)
+	require.NoError(t, err)
+
+	assert.Equal(t, []command.Account{
+		{Name: "work", Session: "work-token", Dir: ""},
+		{Name: "me", Session: "work-token", Dir: ""},
+		{Name: "local", Session: "", Dir: "./work"},
+	}, got, "only source with separator is a dir")
+
+	_, err = parseAccounts([]string{"missing=./missing"}, store)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	log "github.com/obalunenko/logger"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/codegen"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/solutions"
+)
+
+func newAction(ctx context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		year, day, err := newDate(c)
+		if err != nil {
+			return err
+		}
+
+		y, err := strconv.Atoi(year)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errInvalidDate, err)
+		}
+
+		d, err := strconv.Atoi(day)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errInvalidDate, err)
+		}
+
+		if err = codegen.Validate(y, d); err != nil {
+			return fmt.Errorf("%w: %w", errInvalidDate, err)
+		}
+
+		sess, err := resolveSession(c, false)
+		if err != nil {
+			return err
+		}
+
+		tmplDir, err := templatesDir(c)
+		if err != nil {
+			return err
+		}
+
+		root := c.String(flagRoot)
+
+		// Nothing is created when puzzle could not be registered.
+		if err = codegen.Check(root, y); err != nil {
+			return fmt.Errorf("register puzzle: %w", err)
+		}
+
+		// Puzzle package, and dir of the year for a new year, are removed on failure, unless they existed before.
+		dir := createdDir(filepath.Join(root, codegen.SolutionsDir, year), fmt.Sprintf("day%02d", d))
+
+		cleanup := func() {
+			if dir == "" {
+				return
+			}
+
+			if rerr := os.RemoveAll(dir); rerr != nil {
+				log.WithError(ctx, rerr).WithField("dir", dir).Warn("Failed to remove created puzzle")
+			}
+		}
+
+		err = solutions.CreateNew(ctx, filepath.Join(root, codegen.SolutionsDir), tmplDir, year, day, sess,
+			inputOptions(c)...)
+		if err != nil {
+			cleanup()
+
+			return fmt.Errorf("create puzzle: %w", err)
+		}
+
+		if err = codegen.Register(root, y, d); err != nil {
+			cleanup()
+
+			return fmt.Errorf("register puzzle: %w", err)
+		}
+
+		log.WithField(ctx, "puzzle", getURL(year, day)).Info("Puzzle created")
+
+		return nil
+	}
+}
+
+// createdDir returns the topmost dir of yearDir/dayDir which does not exist yet, empty when both exist.
+func createdDir(yearDir, dayDir string) string {
+	for _, dir := range []string{yearDir, filepath.Join(yearDir, dayDir)} {
+		if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+			return dir
+		}
+	}
+
+	return ""
+}
+
+// newDate returns puzzle date passed as puzzle URL or as year and day arguments.
+func newDate(c *cli.Context) (string, string, error) {
+	switch c.NArg() {
+	case 1:
+		return parseDate(c.Args().First())
+	case 2:
+		return parseDate(c.Args().Get(0) + "/" + c.Args().Get(1))
+	default:
+		return "", "", fmt.Errorf("%w: expected <puzzle url> or <year> <day> arguments, got %d", errInvalidDate, c.NArg())
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+
+	log "github.com/obalunenko/logger"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/readme"
+)
+
+func readmeAction(ctx context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		fpath := c.String(flagFile)
+
+		changed, err := readme.UpdateFile(fpath)
+		if err != nil {
+			return err
+		}
+
+		if !changed {
+			log.WithField(ctx, "path", fpath).Info("README is up to date")
+
+			return nil
+		}
+
+		log.WithField(ctx, "path", fpath).Info("README updated")
+
+		return nil
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	log "github.com/obalunenko/logger"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/command"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/rpc"
+)
+
+// cmdRPC is declared at package level as banner and exit message are not printed for it:
+// its stdout is a stream of JSON-RPC messages.
+const cmdRPC = "rpc"
+
+func rpcAction(ctx context.Context) cli.ActionFunc {
+	const submitTimeout = 30 * time.Second
+
+	return func(c *cli.Context) error {
+		// Session is optional: without it puzzles are solved only with passed input.
+		sess, err := resolveSession(c, false)
+		if err != nil {
+			return err
+		}
+
+		ctx := command.ContextWithBaseURL(ctx, c.String(flagBaseURL))
+
+		ctx, err = contextWithInputCache(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		f, err := command.NewFetcher(ctx)
+		if err != nil {
+			return err
+		}
+
+		srv := rpc.NewServer(
+			rpc.WithFetcher(f),
+			rpc.WithSubmitter(input.NewAnswerSubmitter(http.DefaultClient, submitTimeout, inputOptions(c)...)),
+			rpc.WithSession(sess),
+		)
+
+		log.Info(ctx, "Serving JSON-RPC on stdin and stdout")
+
+		return srv.Serve(ctx, os.Stdin, c.App.Writer)
+	}
+}
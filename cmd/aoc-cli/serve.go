@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	log "github.com/obalunenko/logger"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/command"
+	"github.com/obalunenko/advent-of-code/internal/server"
+	"github.com/obalunenko/advent-of-code/internal/web"
+)
+
+// serveAction serves API of solvers, and web UI on top of it when withUI is set.
+func serveAction(ctx context.Context, withUI bool) cli.ActionFunc {
+	const (
+		readHeaderTimeout = 10 * time.Second
+		shutdownTimeout   = 5 * time.Second
+	)
+
+	return func(c *cli.Context) error {
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+
+		// Session is optional: without it puzzles are solved only with passed input.
+		sess, err := resolveSession(c, false)
+		if err != nil {
+			return err
+		}
+
+		fctx := command.ContextWithBaseURL(ctx, c.String(flagBaseURL))
+
+		fctx, err = contextWithInputCache(fctx, c)
+		if err != nil {
+			return err
+		}
+
+		f, err := command.NewFetcher(fctx)
+		if err != nil {
+			return err
+		}
+
+		api := server.NewHandler(
+			server.WithConcurrency(c.Int(flagWorkers)),
+			server.WithTimeout(c.Duration(flagTimeout)),
+			server.WithMaxInputSize(c.Int64(flagMaxInputSize)),
+			server.WithFetcher(f, sess),
+		)
+
+		var h http.Handler = api
+
+		msg := "Serving solvers API"
+
+		if withUI {
+			ui, err := web.NewHandler(api)
+			if err != nil {
+				return fmt.Errorf("create web UI: %w", err)
+			}
+
+			h = ui
+			msg = "Serving web UI"
+		}
+
+		addr := c.String(flagAddr)
+
+		srv := &http.Server{
+			Addr:              addr,
+			Handler:           h,
+			ReadHeaderTimeout: readHeaderTimeout,
+		}
+
+		go func() {
+			<-ctx.Done()
+
+			sctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+
+			if err := srv.Shutdown(sctx); err != nil {
+				log.WithError(ctx, err).Error("Failed to shutdown")
+			}
+		}()
+
+		log.WithField(ctx, "url", "http://"+addr).Info(msg)
+
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("serve: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	log "github.com/obalunenko/logger"
+	"github.com/savioxavier/termlink"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/session"
+)
+
+const (
+	defaultProfile = "default"
+	loginURL       = "https://adventofcode.com/auth/login"
+	sessionTimeout = time.Second * 30
+)
+
+var errNoSession = errors.New("session is not set: pass --session flag, set " + puzzles.AOCSession +
+	" or run 'aoc-cli session login'")
+
+// resolveSession returns session passed by flag or env, falling back to the stored profile.
+// When required is false, empty session is returned if none is found.
+func resolveSession(c *cli.Context, required bool) (string, error) {
+	if sess := sessionFromCli(c); sess != "" {
+		session.Redact(sess)
+
+		return sess, nil
+	}
+
+	store, err := loadSessions()
+	if err != nil {
+		return "", err
+	}
+
+	sess, err := store.Get(c.String(flagProfile))
+	if err != nil {
+		if !required && errors.Is(err, session.ErrProfileNotFound) {
+			return "", nil
+		}
+
+		if errors.Is(err, session.ErrProfileNotFound) && c.String(flagProfile) == "" {
+			return "", errNoSession
+		}
+
+		return "", err
+	}
+
+	return sess, nil
+}
+
+func loadSessions() (*session.Store, error) {
+	path, err := session.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := session.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("load sessions: %w", err)
+	}
+
+	return store, nil
+}
+
+func sessionLoginAction(ctx context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		token := sessionFromCli(c)
+		if token == "" {
+			var err error
+
+			token, err = promptToken()
+			if err != nil {
+				return err
+			}
+		}
+
+		acc, err := session.Validate(ctx, input.NewCalendarFetcher(http.DefaultClient, sessionTimeout, inputOptions(c)...), token)
+		if err != nil {
+			if errors.Is(err, input.ErrUnauthorized) {
+				fmt.Println(termlink.Link("Authorize here", loginURL))
+			}
+
+			return fmt.Errorf("validate session: %w", err)
+		}
+
+		store, err := loadSessions()
+		if err != nil {
+			return err
+		}
+
+		name := c.String(flagProfile)
+
+		if err = store.Set(name, token); err != nil {
+			return fmt.Errorf("set profile: %w", err)
+		}
+
+		if c.Bool(flagDefault) {
+			if err = store.SetDefault(name); err != nil {
+				return fmt.Errorf("set default profile: %w", err)
+			}
+		}
+
+		if err = store.Save(); err != nil {
+			return fmt.Errorf("save sessions: %w", err)
+		}
+
+		log.WithFields(ctx, log.Fields{
+			"profile": name,
+			"account": acc.Name,
+			"file":    store.Path(),
+		}).Info("Session saved")
+
+		return nil
+	}
+}
+
+func sessionStatusAction(ctx context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		token, err := resolveSession(c, true)
+		if err != nil {
+			return err
+		}
+
+		source := "--session flag or " + puzzles.AOCSession
+		if sessionFromCli(c) == "" {
+			store, lerr := loadSessions()
+			if lerr != nil {
+				return lerr
+			}
+
+			source = c.String(flagProfile)
+			if source == "" {
+				source = store.Default
+			}
+
+			source = fmt.Sprintf("profile %q", source)
+		}
+
+		acc, err := session.Validate(ctx, input.NewCalendarFetcher(http.DefaultClient, sessionTimeout, inputOptions(c)...), token)
+		if err != nil {
+			if errors.Is(err, input.ErrUnauthorized) {
+				fmt.Println(termlink.Link("Authorize here", loginURL))
+			}
+
+			return fmt.Errorf("session from %s: %w", source, err)
+		}
+
+		_, err = fmt.Fprintf(c.App.Writer, "Session from %s is valid\nAccount: %s\nStars in %s: %d\n",
+			source, acc.Name, acc.Event, acc.Stars)
+		if err != nil {
+			return fmt.Errorf("print status: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func sessionListAction(_ context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		store, err := loadSessions()
+		if err != nil {
+			return err
+		}
+
+		for _, name := range store.Names() {
+			mark := " "
+			if name == store.Default {
+				mark = "*"
+			}
+
+			if _, err = fmt.Fprintf(c.App.Writer, "%s %s\n", mark, name); err != nil {
+				return fmt.Errorf("print profile: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func sessionUseAction(_ context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		return updateSessions(c, func(store *session.Store, name string) error {
+			return store.SetDefault(name)
+		})
+	}
+}
+
+func sessionLogoutAction(_ context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		return updateSessions(c, func(store *session.Store, name string) error {
+			return store.Remove(name)
+		})
+	}
+}
+
+func updateSessions(c *cli.Context, update func(store *session.Store, name string) error) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("expected <profile> argument, got %d", c.NArg())
+	}
+
+	store, err := loadSessions()
+	if err != nil {
+		return err
+	}
+
+	if err = update(store, c.Args().First()); err != nil {
+		return err
+	}
+
+	if err = store.Save(); err != nil {
+		return fmt.Errorf("save sessions: %w", err)
+	}
+
+	return nil
+}
+
+func promptToken() (string, error) {
+	prompt := promptui.Prompt{
+		Label:       "Session token (session cookie of adventofcode.com)",
+		Default:     "",
+		AllowEdit:   false,
+		Validate:    nil,
+		Mask:        '*',
+		HideEntered: true,
+		Templates:   nil,
+		IsConfirm:   false,
+		IsVimMode:   false,
+		Pointer:     nil,
+		Stdin:       nil,
+		Stdout:      nil,
+	}
+
+	token, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("prompt failed: %w", err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/session"
+)
+
+func Test_resolveSession(t *testing.T) {
+	cfg := t.TempDir()
+
+	t.Setenv("XDG_CONFIG_HOME", cfg)
+	t.Setenv("HOME", cfg)
+	t.Setenv(puzzles.AOCSession, "")
+	t.Setenv(envProfile, "")
+
+	resolve := func(t *testing.T, required bool, args ...string) (string, error) {
+		t.Helper()
+
+		var (
+			got string
+			err error
+		)
+
+		app := cli.NewApp()
+		app.Flags = sessionFlags()
+		app.Action = func(c *cli.Context) error {
+			got, err = resolveSession(c, required)
+
+			return nil
+		}
+
+		require.NoError(t, app.RunContext(context.Background(), append([]string{"aoc-cli"}, args...)))
+
+		return got, err
+	}
+
+	_, err := resolve(t, true)
+	require.ErrorIs(t, err, errNoSession)
+
+	got, err := resolve(t, false)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = resolve(t, true, "--session", "flag-token")
+	require.NoError(t, err)
+	assert.Equal(t, "flag-token", got)
+
+	path, err := session.DefaultPath()
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(cfg, "aoc-cli", "sessions.yaml"), path)
+
+	store, err := session.Load(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Set("work", "work-token"))
+	require.NoError(t, store.Set("personal", "personal-token"))
+	require.NoError(t, store.Save())
+
+	got, err = resolve(t, true)
+	require.NoError(t, err)
+	assert.Equal(t, "work-token", got)
+
+	got, err = resolve(t, true, "--profile", "personal")
+	require.NoError(t, err)
+	assert.Equal(t, "personal-token", got)
+
+	_, err = resolve(t, false, "--profile", "unknown")
+	require.NoError(t, err)
+
+	_, err = resolve(t, true, "--profile", "unknown")
+	require.ErrorIs(t, err, session.ErrProfileNotFound)
+}
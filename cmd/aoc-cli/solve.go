@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/obalunenko/logger"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/command"
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+// Exit codes of the application, so scripts could distinguish failures.
+const (
+	exitCodeOK             = 0
+	exitCodeFailure        = 1
+	exitCodeUsage          = 2
+	exitCodeUnauthorized   = 3
+	exitCodeNotFound       = 4
+	exitCodeNotImplemented = 5
+	exitCodeSolverFailed   = 6
+)
+
+var errInvalidDate = errors.New("invalid puzzle date")
+
+// dateRe matches puzzle date as <year>/<day> or puzzle URL path <year>/day/<day>.
+var dateRe = regexp.MustCompile(`^(\d{4})/(?:day/)?(\d{1,2})$`)
+
+func solveAction(ctx context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		year, day, err := solveDate(c)
+		if err != nil {
+			return err
+		}
+
+		format, err := command.ParseFormat(c.String(flagFormat))
+		if err != nil {
+			return err
+		}
+
+		if year == "" && day == "" {
+			return menu(ctx)(c)
+		}
+
+		ctx, err := runContext(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		if waitFromContext(ctx) {
+			var once sync.Once
+
+			ctx = command.ContextWithUnlockWait(ctx, func(remaining time.Duration) {
+				once.Do(func() {
+					log.WithField(ctx, "remaining", remaining.Round(time.Second).String()).Info("Waiting for puzzle unlock")
+				})
+			})
+		}
+
+		var stubs notImplemented
+
+		ctx = command.ContextWithOptions(ctx,
+			append(command.OptionsFromContext(ctx), puzzles.WithObserver(stubs.observe))...)
+
+		res, err := command.Run(ctx, year, day)
+		if err != nil {
+			return err
+		}
+
+		if err = writeResult(c.App.Writer, format, year, day, res); err != nil {
+			return err
+		}
+
+		return stubs.err(year, day)
+	}
+}
+
+// writeResult writes result of the puzzle in passed format. Table format prints answers as interactive menu does.
+func writeResult(w io.Writer, format command.Format, year, day string, res puzzles.Result) error {
+	if format != command.FormatTable {
+		return command.WriteResults(w, format, []command.BatchResult{{
+			Year:         year,
+			Day:          day,
+			Result:       res,
+			Elapsed:      0,
+			Part1Elapsed: 0,
+			Part2Elapsed: 0,
+			Err:          nil,
+		}})
+	}
+
+	if _, err := fmt.Fprintln(w, res.String()); err != nil {
+		return fmt.Errorf("print result: %w", err)
+	}
+
+	return nil
+}
+
+// notImplemented collects parts which solver returned puzzles.ErrNotImplemented for, e.g. scaffolded stubs.
+type notImplemented struct {
+	parts []string
+}
+
+func (n *notImplemented) observe(m puzzles.PartMetric) {
+	if errors.Is(m.Err, puzzles.ErrNotImplemented) && !slices.Contains(n.parts, m.Part) {
+		n.parts = append(n.parts, m.Part)
+	}
+}
+
+// err returns error wrapping puzzles.ErrNotImplemented when some of solved parts are not implemented.
+func (n *notImplemented) err(year, day string) error {
+	if len(n.parts) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%s/%s part %s: %w", year, day, strings.Join(n.parts, ", "), puzzles.ErrNotImplemented)
+}
+
+// solveDate returns puzzle date passed by --year and --day flags, as <year>/<day> or puzzle URL argument,
+// or as <year> <day> arguments. Empty date is returned when none is passed.
+func solveDate(c *cli.Context) (string, string, error) {
+	year, day := c.String(flagYear), c.String(flagDay)
+
+	switch c.NArg() {
+	case 0:
+	case 1:
+		if year != "" || day != "" {
+			return "", "", fmt.Errorf("%w: pass date either by flags or by argument", errInvalidDate)
+		}
+
+		return parseDate(c.Args().First())
+	case 2:
+		if year != "" || day != "" {
+			return "", "", fmt.Errorf("%w: pass date either by flags or by arguments", errInvalidDate)
+		}
+
+		return parseDate(c.Args().Get(0) + "/" + c.Args().Get(1))
+	default:
+		return "", "", fmt.Errorf("%w: expected at most 2 arguments, got %d", errInvalidDate, c.NArg())
+	}
+
+	if year == "" && day == "" {
+		return "", "", nil
+	}
+
+	if year == "" || day == "" {
+		return "", "", fmt.Errorf("%w: both --%s and --%s should be set", errInvalidDate, flagYear, flagDay)
+	}
+
+	return parseDate(year + "/" + day)
+}
+
+// parseDate parses puzzle date passed as <year>/<day>, e.g. 2021/5 or 2021/05,
+// or as puzzle URL, e.g. https://adventofcode.com/2021/day/5.
+func parseDate(s string) (string, string, error) {
+	date := s
+
+	if u, err := url.Parse(s); err == nil && u.Host != "" {
+		date = u.Path
+	}
+
+	m := dateRe.FindStringSubmatch(strings.Trim(date, "/"))
+	if m == nil {
+		return "", "", fmt.Errorf("%w: %q, expected <year>/<day> or puzzle URL", errInvalidDate, s)
+	}
+
+	day, err := strconv.Atoi(m[2])
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %q: %w", errInvalidDate, s, err)
+	}
+
+	return m[1], strconv.Itoa(day), nil
+}
+
+// exitCode returns exit code of the application for the error returned by command.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitCodeOK
+	case errors.Is(err, errInvalidDate), errors.Is(err, errNoPuzzles), errors.Is(err, command.ErrUnknownFormat):
+		return exitCodeUsage
+	case errors.Is(err, command.ErrUnauthorized), errors.Is(err, input.ErrUnauthorized),
+		errors.Is(err, errNoSession):
+		return exitCodeUnauthorized
+	case errors.Is(err, input.ErrNotFound), errors.Is(err, input.ErrNotYetUnlocked):
+		return exitCodeNotFound
+	case errors.Is(err, puzzles.ErrUnknownYear), errors.Is(err, puzzles.ErrUnknownDay),
+		errors.Is(err, puzzles.ErrNotImplemented):
+		return exitCodeNotImplemented
+	case errors.Is(err, command.ErrSolverFailed), errors.Is(err, errBatchFailed):
+		return exitCodeSolverFailed
+	default:
+		return exitCodeFailure
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/command"
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+func Test_parseDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		wantYear string
+		wantDay  string
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "year and day",
+			in:       "2021/5",
+			wantYear: "2021",
+			wantDay:  "5",
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "padded day",
+			in:       "2021/05",
+			wantYear: "2021",
+			wantDay:  "5",
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "puzzle url",
+			in:       "https://adventofcode.com/2021/day/25",
+			wantYear: "2021",
+			wantDay:  "25",
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "puzzle url with trailing slash",
+			in:       "https://adventofcode.com/2021/day/5/",
+			wantYear: "2021",
+			wantDay:  "5",
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "input url",
+			in:       "https://adventofcode.com/2021/day/5/input",
+			wantYear: "",
+			wantDay:  "",
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "no day",
+			in:       "2021",
+			wantYear: "",
+			wantDay:  "",
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "not a number",
+			in:       "2021/five",
+			wantYear: "",
+			wantDay:  "",
+			wantErr:  assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			year, day, err := parseDate(tt.in)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			if err != nil {
+				assert.ErrorIs(t, err, errInvalidDate)
+			}
+
+			assert.Equal(t, tt.wantYear, year)
+			assert.Equal(t, tt.wantDay, day)
+		})
+	}
+}
+
+func Test_exitCode(t *testing.T) {
+	wrap := func(err error) error {
+		return fmt.Errorf("wrapped: %w", err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "no error",
+			err:  nil,
+			want: exitCodeOK,
+		},
+		{
+			name: "invalid date",
+			err:  wrap(errInvalidDate),
+			want: exitCodeUsage,
+		},
+		{
+			name: "unauthorized",
+			err:  command.ErrUnauthorized,
+			want: exitCodeUnauthorized,
+		},
+		{
+			name: "no session",
+			err:  errNoSession,
+			want: exitCodeUnauthorized,
+		},
+		{
+			name: "input not found",
+			err:  wrap(input.ErrNotFound),
+			want: exitCodeNotFound,
+		},
+		{
+			name: "not yet unlocked",
+			err:  wrap(input.ErrNotYetUnlocked),
+			want: exitCodeNotFound,
+		},
+		{
+			name: "unknown year",
+			err:  wrap(puzzles.ErrUnknownYear),
+			want: exitCodeNotImplemented,
+		},
+		{
+			name: "unknown day",
+			err:  wrap(puzzles.ErrUnknownDay),
+			want: exitCodeNotImplemented,
+		},
+		{
+			name: "part not implemented",
+			err:  wrap(puzzles.ErrNotImplemented),
+			want: exitCodeNotImplemented,
+		},
+		{
+			name: "solver failed",
+			err:  fmt.Errorf("failed to run: %w: %w", command.ErrSolverFailed, errors.New("parse")),
+			want: exitCodeSolverFailed,
+		},
+		{
+			name: "batch failed",
+			err:  fmt.Errorf("%w: 1 of 2", errBatchFailed),
+			want: exitCodeSolverFailed,
+		},
+		{
+			name: "no puzzles to run",
+			err:  errNoPuzzles,
+			want: exitCodeUsage,
+		},
+		{
+			name: "other",
+			err:  errors.New("other"),
+			want: exitCodeFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exitCode(tt.err))
+		})
+	}
+}
+
+// stubSolver answers part one with input and returns puzzles.ErrNotImplemented for part two, as scaffolded solver.
+type stubSolver struct{}
+
+func (stubSolver) Year() string {
+	return "1992"
+}
+
+func (stubSolver) Day() string {
+	return "1"
+}
+
+func (stubSolver) Part1(in io.Reader) (string, error) {
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
+func (stubSolver) Part2(_ io.Reader) (string, error) {
+	return "", puzzles.ErrNotImplemented
+}
+
+func Test_solveAction(t *testing.T) {
+	puzzles.Register(stubSolver{})
+	t.Cleanup(func() {
+		puzzles.UnregisterAllSolvers(t)
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "42\n")
+	}))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantOut  string
+		wantCode int
+	}{
+		{
+			name:     "stub part",
+			args:     []string{"1992/1"},
+			wantOut:  "42",
+			wantCode: exitCodeNotImplemented,
+		},
+		{
+			name:     "stub part in json",
+			args:     []string{"--format", "json", "1992/1"},
+			wantOut:  `"part1": "42"`,
+			wantCode: exitCodeNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+
+			app := cli.NewApp()
+			app.Writer = &out
+			app.Flags = globalFlags()
+			app.Commands = []*cli.Command{{
+				Name:   "solve",
+				Action: solveAction(context.Background()),
+				Flags:  cmdSolveFlags(),
+			}}
+
+			args := append([]string{
+				"aoc-cli", "--" + flagBaseURL, srv.URL, "solve", "--" + flagSession, "token", "--" + flagNoCache,
+			}, tt.args...)
+
+			err := app.RunContext(context.Background(), args)
+			require.ErrorIs(t, err, puzzles.ErrNotImplemented)
+
+			assert.Equal(t, tt.wantCode, exitCode(err))
+			assert.Contains(t, out.String(), tt.wantOut, "implemented part is printed")
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/stats"
+)
+
+func statsAction(ctx context.Context) cli.ActionFunc {
+	const timeout = time.Second * 30
+
+	return func(c *cli.Context) error {
+		dir := c.String(flagDir)
+		if dir == "" {
+			var err error
+
+			dir, err = stats.DefaultDir()
+			if err != nil {
+				return err
+			}
+		}
+
+		var list []stats.Stats
+
+		years := c.StringSlice(flagYear)
+
+		if fpath := c.String(flagFile); fpath != "" {
+			if len(years) > 1 {
+				return fmt.Errorf("expected single year for saved page, got %d", len(years))
+			}
+
+			var year string
+
+			if len(years) == 1 {
+				year = years[0]
+			}
+
+			s, err := statsFromFile(fpath, year)
+			if err != nil {
+				return err
+			}
+
+			if err = stats.Save(dir, s); err != nil {
+				return fmt.Errorf("store statistics: %w", err)
+			}
+
+			list = append(list, s)
+		} else {
+			if len(years) == 0 {
+				years = []string{input.LatestEvent(time.Now())}
+			}
+
+			sess, err := resolveSession(c, true)
+			if err != nil {
+				return err
+			}
+
+			client := stats.NewClient(input.NewStatsFetcher(http.DefaultClient, timeout, inputOptions(c)...), dir)
+
+			for _, year := range years {
+				s, err := client.Get(ctx, year, sess)
+				if err != nil {
+					return fmt.Errorf("get statistics of %s: %w", year, err)
+				}
+
+				list = append(list, s)
+			}
+		}
+
+		for i, s := range list {
+			if i > 0 {
+				if _, err := fmt.Fprintln(c.App.Writer); err != nil {
+					return fmt.Errorf("print: %w", err)
+				}
+			}
+
+			if err := stats.Render(c.App.Writer, s); err != nil {
+				return fmt.Errorf("render statistics: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// statsFromFile parses saved personal statistics page. Year is taken from the page when not passed.
+func statsFromFile(fpath, year string) (stats.Stats, error) {
+	f, err := os.Open(filepath.Clean(fpath))
+	if err != nil {
+		return stats.Stats{}, fmt.Errorf("open page: %w", err)
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	s, err := stats.Parse(f, year)
+	if err != nil {
+		return stats.Stats{}, err
+	}
+
+	if s.Year == "" {
+		return stats.Stats{}, fmt.Errorf("year is not found on page, pass it with --%s", flagYear)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/progress"
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+func statusAction(ctx context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		years := c.StringSlice(flagYear)
+		if len(years) == 0 {
+			years = puzzles.GetYears()
+		}
+
+		// Session is optional: without it only the code state is shown.
+		sess, err := resolveSession(c, false)
+		if err != nil {
+			return err
+		}
+
+		parts := puzzles.Probe(puzzles.Solvers(years, nil))
+
+		fetcher := input.NewCalendarFetcher(http.DefaultClient, sessionTimeout, inputOptions(c)...)
+
+		list := make([]progress.Year, 0, len(years))
+
+		for _, year := range years {
+			var stars map[int]int
+
+			if sess != "" {
+				page, err := fetcher.FetchCalendar(ctx, year, sess)
+				if err != nil {
+					return fmt.Errorf("fetch calendar of %s: %w", year, err)
+				}
+
+				stars, err = progress.ParseCalendar(page)
+				if err != nil {
+					return fmt.Errorf("parse calendar of %s: %w", year, err)
+				}
+			}
+
+			list = append(list, progress.Build(year, parts, stars))
+		}
+
+		return progress.Render(c.App.Writer, list)
+	}
+}
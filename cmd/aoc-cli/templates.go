@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	log "github.com/obalunenko/logger"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/solutions/templates"
+)
+
+func templatesListAction(_ context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		dir, err := templatesDir(c)
+		if err != nil {
+			return err
+		}
+
+		const padding = 2
+
+		w := tabwriter.NewWriter(c.App.Writer, 0, 0, padding, ' ', 0)
+
+		for _, file := range templates.Files() {
+			path, err := templates.Path(dir, file)
+			if err != nil {
+				return err
+			}
+
+			if path == "" {
+				path = "embedded"
+			}
+
+			if _, err = fmt.Fprintf(w, "%s\t%s\n", file, path); err != nil {
+				return fmt.Errorf("print templates: %w", err)
+			}
+		}
+
+		if err = w.Flush(); err != nil {
+			return fmt.Errorf("print templates: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func templatesExportAction(ctx context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		dir, err := templatesDir(c)
+		if err != nil {
+			return err
+		}
+
+		written, err := templates.Export(dir, c.Bool(flagOverwrite))
+		if err != nil {
+			return fmt.Errorf("export templates: %w", err)
+		}
+
+		for _, path := range written {
+			log.WithField(ctx, "path", path).Info("Template exported")
+		}
+
+		if len(written) != len(templates.Files()) {
+			log.WithField(ctx, "dir", dir).Warn("Existing templates were kept, use --overwrite to replace them")
+		}
+
+		return nil
+	}
+}
+
+// templatesDir returns directory of user templates set by flag, or the default one.
+func templatesDir(c *cli.Context) (string, error) {
+	if dir := c.String(flagTemplates); dir != "" {
+		return dir, nil
+	}
+
+	return templates.DefaultDir()
+}
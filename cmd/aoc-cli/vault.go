@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	log "github.com/obalunenko/logger"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/vault"
+)
+
+func vaultKeygenAction(_ context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		key, err := vault.GenerateKey()
+		if err != nil {
+			return err
+		}
+
+		if _, err = fmt.Fprintln(c.App.Writer, key); err != nil {
+			return fmt.Errorf("print key: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func vaultEncryptAction(ctx context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.NArg() == 0 {
+			return errors.New("expected <file>... arguments")
+		}
+
+		v, err := vault.FromEnv()
+		if err != nil {
+			return err
+		}
+
+		for _, fpath := range c.Args().Slice() {
+			out, err := v.EncryptFile(fpath)
+			if err != nil {
+				return fmt.Errorf("encrypt %s: %w", fpath, err)
+			}
+
+			log.WithField(ctx, "path", out).Info("Encrypted")
+		}
+
+		return nil
+	}
+}
+
+func vaultDecryptAction(_ context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("expected <file.enc> argument, got %d", c.NArg())
+		}
+
+		v, err := vault.FromEnv()
+		if err != nil {
+			return err
+		}
+
+		content, err := v.ReadFile(c.Args().First())
+		if err != nil {
+			return err
+		}
+
+		if _, err = c.App.Writer.Write(content); err != nil {
+			return fmt.Errorf("print content: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+
+	log "github.com/obalunenko/logger"
+	"github.com/urfave/cli/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/codegen"
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/watch"
+)
+
+func watchAction(ctx context.Context) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		year, day, err := newDate(c)
+		if err != nil {
+			return err
+		}
+
+		d, err := strconv.Atoi(day)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errInvalidDate, err)
+		}
+
+		root := c.String(flagRoot)
+		dir := filepath.Join(codegen.SolutionsDir, year, fmt.Sprintf("day%02d", d))
+
+		if _, err = os.Stat(filepath.Join(root, dir)); err != nil {
+			return fmt.Errorf("puzzle %s/%s is not scaffolded, run 'aoc-cli new %s %s': %w", year, day, year, day, err)
+		}
+
+		// Session is resolved here and passed by env, so it is not shown in process list.
+		sess, err := resolveSession(c, false)
+		if err != nil {
+			return err
+		}
+
+		env := []string{envBaseURL + "=" + c.String(flagBaseURL)}
+
+		if sess != "" {
+			env = append(env, puzzles.AOCSession+"="+sess)
+		}
+
+		var args []string
+
+		if c.Bool(flagNoCache) {
+			args = append(args, "--"+flagNoCache)
+		}
+
+		if cacheDir := c.String(flagCacheDir); cacheDir != "" {
+			args = append(args, "--"+flagCacheDir, cacheDir)
+		}
+
+		runner := watch.Runner{
+			Root: root,
+			Dir:  dir,
+			Year: year,
+			Day:  day,
+			Args: args,
+			Env:  env,
+		}
+
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+
+		log.WithField(ctx, "dir", filepath.Join(root, dir)).Info("Watching for changes, press Ctrl+C to stop")
+
+		var prev *watch.Round
+
+		return watch.Poll(ctx, c.Duration(flagInterval), []string{filepath.Join(root, dir)},
+			func(ctx context.Context, changed []string) {
+				cur := runner.Run(ctx)
+				if ctx.Err() != nil {
+					return
+				}
+
+				if err := watch.Render(c.App.Writer, prev, cur, changed); err != nil {
+					log.WithError(ctx, err).Error("Failed to print round")
+				}
+
+				if prev == nil || cur.Solved || !prev.Solved {
+					prev = &cur
+				}
+			})
+	}
+}
@@ -0,0 +1,71 @@
+// aocfake is a fake adventofcode.com server for offline development.
+//
+// Usage:
+//
+//	aocfake -dir fixtures -addr 127.0.0.1:8080
+//	AOC_BASE_URL=http://127.0.0.1:8080 aoc-cli run
+//
+// See package internal/aocfake for the fixtures layout.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	log "github.com/obalunenko/logger"
+
+	"github.com/obalunenko/advent-of-code/internal/aocfake"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	log.Init(ctx, log.Params{
+		Writer:     os.Stderr,
+		Level:      "info",
+		Format:     "text",
+		WithSource: false,
+	})
+
+	dir := flag.String("dir", "testdata", "Directory with fixtures")
+	addr := flag.String("addr", "127.0.0.1:8080", "Address to listen on")
+	cooldown := flag.Duration("cooldown", aocfake.DefaultCooldown, "Time to wait after wrong answer")
+
+	flag.Parse()
+
+	h, err := aocfake.NewHandler(*dir, aocfake.WithCooldown(*cooldown))
+	if err != nil {
+		log.WithError(ctx, err).Fatal("Failed to create handler")
+	}
+
+	const readTimeout = 10 * time.Second
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           h,
+		ReadHeaderTimeout: readTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		if err := srv.Shutdown(context.Background()); err != nil {
+			log.WithError(ctx, err).Error("Failed to shutdown")
+		}
+	}()
+
+	log.WithFields(ctx, log.Fields{
+		"addr": *addr,
+		"dir":  *dir,
+	}).Info("Serving fake adventofcode.com")
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.WithError(ctx, err).Fatal("Failed to serve")
+	}
+}
@@ -0,0 +1,121 @@
+package aocfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+// Verdicts returned on answer submission, the same as adventofcode.com uses.
+const (
+	VerdictRight     = "That's the right answer!"
+	VerdictWrong     = "That's not the right answer"
+	VerdictTooHigh   = "your answer is too high"
+	VerdictTooLow    = "your answer is too low"
+	VerdictTooRecent = "You gave an answer too recently"
+	VerdictWrongLvl  = "You don't seem to be solving the right level."
+)
+
+// serveAnswer checks submitted answer against answers.json fixture.
+// Rules follow adventofcode.com: parts are solved in order, wrong answer starts a cooldown,
+// numeric answers get too high/too low hint.
+func (h *Handler) serveAnswer(w http.ResponseWriter, r *http.Request, user *User, d input.Date) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	level := r.PostForm.Get("level")
+	answer := strings.TrimSpace(r.PostForm.Get("answer"))
+
+	answers, err := h.answers(user, d)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+
+	if last, ok := h.lastMiss[user.Name]; ok {
+		if left := last.Add(h.cooldown).Sub(now); left > 0 {
+			writeVerdict(w, fmt.Sprintf("%s; you have to wait after submitting an answer before trying again.  You have %ds left to wait.",
+				VerdictTooRecent, int(left.Seconds()+0.5)))
+
+			return
+		}
+	}
+
+	solved := user.Solved[d.String()]
+
+	want, ok := answers[level]
+	if !ok || level != strconv.Itoa(solved+1) {
+		writeVerdict(w, VerdictWrongLvl+"  Did you already complete it?")
+
+		return
+	}
+
+	if answer == want {
+		user.Solved[d.String()] = solved + 1
+
+		writeVerdict(w, VerdictRight+"  You are one gold star closer to saving your vacation.")
+
+		return
+	}
+
+	h.lastMiss[user.Name] = now
+
+	msg := VerdictWrong
+
+	if hint := compare(answer, want); hint != "" {
+		msg += "; " + hint
+	}
+
+	writeVerdict(w, msg+".  Please wait one minute before trying again.")
+}
+
+func (h *Handler) answers(user *User, d input.Date) (map[string]string, error) {
+	content, ok := h.fixture(user, path.Join("/", d.Year, "day", d.Day, answersFile))
+	if !ok {
+		return nil, fmt.Errorf("no answers for %s", d)
+	}
+
+	var answers map[string]string
+
+	if err := json.Unmarshal(content, &answers); err != nil {
+		return nil, fmt.Errorf("decode answers for %s: %w", d, err)
+	}
+
+	return answers, nil
+}
+
+func compare(got, want string) string {
+	g, err := strconv.Atoi(got)
+	if err != nil {
+		return ""
+	}
+
+	w, err := strconv.Atoi(want)
+	if err != nil {
+		return ""
+	}
+
+	if g > w {
+		return VerdictTooHigh
+	}
+
+	return VerdictTooLow
+}
+
+func writeVerdict(w http.ResponseWriter, msg string) {
+	writeHTML(w, http.StatusOK, "<main>\n<article><p>"+msg+"</p></article>\n</main>")
+}
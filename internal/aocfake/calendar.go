@@ -0,0 +1,78 @@
+package aocfake
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+const days = 25
+
+// serveCalendar writes calendar fixture of the year or generates the one with
+// user header and stars of solved days.
+func (h *Handler) serveCalendar(w http.ResponseWriter, r *http.Request, user *User, year string) {
+	if _, err := strconv.Atoi(year); err != nil {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	if _, ok := h.fixture(user, path.Join("/", year)); ok {
+		h.serveFile(w, user, path.Join("/", year))
+
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var sb strings.Builder
+
+	if user != nil {
+		fmt.Fprintf(&sb, "<header><div class=\"user\">%s <span class=\"star-count\">%d*</span></div></header>\n",
+			html.EscapeString(user.Name), user.Stars(year))
+	}
+
+	sb.WriteString("<main>\n<pre class=\"calendar\">\n")
+
+	for day := 1; day <= days; day++ {
+		d := input.Date{Year: year, Day: strconv.Itoa(day)}
+
+		if !h.unlocked(d) {
+			fmt.Fprintf(&sb, "<span class=\"calendar-day%d\">%2d</span>\n", day, day)
+
+			continue
+		}
+
+		var solved int
+
+		if user != nil {
+			solved = user.Solved[d.String()]
+		}
+
+		class, label := starsClass(solved)
+
+		fmt.Fprintf(&sb, "<a aria-label=\"Day %d%s\" href=\"/%s/day/%d\" class=\"calendar-day%d%s\">%2d</a>\n",
+			day, label, year, day, day, class, day)
+	}
+
+	sb.WriteString("</pre>\n</main>")
+
+	writeHTML(w, http.StatusOK, sb.String())
+}
+
+func starsClass(solved int) (string, string) {
+	switch {
+	case solved >= 2:
+		return " calendar-verycomplete", ", two stars"
+	case solved == 1:
+		return " calendar-complete", ", one star"
+	default:
+		return "", ""
+	}
+}
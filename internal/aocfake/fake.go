@@ -0,0 +1,307 @@
+// Package aocfake provides a fake adventofcode.com server for offline development and tests.
+//
+// Server is seeded from the directory of fixtures that mirrors site URL paths:
+//
+//	users.json                              - accounts by session token, optional
+//	2021/index.html                         - calendar page, generated when missing
+//	2021/day/1/index.html                   - puzzle page
+//	2021/day/1/input                        - puzzle input
+//	2021/day/1/answers.json                 - correct answers: {"1": "7", "2": "5"}
+//	2021/leaderboard/private/view/42.json   - private leaderboard
+//	users/<name>/...                        - per-user overlay of any file above
+//
+// When users.json is missing any non-empty session is accepted as an anonymous user.
+package aocfake
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+const (
+	usersFile   = "users.json"
+	usersDir    = "users"
+	answersFile = "answers.json"
+	indexFile   = "index.html"
+
+	sessionCookie = "session"
+	loginPath     = "/auth/login"
+
+	// DefaultCooldown is a time to wait after wrong answer before next submission.
+	DefaultCooldown = time.Minute
+)
+
+// User is an account of fake server.
+type User struct {
+	// Name is a display name.
+	Name string `json:"name"`
+	// Solved holds number of solved parts by "year/day", e.g. {"2021/1": 2}.
+	Solved map[string]int `json:"solved"`
+}
+
+// Stars returns number of collected stars in passed year.
+func (u User) Stars(year string) int {
+	var n int
+
+	for k, v := range u.Solved {
+		if strings.HasPrefix(k, year+"/") {
+			n += v
+		}
+	}
+
+	return n
+}
+
+const anonymous = "(anonymous user #1)"
+
+// Option configures Handler.
+type Option func(h *Handler)
+
+// WithClock sets the clock used for unlock times and submission cooldowns.
+func WithClock(now func() time.Time) Option {
+	return func(h *Handler) {
+		h.now = now
+	}
+}
+
+// WithCooldown sets a time to wait after wrong answer before next submission.
+func WithCooldown(d time.Duration) Option {
+	return func(h *Handler) {
+		h.cooldown = d
+	}
+}
+
+// Handler serves fake adventofcode.com pages from fixtures directory.
+type Handler struct {
+	dir      string
+	users    map[string]*User
+	anon     *User
+	now      func() time.Time
+	cooldown time.Duration
+
+	mu       sync.Mutex
+	lastMiss map[string]time.Time
+}
+
+// NewHandler creates Handler serving fixtures from dir.
+func NewHandler(dir string, opts ...Option) (*Handler, error) {
+	users, err := loadUsers(filepath.Join(dir, usersFile))
+	if err != nil {
+		return nil, err
+	}
+
+	h := Handler{
+		dir:      dir,
+		users:    users,
+		anon:     nil,
+		now:      time.Now,
+		cooldown: DefaultCooldown,
+		mu:       sync.Mutex{},
+		lastMiss: make(map[string]time.Time),
+	}
+
+	if users == nil {
+		h.anon = &User{
+			Name:   anonymous,
+			Solved: make(map[string]int),
+		}
+	}
+
+	for _, opt := range opts {
+		opt(&h)
+	}
+
+	return &h, nil
+}
+
+func loadUsers(fpath string) (map[string]*User, error) {
+	content, err := os.ReadFile(filepath.Clean(fpath))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read users: %w", err)
+	}
+
+	var users map[string]*User
+
+	if err = json.Unmarshal(content, &users); err != nil {
+		return nil, fmt.Errorf("decode users: %w", err)
+	}
+
+	for _, u := range users {
+		if u.Solved == nil {
+			u.Solved = make(map[string]int)
+		}
+	}
+
+	return users, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := path.Clean("/" + r.URL.Path)
+	elems := strings.Split(strings.Trim(p, "/"), "/")
+
+	user := h.user(r)
+
+	switch {
+	case p == loginPath:
+		h.serveLogin(w)
+	case len(elems) == 1 && elems[0] != "":
+		h.serveCalendar(w, r, user, elems[0])
+	case len(elems) >= 3 && elems[1] == "day":
+		h.serveDay(w, r, user, input.Date{Year: elems[0], Day: elems[2]}, elems[3:])
+	case len(elems) >= 2 && elems[1] == "leaderboard":
+		h.serveLeaderboard(w, r, user, p)
+	case p == "/"+usersFile || elems[0] == usersDir:
+		http.NotFound(w, r)
+	default:
+		h.serveFile(w, user, p)
+	}
+}
+
+func (h *Handler) user(r *http.Request) *User {
+	c, err := r.Cookie(sessionCookie)
+	if err != nil || c.Value == "" {
+		return nil
+	}
+
+	if h.anon != nil {
+		return h.anon
+	}
+
+	return h.users[c.Value]
+}
+
+func (h *Handler) serveDay(w http.ResponseWriter, r *http.Request, user *User, d input.Date, rest []string) {
+	if !h.unlocked(d) {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	p := path.Join("/", d.Year, "day", d.Day)
+
+	switch {
+	case len(rest) == 0:
+		h.serveFile(w, user, p)
+	case len(rest) == 1 && rest[0] == "input":
+		if user == nil {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+
+			return
+		}
+
+		h.serveFile(w, user, path.Join(p, "input"))
+	case len(rest) == 1 && rest[0] == "answer" && r.Method == http.MethodPost:
+		if user == nil {
+			http.Error(w, "Please log in to submit answers.", http.StatusBadRequest)
+
+			return
+		}
+
+		h.serveAnswer(w, r, user, d)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *Handler) unlocked(d input.Date) bool {
+	unlock, err := input.UnlockTime(d)
+	if err != nil {
+		return false
+	}
+
+	return !h.now().Before(unlock)
+}
+
+func (h *Handler) serveLeaderboard(w http.ResponseWriter, r *http.Request, user *User, p string) {
+	if user == nil {
+		http.Redirect(w, r, loginPath, http.StatusFound)
+
+		return
+	}
+
+	h.serveFile(w, user, p)
+}
+
+func (h *Handler) serveLogin(w http.ResponseWriter) {
+	writeHTML(w, http.StatusOK, `<main><p>To play, please identify yourself via one of these services:</p></main>`)
+}
+
+// serveFile writes fixture for URL path, preferring user overlay.
+func (h *Handler) serveFile(w http.ResponseWriter, user *User, p string) {
+	content, ok := h.fixture(user, p)
+	if !ok {
+		http.Error(w, "404 Not Found", http.StatusNotFound)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType(p))
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = w.Write(content) //nolint:gosec // fixtures are trusted.
+}
+
+// fixture returns content of fixture for URL path: file itself or index.html inside of directory.
+func (h *Handler) fixture(user *User, p string) ([]byte, bool) {
+	rel := filepath.FromSlash(strings.TrimPrefix(p, "/"))
+
+	var roots []string
+
+	if user != nil && user.Name != "" {
+		roots = append(roots, filepath.Join(h.dir, usersDir, user.Name))
+	}
+
+	roots = append(roots, h.dir)
+
+	for _, root := range roots {
+		for _, fpath := range []string{
+			filepath.Join(root, rel),
+			filepath.Join(root, rel, indexFile),
+		} {
+			content, err := os.ReadFile(filepath.Clean(fpath))
+			if err == nil {
+				return content, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func contentType(p string) string {
+	switch path.Ext(p) {
+	case ".json":
+		return "application/json"
+	case "":
+		if path.Base(p) == "input" {
+			return "text/plain"
+		}
+
+		return "text/html; charset=utf-8"
+	default:
+		return "text/html; charset=utf-8"
+	}
+}
+
+func writeHTML(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+
+	_, _ = fmt.Fprintf(w, "<!DOCTYPE html>\n<html lang=\"en-us\"><body>%s</body></html>\n", body)
+}
@@ -0,0 +1,401 @@
+package aocfake_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/aocfake"
+	"github.com/obalunenko/advent-of-code/internal/leaderboard"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/spec"
+	"github.com/obalunenko/advent-of-code/internal/session"
+)
+
+const (
+	fixtures = "testdata"
+	timeout  = 5 * time.Second
+)
+
+func clock(t time.Time) aocfake.Option {
+	return aocfake.WithClock(func() time.Time {
+		return t
+	})
+}
+
+var dec2021 = time.Date(2021, time.December, 10, 12, 0, 0, 0, time.UTC)
+
+func TestFetch(t *testing.T) {
+	srv := aocfake.NewServer(t, fixtures)
+
+	f := input.NewFetcher(http.DefaultClient, timeout, input.WithBaseURL(srv.URL))
+
+	d := input.Date{Year: "2021", Day: "1"}
+
+	tests := []struct {
+		name    string
+		date    input.Date
+		session string
+		want    []byte
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "shared input",
+			date:    d,
+			session: "alice-token",
+			want:    []byte("1,2,3"),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "user overlay input",
+			date:    d,
+			session: "bob-token",
+			want:    []byte("4,5,6"),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "no session",
+			date:    d,
+			session: "",
+			want:    nil,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, input.ErrUnauthorized)
+			},
+		},
+		{
+			name:    "unknown session",
+			date:    d,
+			session: "unknown",
+			want:    nil,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, input.ErrUnauthorized)
+			},
+		},
+		{
+			name:    "no fixture",
+			date:    input.Date{Year: "2021", Day: "2"},
+			session: "alice-token",
+			want:    nil,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, input.ErrNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.Fetch(context.Background(), tt.date, tt.session)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFetch_notYetUnlocked(t *testing.T) {
+	srv := aocfake.NewServer(t, fixtures, clock(time.Date(2021, time.November, 30, 0, 0, 0, 0, time.UTC)))
+
+	resp := get(t, srv.URL+"/2021/day/1/input", "alice-token")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = get(t, srv.URL+"/2021/day/1", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestFetchPuzzle(t *testing.T) {
+	srv := aocfake.NewServer(t, fixtures)
+
+	f := input.NewPuzzleFetcher(http.DefaultClient, timeout, input.WithBaseURL(srv.URL))
+
+	page, err := f.FetchPuzzle(context.Background(), input.Date{Year: "2021", Day: "1"}, "")
+	require.NoError(t, err)
+
+	s, err := spec.Parse(strings.NewReader(string(page)), srv.URL+"/2021/day/1")
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, s.Title)
+}
+
+func TestValidate(t *testing.T) {
+	srv := aocfake.NewServer(t, fixtures)
+
+	f := input.NewCalendarFetcher(http.DefaultClient, timeout, input.WithBaseURL(srv.URL))
+
+	acc, err := session.Validate(context.Background(), f, "alice-token")
+	require.NoError(t, err)
+
+	assert.Equal(t, "alice", acc.Name)
+
+	_, err = session.Validate(context.Background(), f, "unknown")
+	assert.ErrorIs(t, err, session.ErrInvalidSession)
+}
+
+func TestCalendar(t *testing.T) {
+	srv := aocfake.NewServer(t, fixtures, clock(dec2021))
+
+	f := input.NewCalendarFetcher(http.DefaultClient, timeout, input.WithBaseURL(srv.URL))
+
+	page, err := f.FetchCalendar(context.Background(), "2021", "alice-token")
+	require.NoError(t, err)
+
+	acc, err := session.ParseAccount(page)
+	require.NoError(t, err)
+
+	assert.Equal(t, session.Account{Name: "alice", Stars: 1, Event: ""}, acc)
+	assert.Contains(t, string(page), `class="calendar-day1 calendar-complete"`)
+	assert.Contains(t, string(page), `<span class="calendar-day11">`)
+}
+
+func TestLeaderboard(t *testing.T) {
+	srv := aocfake.NewServer(t, fixtures)
+
+	f := input.NewLeaderboardFetcher(http.DefaultClient, timeout, input.WithBaseURL(srv.URL))
+
+	lb, _, err := leaderboard.NewClient(f, t.TempDir()).Get(context.Background(), "2021", "42", "alice-token")
+	require.NoError(t, err)
+
+	assert.Equal(t, "2021", lb.Event)
+
+	_, _, err = leaderboard.NewClient(f, t.TempDir()).Get(context.Background(), "2021", "42", "")
+	assert.ErrorIs(t, err, leaderboard.ErrInvalidResponse)
+}
+
+func TestSubmit(t *testing.T) {
+	now := dec2021
+
+	srv := aocfake.NewServer(t, fixtures, aocfake.WithClock(func() time.Time {
+		return now
+	}))
+
+	steps := []struct {
+		name    string
+		session string
+		level   string
+		answer  string
+		advance time.Duration
+		want    []string
+	}{
+		{
+			name:    "already solved level",
+			session: "alice-token",
+			level:   "1",
+			answer:  "7",
+			want:    []string{aocfake.VerdictWrongLvl},
+		},
+		{
+			name:    "too high",
+			session: "alice-token",
+			level:   "2",
+			answer:  "10",
+			want:    []string{aocfake.VerdictWrong, aocfake.VerdictTooHigh},
+		},
+		{
+			name:    "cooldown",
+			session: "alice-token",
+			level:   "2",
+			answer:  "5",
+			advance: 15 * time.Second,
+			want:    []string{aocfake.VerdictTooRecent, "45s left"},
+		},
+		{
+			name:    "other user is not affected by cooldown",
+			session: "bob-token",
+			level:   "1",
+			answer:  "3",
+			want:    []string{aocfake.VerdictWrong, aocfake.VerdictTooLow},
+		},
+		{
+			name:    "right after cooldown",
+			session: "alice-token",
+			level:   "2",
+			answer:  "5",
+			advance: time.Minute,
+			want:    []string{aocfake.VerdictRight},
+		},
+		{
+			name:    "completed",
+			session: "alice-token",
+			level:   "2",
+			answer:  "5",
+			want:    []string{aocfake.VerdictWrongLvl},
+		},
+	}
+
+	for _, st := range steps {
+		now = now.Add(st.advance)
+
+		resp := submit(t, srv.URL+"/2021/day/1/answer", st.session, st.level, st.answer)
+		require.Equal(t, http.StatusOK, resp.StatusCode, st.name)
+
+		body := readBody(t, resp)
+
+		for _, w := range st.want {
+			assert.Contains(t, body, w, st.name)
+		}
+	}
+
+	resp := submit(t, srv.URL+"/2021/day/1/answer", "", "1", "7")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSubmitAnswer(t *testing.T) {
+	srv := aocfake.NewServer(t, fixtures, clock(dec2021))
+
+	sub := input.NewAnswerSubmitter(http.DefaultClient, timeout, input.WithBaseURL(srv.URL))
+	d := input.Date{Year: "2021", Day: "1"}
+
+	tests := []struct {
+		name    string
+		session string
+		part    string
+		answer  string
+		want    input.AnswerStatus
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "too low",
+			session: "bob-token",
+			part:    "1",
+			answer:  "3",
+			want:    input.AnswerTooLow,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "cooldown",
+			session: "bob-token",
+			part:    "1",
+			answer:  "7",
+			want:    input.AnswerTooRecent,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "right",
+			session: "alice-token",
+			part:    "2",
+			answer:  "5",
+			want:    input.AnswerRight,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "solved level",
+			session: "alice-token",
+			part:    "2",
+			answer:  "5",
+			want:    input.AnswerWrongLevel,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "no session",
+			session: "",
+			part:    "1",
+			answer:  "7",
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := sub.SubmitAnswer(context.Background(), d, tt.part, tt.answer, tt.session)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+
+			v, err := input.ParseVerdict(page)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, v.Status)
+			assert.NotEmpty(t, v.Message)
+		})
+	}
+}
+
+func TestNoUsers(t *testing.T) {
+	dir := t.TempDir()
+
+	srv := aocfake.NewServer(t, dir, clock(dec2021))
+
+	resp := get(t, srv.URL+"/2021", "any")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	acc, err := session.ParseAccount([]byte(readBody(t, resp)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "(anonymous user #1)", acc.Name)
+
+	resp = get(t, srv.URL+"/users.json", "any")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func get(tb testing.TB, u, sess string) *http.Response {
+	tb.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, u, http.NoBody)
+	require.NoError(tb, err)
+
+	return do(tb, req, sess)
+}
+
+func submit(tb testing.TB, u, sess, level, answer string) *http.Response {
+	tb.Helper()
+
+	form := url.Values{
+		"level":  []string{level},
+		"answer": []string{answer},
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, u, strings.NewReader(form.Encode()))
+	require.NoError(tb, err)
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return do(tb, req, sess)
+}
+
+func do(tb testing.TB, req *http.Request, sess string) *http.Response {
+	tb.Helper()
+
+	if sess != "" {
+		req.AddCookie(&http.Cookie{
+			Name:       "session",
+			Value:      sess,
+			Path:       "",
+			Domain:     "",
+			Expires:    time.Time{},
+			RawExpires: "",
+			MaxAge:     0,
+			Secure:     false,
+			HttpOnly:   false,
+			SameSite:   0,
+			Raw:        "",
+			Unparsed:   nil,
+		})
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(tb, err)
+
+	tb.Cleanup(func() {
+		_ = resp.Body.Close()
+	})
+
+	return resp
+}
+
+func readBody(tb testing.TB, resp *http.Response) string {
+	tb.Helper()
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(tb, err)
+
+	return string(b)
+}
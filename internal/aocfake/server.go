@@ -0,0 +1,23 @@
+package aocfake
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+// NewServer starts fake server with fixtures from dir. Server is closed on test cleanup.
+// Use its URL with input.WithBaseURL.
+func NewServer(tb testing.TB, dir string, opts ...Option) *httptest.Server {
+	tb.Helper()
+
+	h, err := NewHandler(dir, opts...)
+	if err != nil {
+		tb.Fatalf("create fake handler: %v", err)
+	}
+
+	srv := httptest.NewServer(h)
+
+	tb.Cleanup(srv.Close)
+
+	return srv
+}
@@ -0,0 +1,411 @@
+// Package codegen registers scaffolded puzzle packages in the repository source code: blank import of the package,
+// constant of the year with its stringer, and regression test case.
+//
+// Declarations, specs and literals are added to go/ast of the files and printed with go/format, so hand-written code
+// around is kept as is. All changes are idempotent: running registration of the same puzzle twice changes nothing.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const (
+	// SolutionsDir is a path of solutions package relative to the repository root.
+	SolutionsDir = "internal/puzzles/solutions"
+	// PuzzlesDir is a path of puzzles package relative to the repository root.
+	PuzzlesDir = "internal/puzzles"
+	// TestsDir is a path of regression tests relative to the repository root.
+	TestsDir = "tests"
+
+	constantsFile = "constants.go"
+
+	firstYear = 2015
+	minDay    = 1
+	maxDay    = 25
+)
+
+var (
+	// ErrUnexpectedSource is returned when source file to change has unexpected structure.
+	ErrUnexpectedSource = errors.New("unexpected source")
+	// ErrToolNotInstalled is returned when tool required for registration is not installed.
+	ErrToolNotInstalled = errors.New("tool is not installed")
+)
+
+// Register registers puzzle package of the year and day in the repository located at root:
+//   - adds blank import of the package to register_<year>.go of solutions package, creating the file for a new year;
+//   - adds Year<year> constant and regenerates stringer files of puzzles package for a new year;
+//   - adds regression test case, creating regression_<year>_test.go for a new year.
+//
+// Registration is checked by Check before any file is changed, and changed files are restored when it fails.
+func Register(root string, year, day int) error {
+	if err := Validate(year, day); err != nil {
+		return err
+	}
+
+	if err := Check(root, year); err != nil {
+		return err
+	}
+
+	module, err := modulePath(root)
+	if err != nil {
+		return err
+	}
+
+	snap, err := takeSnapshot(
+		filepath.Join(root, SolutionsDir, fmt.Sprintf("register_%d.go", year)),
+		filepath.Join(root, PuzzlesDir, constantsFile),
+		filepath.Join(root, PuzzlesDir, "year_string.go"),
+		filepath.Join(root, PuzzlesDir, "day_string.go"),
+		filepath.Join(root, TestsDir, fmt.Sprintf("regression_%d_test.go", year)),
+		filepath.Join(root, TestsDir, regressionFile),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err = register(root, module, year, day); err != nil {
+		if rerr := snap.restore(); rerr != nil {
+			return errors.Join(err, fmt.Errorf("restore files: %w", rerr))
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func register(root, module string, year, day int) error {
+	err := AddSolutionImport(filepath.Join(root, SolutionsDir), module+"/"+SolutionsDir, year, day)
+	if err != nil {
+		return fmt.Errorf("add solution import: %w", err)
+	}
+
+	constants := filepath.Join(root, PuzzlesDir, constantsFile)
+
+	added, err := AddYear(constants, year)
+	if err != nil {
+		return fmt.Errorf("add year constant: %w", err)
+	}
+
+	if added {
+		if err = GenerateStringers(constants); err != nil {
+			return fmt.Errorf("generate stringers: %w", err)
+		}
+	}
+
+	if err = AddRegressionCase(filepath.Join(root, TestsDir), module, year, day); err != nil {
+		return fmt.Errorf("add regression case: %w", err)
+	}
+
+	return nil
+}
+
+// Check checks that puzzle of the year could be registered in the repository located at root: stringer is installed
+// when Year<year> constant should be added.
+func Check(root string, year int) error {
+	path := filepath.Join(root, PuzzlesDir, constantsFile)
+
+	s, err := parseFile(path)
+	if err != nil {
+		return err
+	}
+
+	decl := enumDecl(s.file, yearPrefix)
+	if decl == nil {
+		return fmt.Errorf("%w: %s: %s enum not found", ErrUnexpectedSource, path, yearPrefix)
+	}
+
+	if _, exist := yearIndex(decl, year); exist {
+		return nil
+	}
+
+	if _, err = exec.LookPath(stringerTool); err != nil {
+		return fmt.Errorf("%w: %s is required to add year %d: %w", ErrToolNotInstalled, stringerTool, year, err)
+	}
+
+	return nil
+}
+
+// snapshot is a content of files before registration, nil for files which did not exist.
+type snapshot map[string][]byte
+
+func takeSnapshot(paths ...string) (snapshot, error) {
+	snap := make(snapshot, len(paths))
+
+	for _, path := range paths {
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("read file: %w", err)
+		}
+
+		snap[path] = content
+	}
+
+	return snap, nil
+}
+
+// restore restores files to their content in snapshot, removing ones which did not exist.
+func (s snapshot) restore() error {
+	var errs []error
+
+	for path, content := range s {
+		if content == nil {
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+
+			continue
+		}
+
+		if err := writeFile(path, content); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate checks that puzzle of the year and day may exist.
+func Validate(year, day int) error {
+	if year < firstYear {
+		return fmt.Errorf("invalid year %d: first event was in %d", year, firstYear)
+	}
+
+	if day < minDay || day > maxDay {
+		return fmt.Errorf("invalid day %d: should be in range [%d, %d]", day, minDay, maxDay)
+	}
+
+	return nil
+}
+
+// modulePath reads module path from go.mod at root.
+func modulePath(root string) (string, error) {
+	content, err := os.ReadFile(filepath.Clean(filepath.Join(root, "go.mod")))
+	if err != nil {
+		return "", fmt.Errorf("read go.mod: %w", err)
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(content))
+
+	for sc.Scan() {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(sc.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`), nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: module path not found in go.mod", ErrUnexpectedSource)
+}
+
+// source is a go file being changed.
+type source struct {
+	path string
+	fset *token.FileSet
+	file *ast.File
+}
+
+// parseFile parses go file at path with comments.
+func parseFile(path string) (*source, error) {
+	src, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	return parseSource(path, src)
+}
+
+// parseSource parses src of go file at path with comments. Objects are not resolved, so the tree has no cycles.
+func parseSource(path string, src []byte) (*source, error) {
+	fset := token.NewFileSet()
+
+	f, err := parser.ParseFile(fset, path, src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("parse file: %w", err)
+	}
+
+	return &source{
+		path: path,
+		fset: fset,
+		file: f,
+	}, nil
+}
+
+// lines are empty lines of the file, new nodes are placed on them.
+type lines struct {
+	file  *token.File
+	first int
+}
+
+// pos returns position at the start of i-th line. Each line has room for two positions: pos(i) and pos(i)+1.
+func (l lines) pos(i int) token.Pos {
+	return l.file.LineStart(l.first + i)
+}
+
+// reserve adds n empty lines to the file at the start of line with pos, or of the next line when next is true.
+// Printer lays out nodes by lines of their positions, so nodes placed on the reserved lines are printed as written
+// by hand.
+//
+// Source is not changed: positions of all nodes and comments are moved to a new file of the fileset, which has
+// the lines of the parsed one with the reserved lines inserted.
+func (s *source) reserve(pos token.Pos, next bool, n int) (lines, error) {
+	const lineSize = 2
+
+	old := s.fset.File(pos)
+
+	line := old.Line(pos)
+	if next {
+		line++
+	}
+
+	offset := old.Offset(old.LineStart(line))
+	shift := lineSize * n
+
+	// Comment group is split at the reserved lines, as parser does for comments separated by an empty line.
+	for i, cg := range s.file.Comments {
+		j := slices.IndexFunc(cg.List, func(c *ast.Comment) bool {
+			return old.Offset(c.Pos()) >= offset
+		})
+
+		if j > 0 {
+			s.file.Comments = slices.Insert(s.file.Comments, i+1, &ast.CommentGroup{List: cg.List[j:]})
+			cg.List = cg.List[:j]
+
+			break
+		}
+	}
+
+	f := s.fset.AddFile(old.Name(), -1, old.Size()+shift)
+
+	starts := make([]int, 0, old.LineCount()+n)
+
+	for _, o := range old.Lines() {
+		if o == offset {
+			for i := range n {
+				starts = append(starts, offset+lineSize*i)
+			}
+		}
+
+		if o >= offset {
+			o += shift
+		}
+
+		starts = append(starts, o)
+	}
+
+	if !f.SetLines(starts) {
+		return lines{}, fmt.Errorf("%w: %s: invalid lines", ErrUnexpectedSource, old.Name())
+	}
+
+	movePositions(reflect.ValueOf(s.file), make(map[uintptr]bool), func(p token.Pos) token.Pos {
+		o := old.Offset(p)
+		if o >= offset {
+			o += shift
+		}
+
+		return f.Pos(o)
+	})
+
+	return lines{
+		file:  f,
+		first: line,
+	}, nil
+}
+
+var posType = reflect.TypeFor[token.Pos]()
+
+// movePositions replaces valid positions of the syntax tree at v with returned by move. Shared nodes, e.g. comments
+// referenced by both file and declaration, are moved once.
+func movePositions(v reflect.Value, seen map[uintptr]bool, move func(token.Pos) token.Pos) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || seen[v.Pointer()] {
+			return
+		}
+
+		seen[v.Pointer()] = true
+
+		movePositions(v.Elem(), seen, move)
+	case reflect.Interface:
+		if !v.IsNil() {
+			movePositions(v.Elem(), seen, move)
+		}
+	case reflect.Slice:
+		for i := range v.Len() {
+			movePositions(v.Index(i), seen, move)
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			fv := v.Field(i)
+
+			if fv.Type() != posType {
+				movePositions(fv, seen, move)
+
+				continue
+			}
+
+			if p := token.Pos(fv.Int()); p.IsValid() && fv.CanSet() {
+				fv.SetInt(int64(move(p)))
+			}
+		}
+	default:
+	}
+}
+
+// addComment adds line comment with text at pos to the file and returns its group.
+func (s *source) addComment(pos token.Pos, text string) *ast.CommentGroup {
+	cg := &ast.CommentGroup{
+		List: []*ast.Comment{
+			{
+				Slash: pos,
+				Text:  "// " + text,
+			},
+		},
+	}
+
+	i, _ := slices.BinarySearchFunc(s.file.Comments, pos, func(c *ast.CommentGroup, pos token.Pos) int {
+		return cmp.Compare(c.Pos(), pos)
+	})
+
+	s.file.Comments = slices.Insert(s.file.Comments, i, cg)
+
+	return cg
+}
+
+// write prints the file with gofmt style and writes it to its path.
+func (s *source) write() error {
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, s.fset, s.file); err != nil {
+		return fmt.Errorf("format source: %w", err)
+	}
+
+	return writeFile(s.path, buf.Bytes())
+}
+
+func writeFile(path string, content []byte) error {
+	if err := os.WriteFile(filepath.Clean(path), content, os.ModePerm); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+
+	return nil
+}
+
+func isExist(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
@@ -0,0 +1,339 @@
+package codegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repoRoot is a path of the repository root relative to the package.
+const repoRoot = "../.."
+
+// copyRepoFiles copies repository files changed by registration to the temp dir and returns it.
+func copyRepoFiles(tb testing.TB) string {
+	tb.Helper()
+
+	root := tb.TempDir()
+
+	for _, path := range []string{
+		"go.mod",
+		filepath.Join(PuzzlesDir, constantsFile),
+		filepath.Join(PuzzlesDir, "year_string.go"),
+		filepath.Join(PuzzlesDir, "day_string.go"),
+		filepath.Join(SolutionsDir, "register_2023.go"),
+		filepath.Join(TestsDir, regressionFile),
+		filepath.Join(TestsDir, "regression_2023_test.go"),
+	} {
+		content, err := os.ReadFile(filepath.Join(repoRoot, path))
+		require.NoError(tb, err)
+
+		dst := filepath.Join(root, path)
+
+		require.NoError(tb, os.MkdirAll(filepath.Dir(dst), os.ModePerm))
+		require.NoError(tb, os.WriteFile(dst, content, os.ModePerm))
+	}
+
+	return root
+}
+
+func readFile(tb testing.TB, path string) string {
+	tb.Helper()
+
+	content, err := os.ReadFile(path)
+	require.NoError(tb, err)
+
+	return string(content)
+}
+
+// requireStringer skips the test when stringer is not installed.
+func requireStringer(tb testing.TB) {
+	tb.Helper()
+
+	if _, err := exec.LookPath(stringerTool); err != nil {
+		tb.Skip("stringer is not installed")
+	}
+}
+
+func TestGenerateStringers(t *testing.T) {
+	requireStringer(t)
+
+	root := copyRepoFiles(t)
+	dir := filepath.Join(root, PuzzlesDir)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "year_string.go")))
+	require.NoError(t, os.Remove(filepath.Join(dir, "day_string.go")))
+
+	require.NoError(t, GenerateStringers(filepath.Join(dir, constantsFile)))
+
+	for _, name := range []string{"year_string.go", "day_string.go"} {
+		assert.Equal(t, readFile(t, filepath.Join(repoRoot, PuzzlesDir, name)), readFile(t, filepath.Join(dir, name)),
+			"%s differs from generated by stringer", name)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	requireStringer(t)
+
+	root := copyRepoFiles(t)
+
+	require.NoError(t, Register(root, 2024, 3))
+	require.NoError(t, Register(root, 2024, 1))
+	require.NoError(t, Register(root, 2023, 2))
+
+	files := []string{
+		filepath.Join(SolutionsDir, "register_2023.go"),
+		filepath.Join(SolutionsDir, "register_2024.go"),
+		filepath.Join(PuzzlesDir, constantsFile),
+		filepath.Join(PuzzlesDir, "year_string.go"),
+		filepath.Join(TestsDir, regressionFile),
+		filepath.Join(TestsDir, "regression_2024_test.go"),
+	}
+
+	registered := make(map[string]string, len(files))
+
+	for _, path := range files {
+		registered[path] = readFile(t, filepath.Join(root, path))
+	}
+
+	assert.Equal(t, `package solutions
+
+import (
+	/*
+		2024 solutions.
+	*/
+	// register day01 solution.
+	_ "github.com/obalunenko/advent-of-code/internal/puzzles/solutions/2024/day01"
+	// register day03 solution.
+	_ "github.com/obalunenko/advent-of-code/internal/puzzles/solutions/2024/day03"
+)
+`, registered[filepath.Join(SolutionsDir, "register_2024.go")])
+
+	assert.Contains(t, registered[filepath.Join(SolutionsDir, "register_2023.go")],
+		"\t_ \"github.com/obalunenko/advent-of-code/internal/puzzles/solutions/2023/day01\"\n"+
+			"\t// register day02 solution.\n"+
+			"\t_ \"github.com/obalunenko/advent-of-code/internal/puzzles/solutions/2023/day02\"\n)\n")
+	assert.Contains(t, registered[filepath.Join(PuzzlesDir, constantsFile)],
+		"\tYear2023 // 2023\n\tYear2024 // 2024\n\n\tyearSentinel\n")
+	assert.Contains(t, registered[filepath.Join(PuzzlesDir, "year_string.go")], "_ = x[Year2024-10]")
+	assert.Contains(t, registered[filepath.Join(TestsDir, regressionFile)],
+		"\ttests = append(tests, testcases2023(t)...)\n\ttests = append(tests, testcases2024(t)...)\n")
+
+	cases := registered[filepath.Join(TestsDir, "regression_2024_test.go")]
+	assert.Contains(t, cases, "func testcases2024(tb testing.TB) []testcase {\n\tyear := puzzles.Year2024\n")
+	assert.Less(t, strings.Index(cases, "puzzles.Day01"), strings.Index(cases, "puzzles.Day03"), "cases are sorted by day")
+
+	t.Run("idempotent", func(t *testing.T) {
+		require.NoError(t, Register(root, 2024, 3))
+		require.NoError(t, Register(root, 2023, 2))
+
+		for _, path := range files {
+			assert.Equal(t, registered[path], readFile(t, filepath.Join(root, path)), path)
+		}
+	})
+}
+
+func TestRegister_failed(t *testing.T) {
+	files := []string{
+		filepath.Join(SolutionsDir, "register_2023.go"),
+		filepath.Join(PuzzlesDir, constantsFile),
+		filepath.Join(TestsDir, regressionFile),
+		filepath.Join(TestsDir, "regression_2023_test.go"),
+	}
+
+	snapshot := func(root string) map[string]string {
+		res := make(map[string]string, len(files))
+
+		for _, path := range files {
+			res[path] = readFile(t, filepath.Join(root, path))
+		}
+
+		return res
+	}
+
+	t.Run("stringer is not installed", func(t *testing.T) {
+		root := copyRepoFiles(t)
+		before := snapshot(root)
+
+		t.Setenv("PATH", t.TempDir())
+
+		require.ErrorIs(t, Register(root, 2024, 1), ErrToolNotInstalled)
+
+		assert.Equal(t, before, snapshot(root))
+		assert.NoFileExists(t, filepath.Join(root, SolutionsDir, "register_2024.go"))
+	})
+
+	t.Run("files restored", func(t *testing.T) {
+		root := copyRepoFiles(t)
+
+		cases := filepath.Join(root, TestsDir, "regression_2023_test.go")
+		require.NoError(t, os.WriteFile(cases, []byte(strings.Replace(readFile(t, cases),
+			"func testcases2023(", "func cases2023(", 1)), os.ModePerm))
+
+		before := snapshot(root)
+
+		require.ErrorIs(t, Register(root, 2023, 2), ErrUnexpectedSource)
+
+		assert.Equal(t, before, snapshot(root), "import added before failed step is removed")
+	})
+}
+
+func TestAddYear(t *testing.T) {
+	root := copyRepoFiles(t)
+	path := filepath.Join(root, PuzzlesDir, constantsFile)
+
+	added, err := AddYear(path, 2024)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	content := readFile(t, path)
+	assert.Contains(t, content, "\tYear2022 // 2022\n\tYear2023 // 2023\n\tYear2024 // 2024\n\n\tyearSentinel\n")
+
+	added, err = AddYear(path, 2023)
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Equal(t, content, readFile(t, path))
+}
+
+func TestAddSolutionImport(t *testing.T) {
+	root := copyRepoFiles(t)
+	dir := filepath.Join(root, SolutionsDir)
+	prefix := "github.com/obalunenko/advent-of-code/" + SolutionsDir
+
+	require.NoError(t, AddSolutionImport(dir, prefix, 2024, 3))
+	require.NoError(t, AddSolutionImport(dir, prefix, 2024, 1))
+	require.NoError(t, AddSolutionImport(dir, prefix, 2024, 2))
+	require.NoError(t, AddSolutionImport(dir, prefix, 2024, 1))
+
+	assert.Equal(t, `package solutions
+
+import (
+	/*
+		2024 solutions.
+	*/
+	// register day01 solution.
+	_ "github.com/obalunenko/advent-of-code/internal/puzzles/solutions/2024/day01"
+	// register day02 solution.
+	_ "github.com/obalunenko/advent-of-code/internal/puzzles/solutions/2024/day02"
+	// register day03 solution.
+	_ "github.com/obalunenko/advent-of-code/internal/puzzles/solutions/2024/day03"
+)
+`, readFile(t, filepath.Join(dir, "register_2024.go")))
+}
+
+func TestAddRegressionCase(t *testing.T) {
+	root := copyRepoFiles(t)
+	dir := filepath.Join(root, TestsDir)
+	module := "github.com/obalunenko/advent-of-code"
+
+	require.NoError(t, AddRegressionCase(dir, module, 2024, 3))
+	require.NoError(t, AddRegressionCase(dir, module, 2024, 1))
+
+	assert.Equal(t, `package tests_test
+
+import (
+	"testing"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+)
+
+func testcases2024(tb testing.TB) []testcase {
+	year := puzzles.Year2024
+
+	return []testcase{
+		{
+			name: tcName(tb, year, puzzles.Day01),
+			args: args{
+				year: year.String(),
+				name: puzzles.Day01.String(),
+			},
+			want: puzzles.Result{
+				Year:  year.String(),
+				Name:  puzzles.Day01.String(),
+				Part1: "",
+				Part2: "",
+			},
+			wantErr: false,
+		},
+		{
+			name: tcName(tb, year, puzzles.Day03),
+			args: args{
+				year: year.String(),
+				name: puzzles.Day03.String(),
+			},
+			want: puzzles.Result{
+				Year:  year.String(),
+				Name:  puzzles.Day03.String(),
+				Part1: "",
+				Part2: "",
+			},
+			wantErr: false,
+		},
+	}
+}
+`, readFile(t, filepath.Join(dir, "regression_2024_test.go")))
+	assert.Contains(t, readFile(t, filepath.Join(dir, regressionFile)),
+		"\ttests = append(tests, testcases2023(t)...)\n\ttests = append(tests, testcases2024(t)...)\n")
+
+	t.Run("not registered case", func(t *testing.T) {
+		path := filepath.Join(dir, "regression_2023_test.go")
+		before := readFile(t, path)
+
+		require.NoError(t, AddRegressionCase(dir, module, 2023, 2))
+
+		after := readFile(t, path)
+		day02 := func(content string) string {
+			start := strings.Index(content, "puzzles.Day02),")
+
+			return content[start : start+strings.Index(content[start:], "},\n\t\t{")]
+		}
+
+		assert.Contains(t, day02(before), "wantErr: true")
+		assert.Contains(t, day02(after), "wantErr: false")
+		assert.Equal(t, strings.Replace(before, day02(before), day02(after), 1), after, "only case of the day is changed")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		year    int
+		day     int
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "valid",
+			year:    2024,
+			day:     25,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "year before first event",
+			year:    2014,
+			day:     1,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "day out of range",
+			year:    2024,
+			day:     26,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "zero day",
+			year:    2024,
+			day:     0,
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, Validate(tt.year, tt.day))
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const yearPrefix = "Year"
+
+// AddYear adds Year<year> constant to the Year enum declared in file at path and reports whether it was added.
+// Constants are kept sorted by year.
+func AddYear(path string, year int) (bool, error) {
+	s, err := parseFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	decl := enumDecl(s.file, yearPrefix)
+	if decl == nil {
+		return false, fmt.Errorf("%w: %s: %s enum not found", ErrUnexpectedSource, path, yearPrefix)
+	}
+
+	i, exist := yearIndex(decl, year)
+	if exist {
+		return false, nil
+	}
+
+	if i < 0 {
+		return false, fmt.Errorf("%w: %s: no %s constants in enum", ErrUnexpectedSource, path, yearPrefix)
+	}
+
+	// Constant is placed right before the next year or right after the previous one.
+	var (
+		l    lines
+		next bool
+	)
+
+	if i < len(decl.Specs) {
+		_, next = specYear(decl.Specs[i])
+	}
+
+	if next {
+		l, err = s.reserve(decl.Specs[i].Pos(), false, 1)
+	} else {
+		l, err = s.reserve(decl.Specs[i-1].Pos(), true, 1)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	decl.Specs = slices.Insert(decl.Specs, i, ast.Spec(&ast.ValueSpec{
+		Names: []*ast.Ident{
+			{
+				NamePos: l.pos(0),
+				Name:    yearPrefix + strconv.Itoa(year),
+			},
+		},
+		Comment: s.addComment(l.pos(0)+1, strconv.Itoa(year)),
+	}))
+
+	if err = s.write(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// yearIndex returns index of spec in decl the constant of year is declared at or should be inserted to, -1 when there
+// are no year constants in decl.
+func yearIndex(decl *ast.GenDecl, year int) (int, bool) {
+	i := -1
+
+	for j, s := range decl.Specs {
+		y, ok := specYear(s)
+		if !ok {
+			continue
+		}
+
+		if y == year {
+			return j, true
+		}
+
+		if y > year {
+			return j, false
+		}
+
+		i = j + 1
+	}
+
+	return i, false
+}
+
+// specYear returns year of Year<year> constant declared by spec.
+func specYear(s ast.Spec) (int, bool) {
+	vs, ok := s.(*ast.ValueSpec)
+	if !ok || len(vs.Names) != 1 {
+		return 0, false
+	}
+
+	return yearOf(vs.Names[0].Name)
+}
+
+// yearOf returns year of Year<year> constant name.
+func yearOf(name string) (int, bool) {
+	s, ok := strings.CutPrefix(name, yearPrefix)
+	if !ok {
+		return 0, false
+	}
+
+	y, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false
+	}
+
+	return y, true
+}
+
+// enumDecl returns const declaration of constants with type typ.
+func enumDecl(f *ast.File, typ string) *ast.GenDecl {
+	for _, d := range f.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+
+		for _, s := range gd.Specs {
+			if vs, ok := s.(*ast.ValueSpec); ok && isIdent(vs.Type, typ) {
+				return gd
+			}
+		}
+	}
+
+	return nil
+}
+
+func isIdent(e ast.Expr, name string) bool {
+	id, ok := e.(*ast.Ident)
+
+	return ok && id.Name == name
+}
@@ -0,0 +1,115 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strconv"
+)
+
+// AddSolutionImport adds blank import of the puzzle package to register_<year>.go in dir.
+// File is created when it does not exist. Imports are kept sorted by path.
+func AddSolutionImport(dir, pkgPrefix string, year, day int) error {
+	path := filepath.Join(dir, fmt.Sprintf("register_%d.go", year))
+	pkg := fmt.Sprintf("%s/%d/day%02d", pkgPrefix, year, day)
+
+	var (
+		s   *source
+		err error
+	)
+
+	if isExist(path) {
+		s, err = parseFile(path)
+	} else {
+		s, err = parseSource(path, []byte(fmt.Sprintf(
+			"package %s\n\nimport (\n\t/*\n\t\t%d solutions.\n\t*/\n)\n", filepath.Base(dir), year)))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	decl := importDecl(s.file)
+	if decl == nil || !decl.Lparen.IsValid() {
+		return fmt.Errorf("%w: %s: grouped import declaration not found", ErrUnexpectedSource, path)
+	}
+
+	i, exist, err := importIndex(decl, pkg)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrUnexpectedSource, path, err)
+	}
+
+	if exist {
+		return nil
+	}
+
+	var l lines
+
+	if i < len(decl.Specs) {
+		// Placed right before the comment of the next import, keeping the year comment above the first one.
+		pos := decl.Specs[i].Pos()
+		if is, ok := decl.Specs[i].(*ast.ImportSpec); ok && is.Doc != nil {
+			pos = is.Doc.List[len(is.Doc.List)-1].Pos()
+		}
+
+		l, err = s.reserve(pos, false, 2)
+	} else {
+		l, err = s.reserve(decl.Rparen, false, 2)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	decl.Specs = slices.Insert(decl.Specs, i, ast.Spec(&ast.ImportSpec{
+		Doc: s.addComment(l.pos(0), fmt.Sprintf("register day%02d solution.", day)),
+		Name: &ast.Ident{
+			NamePos: l.pos(1),
+			Name:    "_",
+		},
+		Path: &ast.BasicLit{
+			ValuePos: l.pos(1) + 1,
+			Kind:     token.STRING,
+			Value:    strconv.Quote(pkg),
+		},
+	}))
+
+	return s.write()
+}
+
+// importIndex returns index of spec in decl pkg is imported at or should be inserted to.
+func importIndex(decl *ast.GenDecl, pkg string) (int, bool, error) {
+	for i, s := range decl.Specs {
+		is, ok := s.(*ast.ImportSpec)
+		if !ok {
+			continue
+		}
+
+		ipath, err := strconv.Unquote(is.Path.Value)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if ipath == pkg {
+			return i, true, nil
+		}
+
+		if ipath > pkg {
+			return i, false, nil
+		}
+	}
+
+	return len(decl.Specs), false, nil
+}
+
+func importDecl(f *ast.File) *ast.GenDecl {
+	for _, d := range f.Decls {
+		if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+			return gd
+		}
+	}
+
+	return nil
+}
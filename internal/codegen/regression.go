@@ -0,0 +1,394 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	regressionFile    = "regression_test.go"
+	regressionRunFunc = "TestRun"
+	casesFuncPrefix   = "testcases"
+
+	// caseLines is a number of lines of the case literal built by regressionCase.
+	caseLines = 14
+)
+
+// AddRegressionCase adds case of not solved puzzle to regression tests in dir. For a new year regression_<year>_test.go
+// is created and its cases are added to the regression run. Case of not registered puzzle, expecting an error, is
+// turned into case of not solved one.
+func AddRegressionCase(dir, module string, year, day int) error {
+	path := filepath.Join(dir, fmt.Sprintf("regression_%d_test.go", year))
+	fn := fmt.Sprintf("%s%d", casesFuncPrefix, year)
+
+	if !isExist(path) {
+		s, err := parseSource(path, []byte(fmt.Sprintf(`package tests_test
+
+import (
+	"testing"
+
+	"%s/internal/puzzles"
+)
+
+func %s(tb testing.TB) []testcase {
+	year := puzzles.Year%d
+
+	return []testcase{
+	}
+}
+`, module, fn, year)))
+		if err != nil {
+			return err
+		}
+
+		if err = addCase(s, fn, day); err != nil {
+			return err
+		}
+
+		return addCasesToRun(filepath.Join(dir, regressionFile), fn)
+	}
+
+	s, err := parseFile(path)
+	if err != nil {
+		return err
+	}
+
+	return addCase(s, fn, day)
+}
+
+// addCase adds case of the day to cases literal returned by function fn in s and writes it. Cases are kept sorted by
+// day.
+func addCase(s *source, fn string, day int) error {
+	cases := casesLit(s.file, fn)
+	if cases == nil {
+		return fmt.Errorf("%w: %s: cases literal of %s not found", ErrUnexpectedSource, s.path, fn)
+	}
+
+	i := len(cases.Elts)
+
+	for j, elt := range cases.Elts {
+		d, ok := caseDay(elt)
+		if !ok {
+			continue
+		}
+
+		if d == day {
+			if !unregisteredToStub(elt) {
+				return nil
+			}
+
+			return s.write()
+		}
+
+		if d > day {
+			i = j
+
+			break
+		}
+	}
+
+	var (
+		l   lines
+		err error
+	)
+
+	if i < len(cases.Elts) {
+		l, err = s.reserve(cases.Elts[i].Pos(), false, caseLines)
+	} else {
+		l, err = s.reserve(cases.Rbrace, false, caseLines)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	cases.Elts = slices.Insert(cases.Elts, i, ast.Expr(regressionCase(l, day)))
+
+	return s.write()
+}
+
+// regressionCase returns case of not solved puzzle placed on lines l. Answers are filled when puzzle is solved.
+//
+// Registered puzzle not solved yet returns empty answers without an error.
+func regressionCase(l lines, day int) *ast.CompositeLit {
+	dayConst := func(line int) ast.Expr {
+		return selector(l.pos(line), "puzzles", fmt.Sprintf("Day%02d", day))
+	}
+
+	stringCall := func(line int, x ast.Expr) ast.Expr {
+		return call(l.pos(line), &ast.SelectorExpr{X: x, Sel: ident(l.pos(line), "String")})
+	}
+
+	return &ast.CompositeLit{
+		Lbrace: l.pos(0),
+		Elts: []ast.Expr{
+			keyValue(l.pos(1), "name",
+				call(l.pos(1), ident(l.pos(1), "tcName"), ident(l.pos(1), "tb"), ident(l.pos(1), "year"), dayConst(1))),
+			keyValue(l.pos(2), "args", &ast.CompositeLit{
+				Type:   ident(l.pos(2), "args"),
+				Lbrace: l.pos(2),
+				Elts: []ast.Expr{
+					keyValue(l.pos(3), "year", stringCall(3, ident(l.pos(3), "year"))),
+					keyValue(l.pos(4), "name", stringCall(4, dayConst(4))),
+				},
+				Rbrace: l.pos(5),
+			}),
+			keyValue(l.pos(6), "want", &ast.CompositeLit{
+				Type:   selector(l.pos(6), "puzzles", "Result"),
+				Lbrace: l.pos(6),
+				Elts: []ast.Expr{
+					keyValue(l.pos(7), "Year", stringCall(7, ident(l.pos(7), "year"))),
+					keyValue(l.pos(8), "Name", stringCall(8, dayConst(8))),
+					keyValue(l.pos(9), "Part1", emptyString(l.pos(9))),
+					keyValue(l.pos(10), "Part2", emptyString(l.pos(10))),
+				},
+				Rbrace: l.pos(11),
+			}),
+			keyValue(l.pos(12), "wantErr", ident(l.pos(12), "false")),
+		},
+		Rbrace: l.pos(13),
+	}
+}
+
+// unregisteredToStub turns case of not registered puzzle, expecting an error, into case built by regressionCase.
+// It reports whether case was changed.
+func unregisteredToStub(elt ast.Expr) bool {
+	lit, ok := elt.(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+
+	wantErr, ok := field(lit, "wantErr").(*ast.Ident)
+	if !ok || wantErr.Name != "true" {
+		return false
+	}
+
+	wantErr.Name = "false"
+
+	if want, ok := field(lit, "want").(*ast.CompositeLit); ok {
+		for _, part := range []string{"Part1", "Part2"} {
+			if v, ok := field(want, part).(*ast.BasicLit); ok {
+				v.Kind, v.Value = token.STRING, `""`
+			}
+		}
+	}
+
+	return true
+}
+
+// field returns value of the key in composite literal lit.
+func field(lit *ast.CompositeLit, key string) ast.Expr {
+	for _, e := range lit.Elts {
+		if kv, ok := e.(*ast.KeyValueExpr); ok && isIdent(kv.Key, key) {
+			return kv.Value
+		}
+	}
+
+	return nil
+}
+
+// casesLit returns composite literal returned by function fn.
+func casesLit(f *ast.File, fn string) *ast.CompositeLit {
+	var lit *ast.CompositeLit
+
+	for _, d := range f.Decls {
+		fd, ok := d.(*ast.FuncDecl)
+		if !ok || fd.Name.Name != fn || fd.Body == nil {
+			continue
+		}
+
+		for _, st := range fd.Body.List {
+			if rs, ok := st.(*ast.ReturnStmt); ok && len(rs.Results) == 1 {
+				lit, _ = rs.Results[0].(*ast.CompositeLit)
+			}
+		}
+	}
+
+	return lit
+}
+
+// caseDay returns day of the case from puzzles.Day<NN> argument of its name.
+func caseDay(elt ast.Expr) (int, bool) {
+	lit, ok := elt.(*ast.CompositeLit)
+	if !ok {
+		return 0, false
+	}
+
+	call, ok := field(lit, "name").(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return 0, false
+	}
+
+	sel, ok := call.Args[len(call.Args)-1].(*ast.SelectorExpr)
+	if !ok {
+		return 0, false
+	}
+
+	d, err := strconv.Atoi(strings.TrimPrefix(sel.Sel.Name, "Day"))
+	if err != nil {
+		return 0, false
+	}
+
+	return d, true
+}
+
+// addCasesToRun appends cases returned by function fn to the tests of regression run in file at path.
+// Calls are kept sorted by function name.
+func addCasesToRun(path, fn string) error {
+	s, err := parseFile(path)
+	if err != nil {
+		return err
+	}
+
+	body := runBody(s.file)
+	if body == nil {
+		return fmt.Errorf("%w: %s: %s not found", ErrUnexpectedSource, path, regressionRunFunc)
+	}
+
+	i, exist := appendIndex(body, fn)
+	if exist {
+		return nil
+	}
+
+	if i < 0 {
+		return fmt.Errorf("%w: %s: cases are not appended in %s", ErrUnexpectedSource, path, regressionRunFunc)
+	}
+
+	// Call is placed right before the call of the next year or right after the previous one.
+	var (
+		l    lines
+		next bool
+	)
+
+	if i < len(body.List) {
+		_, next = appendedCases(body.List[i])
+	}
+
+	if next {
+		l, err = s.reserve(body.List[i].Pos(), false, 1)
+	} else {
+		l, err = s.reserve(body.List[i-1].Pos(), true, 1)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	tests := ident(l.pos(0), "tests")
+	appendCall := call(l.pos(0), ident(l.pos(0), "append"), tests, call(l.pos(0), ident(l.pos(0), fn), ident(l.pos(0), "t")))
+	appendCall.Ellipsis = l.pos(0)
+
+	body.List = slices.Insert(body.List, i, ast.Stmt(&ast.AssignStmt{
+		Lhs:    []ast.Expr{tests},
+		TokPos: l.pos(0),
+		Tok:    token.ASSIGN,
+		Rhs:    []ast.Expr{appendCall},
+	}))
+
+	return s.write()
+}
+
+// runBody returns body of the regression run function.
+func runBody(f *ast.File) *ast.BlockStmt {
+	for _, d := range f.Decls {
+		if fd, ok := d.(*ast.FuncDecl); ok && fd.Name.Name == regressionRunFunc {
+			return fd.Body
+		}
+	}
+
+	return nil
+}
+
+// appendIndex returns index of statement in body cases of function fn are appended at or should be inserted to,
+// -1 when cases are not appended in body.
+func appendIndex(body *ast.BlockStmt, fn string) (int, bool) {
+	i := -1
+
+	for j, st := range body.List {
+		name, ok := appendedCases(st)
+		if !ok {
+			continue
+		}
+
+		if name == fn {
+			return j, true
+		}
+
+		if name > fn {
+			return j, false
+		}
+
+		i = j + 1
+	}
+
+	return i, false
+}
+
+// appendedCases returns name of cases function from `tests = append(tests, testcases<year>(t)...)` statement.
+func appendedCases(st ast.Stmt) (string, bool) {
+	as, ok := st.(*ast.AssignStmt)
+	if !ok || as.Tok != token.ASSIGN || len(as.Rhs) != 1 {
+		return "", false
+	}
+
+	call, ok := as.Rhs[0].(*ast.CallExpr)
+	if !ok || !isIdent(call.Fun, "append") || !call.Ellipsis.IsValid() || len(call.Args) != 2 {
+		return "", false
+	}
+
+	inner, ok := call.Args[1].(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+
+	id, ok := inner.Fun.(*ast.Ident)
+	if !ok || !strings.HasPrefix(id.Name, casesFuncPrefix) {
+		return "", false
+	}
+
+	return id.Name, true
+}
+
+func ident(pos token.Pos, name string) *ast.Ident {
+	return &ast.Ident{
+		NamePos: pos,
+		Name:    name,
+	}
+}
+
+func selector(pos token.Pos, x, sel string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ident(pos, x),
+		Sel: ident(pos, sel),
+	}
+}
+
+func call(pos token.Pos, fun ast.Expr, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{
+		Fun:    fun,
+		Lparen: pos,
+		Args:   args,
+		Rparen: pos,
+	}
+}
+
+func keyValue(pos token.Pos, key string, value ast.Expr) *ast.KeyValueExpr {
+	return &ast.KeyValueExpr{
+		Key:   ident(pos, key),
+		Colon: pos,
+		Value: value,
+	}
+}
+
+func emptyString(pos token.Pos) *ast.BasicLit {
+	return &ast.BasicLit{
+		ValuePos: pos,
+		Kind:     token.STRING,
+		Value:    `""`,
+	}
+}
@@ -0,0 +1,23 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+)
+
+const stringerTool = "stringer"
+
+// GenerateStringers regenerates stringer files of enums declared in file at path by running its go:generate stringer
+// directives. stringer should be installed.
+func GenerateStringers(path string) error {
+	cmd := exec.Command("go", "generate", "-run", "^//go:generate stringer ", filepath.Base(path))
+	cmd.Dir = filepath.Dir(path)
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go generate: %w: %s", err, bytes.TrimSpace(out))
+	}
+
+	return nil
+}
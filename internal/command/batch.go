@@ -0,0 +1,412 @@
+package command
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/report"
+)
+
+const (
+	// DefaultBatchTimeout is a default time limit for solving one puzzle in batch, including input fetching.
+	DefaultBatchTimeout = time.Minute
+	// SlowestNum is a number of the slowest puzzles listed in batch summary.
+	SlowestNum = 10
+)
+
+// ErrTimeout returns when puzzle was not solved in time.
+var ErrTimeout = errors.New("puzzle timed out")
+
+// BatchOption configures batch run.
+type BatchOption func(b *batch)
+
+// WithWorkers sets number of puzzles solved concurrently. Non-positive number is ignored.
+func WithWorkers(n int) BatchOption {
+	return func(b *batch) {
+		if n > 0 {
+			b.workers = n
+		}
+	}
+}
+
+// WithTimeout sets time limit for solving one puzzle. Non-positive timeout is ignored.
+func WithTimeout(timeout time.Duration) BatchOption {
+	return func(b *batch) {
+		if timeout > 0 {
+			b.timeout = timeout
+		}
+	}
+}
+
+// WithInputDir makes batch read inputs from dir in <dir>/<year>/day/<day>/input layout instead of fetching them.
+func WithInputDir(dir string) BatchOption {
+	return func(b *batch) {
+		b.dir = dir
+	}
+}
+
+// WithOnStart sets func called when worker starts solving puzzle, e.g. to report progress.
+func WithOnStart(f func(s puzzles.Solver)) BatchOption {
+	return func(b *batch) {
+		b.onStart = f
+	}
+}
+
+type batch struct {
+	workers int
+	timeout time.Duration
+	dir     string
+	onStart func(s puzzles.Solver)
+}
+
+// BatchResult is a result of solving one puzzle in batch.
+type BatchResult struct {
+	Year   string
+	Day    string
+	Result puzzles.Result
+	// Elapsed is time spent by solver, input fetching is not included.
+	Elapsed time.Duration
+	// Part1Elapsed and Part2Elapsed are times spent by solver on each part.
+	Part1Elapsed time.Duration
+	Part2Elapsed time.Duration
+	Err          error
+}
+
+// RunBatch solves puzzles of passed solvers by pool of workers. Results are sent to returned channel
+// as soon as puzzles are solved, channel is closed when all of them are done or ctx is canceled.
+// Caller that stops reading results before channel is closed should cancel ctx to stop the workers.
+//
+// Solver that exceeded timeout is reported with ErrTimeout, but its goroutine runs until solver returns,
+// as solvers could not be interrupted.
+func RunBatch(ctx context.Context, solvers []puzzles.Solver, opts ...BatchOption) (<-chan BatchResult, error) {
+	b := batch{
+		workers: runtime.NumCPU(),
+		timeout: DefaultBatchTimeout,
+		dir:     "",
+		onStart: nil,
+	}
+
+	for _, opt := range opts {
+		opt(&b)
+	}
+
+	cli, err := b.fetcher(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make(chan puzzles.Solver)
+	results := make(chan BatchResult)
+
+	var wg sync.WaitGroup
+
+	for range b.workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for s := range jobs {
+				if b.onStart != nil {
+					b.onStart(s)
+				}
+
+				select {
+				case results <- b.solve(ctx, cli, s):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+
+		for _, s := range solvers {
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- s:
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+
+		close(results)
+	}()
+
+	return results, nil
+}
+
+// fetcher returns inputs dir reader when dir is set, or fetcher configured by context otherwise.
+func (b batch) fetcher(ctx context.Context) (input.Fetcher, error) {
+	if b.dir == "" {
+		return NewFetcher(ctx)
+	}
+
+	v, err := openVault()
+	if err != nil {
+		return nil, err
+	}
+
+	return input.NewDirFetcher(b.dir, v), nil
+}
+
+func (b batch) solve(ctx context.Context, cli input.Fetcher, s puzzles.Solver) BatchResult {
+	res := BatchResult{
+		Year:         s.Year(),
+		Day:          s.Day(),
+		Result:       puzzles.Result{},
+		Elapsed:      0,
+		Part1Elapsed: 0,
+		Part2Elapsed: 0,
+		Err:          nil,
+	}
+
+	fullName, err := puzzles.MakeName(s.Year(), s.Day())
+	if err != nil {
+		res.Err = fmt.Errorf("failed to make full name: %w", err)
+
+		return res
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, b.timeout)
+	defer cancel()
+
+	asset, err := fetch(ctx, cli, input.Date{
+		Year: s.Year(),
+		Day:  s.Day(),
+	})
+	if err != nil {
+		res.Err = fmt.Errorf("failed to get input for puzzle: %w", err)
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			res.Err = fmt.Errorf("%w: %w", ErrTimeout, res.Err)
+		}
+
+		return res
+	}
+
+	type outcome struct {
+		result puzzles.Result
+		parts  [2]time.Duration
+		err    error
+	}
+
+	done := make(chan outcome, 1)
+	start := time.Now()
+
+	go func() {
+		// Parts are measured by the same observer as served solves, benchmark runs are not observed.
+		var parts [2]time.Duration
+
+		octx := ContextWithOptions(ctx, append(OptionsFromContext(ctx), puzzles.WithObserver(func(m puzzles.PartMetric) {
+			switch m.Part {
+			case puzzles.PartOne:
+				parts[0] = m.Elapsed
+			case puzzles.PartTwo:
+				parts[1] = m.Elapsed
+			}
+		}))...)
+
+		r, serr := solve(octx, s, fullName, asset)
+
+		done <- outcome{
+			result: r,
+			parts:  parts,
+			err:    serr,
+		}
+	}()
+
+	select {
+	case o := <-done:
+		res.Result, res.Err = o.result, o.err
+		res.Part1Elapsed, res.Part2Elapsed = o.parts[0], o.parts[1]
+	case <-ctx.Done():
+		res.Err = fmt.Errorf("[%s]: %w after %s", fullName, ErrTimeout, b.timeout)
+
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			res.Err = fmt.Errorf("[%s]: %w", fullName, ctx.Err())
+		}
+	}
+
+	res.Elapsed = time.Since(start)
+
+	return res
+}
+
+// Cases returns results of both puzzle parts for reports, with expected answers if known.
+// When solving failed, both cases hold the error.
+func (r BatchResult) Cases(expected Answers) []report.Case {
+	parts := []struct {
+		answer   string
+		expected string
+		elapsed  time.Duration
+	}{
+		{answer: r.Result.Part1, expected: expected.Part1, elapsed: r.Part1Elapsed},
+		{answer: r.Result.Part2, expected: expected.Part2, elapsed: r.Part2Elapsed},
+	}
+
+	cases := make([]report.Case, 0, len(parts))
+
+	for i, p := range parts {
+		cases = append(cases, report.Case{
+			Year:     r.Year,
+			Day:      r.Day,
+			Part:     i + 1,
+			Answer:   p.answer,
+			Expected: p.expected,
+			Duration: p.elapsed,
+			Err:      r.Err,
+		})
+	}
+
+	return cases
+}
+
+// YearSummary holds totals of batch run for one year.
+type YearSummary struct {
+	Year    string
+	Solved  int
+	Failed  int
+	Elapsed time.Duration
+}
+
+// BatchSummary summarizes batch run.
+type BatchSummary struct {
+	// Years are sorted by year.
+	Years []YearSummary
+	Total YearSummary
+	// Slowest are solved puzzles sorted by elapsed time, not more than SlowestNum.
+	Slowest []BatchResult
+}
+
+// SummarizeBatch calculates totals per year and picks the slowest puzzles.
+func SummarizeBatch(results []BatchResult) BatchSummary {
+	byYear := make(map[string]*YearSummary)
+
+	total := YearSummary{
+		Year:    "total",
+		Solved:  0,
+		Failed:  0,
+		Elapsed: 0,
+	}
+
+	var solved []BatchResult
+
+	for _, r := range results {
+		ys, ok := byYear[r.Year]
+		if !ok {
+			ys = &YearSummary{
+				Year:    r.Year,
+				Solved:  0,
+				Failed:  0,
+				Elapsed: 0,
+			}
+
+			byYear[r.Year] = ys
+		}
+
+		for _, s := range []*YearSummary{ys, &total} {
+			s.Elapsed += r.Elapsed
+
+			if r.Err != nil {
+				s.Failed++
+			} else {
+				s.Solved++
+			}
+		}
+
+		if r.Err == nil {
+			solved = append(solved, r)
+		}
+	}
+
+	summary := BatchSummary{
+		Years:   make([]YearSummary, 0, len(byYear)),
+		Total:   total,
+		Slowest: nil,
+	}
+
+	for _, ys := range byYear {
+		summary.Years = append(summary.Years, *ys)
+	}
+
+	slices.SortFunc(summary.Years, func(a, b YearSummary) int {
+		return cmp.Compare(a.Year, b.Year)
+	})
+
+	slices.SortStableFunc(solved, func(a, b BatchResult) int {
+		return cmp.Compare(b.Elapsed, a.Elapsed)
+	})
+
+	summary.Slowest = solved[:min(len(solved), SlowestNum)]
+
+	return summary
+}
+
+// RenderBatchResult writes one line with result of solving puzzle.
+func RenderBatchResult(w io.Writer, r BatchResult) error {
+	var err error
+
+	if r.Err != nil {
+		_, err = fmt.Fprintf(w, "FAIL  %-7s  %12s  %v\n", r.Year+"/"+r.Day, formatElapsed(r.Elapsed), r.Err)
+	} else {
+		_, err = fmt.Fprintf(w, "ok    %-7s  %12s  part1: %s  part2: %s\n",
+			r.Year+"/"+r.Day, formatElapsed(r.Elapsed), r.Result.Part1, r.Result.Part2)
+	}
+
+	if err != nil {
+		return fmt.Errorf("write result: %w", err)
+	}
+
+	return nil
+}
+
+// RenderBatchSummary writes totals per year and the slowest puzzles.
+func RenderBatchSummary(w io.Writer, s BatchSummary) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	lines := []string{"\nYEAR\tSOLVED\tFAILED\tTIME"}
+
+	for _, ys := range append(slices.Clone(s.Years), s.Total) {
+		lines = append(lines, fmt.Sprintf("%s\t%d\t%d\t%s", ys.Year, ys.Solved, ys.Failed, formatElapsed(ys.Elapsed)))
+	}
+
+	if len(s.Slowest) != 0 {
+		lines = append(lines, "\nSLOWEST\tTIME")
+
+		for _, r := range s.Slowest {
+			lines = append(lines, fmt.Sprintf("%s/%s\t%s", r.Year, r.Day, formatElapsed(r.Elapsed)))
+		}
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(tw, line); err != nil {
+			return fmt.Errorf("write summary: %w", err)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+
+	return nil
+}
+
+func formatElapsed(d time.Duration) string {
+	return d.Round(time.Microsecond).String()
+}
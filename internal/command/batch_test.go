@@ -0,0 +1,134 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"runtime"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+// blockingSolver blocks until release is closed.
+type blockingSolver struct {
+	mockSolver
+	release chan struct{}
+}
+
+func (b blockingSolver) Part1(in io.Reader) (string, error) {
+	<-b.release
+
+	return b.mockSolver.Part1(in)
+}
+
+func TestRunBatch(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() {
+		close(release)
+	})
+
+	solvers := []puzzles.Solver{
+		mockSolver{year: "1992", name: "28"},
+		mockSolver{year: "1992", name: "30"},
+		mockSolver{year: "1992", name: "31"},
+		blockingSolver{mockSolver: mockSolver{year: "1993", name: "31"}, release: release},
+	}
+
+	ch, err := RunBatch(context.Background(), solvers,
+		WithInputDir("testdata"), WithWorkers(2), WithTimeout(100*time.Millisecond))
+	require.NoError(t, err)
+
+	var results []BatchResult
+
+	for r := range ch {
+		results = append(results, r)
+	}
+
+	require.Len(t, results, len(solvers))
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Year+results[i].Day < results[j].Year+results[j].Day
+	})
+
+	assert.ErrorIs(t, results[0].Err, ErrSolverFailed)
+	assert.ErrorIs(t, results[1].Err, input.ErrNotFound)
+	assert.NoError(t, results[2].Err)
+	assert.Equal(t, puzzles.Result{Year: "1992", Name: "31", Part1: "2", Part2: "3"}, results[2].Result)
+	assert.ErrorIs(t, results[3].Err, ErrTimeout)
+
+	assert.Positive(t, results[2].Part1Elapsed)
+	assert.Positive(t, results[2].Part2Elapsed)
+
+	cases := results[2].Cases(Answers{Part1: "2", Part2: "4"})
+	require.Len(t, cases, 2)
+	assert.False(t, cases[0].Failed())
+	assert.True(t, cases[1].Failed())
+	assert.Equal(t, results[2].Part2Elapsed, cases[1].Duration)
+}
+
+func TestRunBatch_canceled(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch, err := RunBatch(ctx, []puzzles.Solver{
+		mockSolver{year: "1992", name: "31"},
+		mockSolver{year: "1992", name: "31"},
+		mockSolver{year: "1992", name: "31"},
+	}, WithInputDir("testdata"), WithWorkers(2))
+	require.NoError(t, err)
+
+	<-ch
+
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		return runtime.NumGoroutine() <= goroutines
+	}, time.Second, 10*time.Millisecond, "workers should stop when results are not read")
+}
+
+func TestSummarizeBatch(t *testing.T) {
+	var results []BatchResult
+
+	for i := range SlowestNum + 2 {
+		results = append(results, BatchResult{
+			Year:    "2021",
+			Day:     puzzles.Day(i + 1).String(),
+			Result:  puzzles.Result{},
+			Elapsed: time.Duration(i+1) * time.Millisecond,
+			Err:     nil,
+		})
+	}
+
+	results = append(results, BatchResult{
+		Year:    "2020",
+		Day:     "1",
+		Result:  puzzles.Result{},
+		Elapsed: time.Second,
+		Err:     ErrTimeout,
+	})
+
+	got := SummarizeBatch(results)
+
+	assert.Equal(t, []YearSummary{
+		{Year: "2020", Solved: 0, Failed: 1, Elapsed: time.Second},
+		{Year: "2021", Solved: 12, Failed: 0, Elapsed: 78 * time.Millisecond},
+	}, got.Years)
+	assert.Equal(t, YearSummary{Year: "total", Solved: 12, Failed: 1, Elapsed: time.Second + 78*time.Millisecond}, got.Total)
+
+	require.Len(t, got.Slowest, SlowestNum)
+	assert.Equal(t, 12*time.Millisecond, got.Slowest[0].Elapsed, "failed puzzles are not listed as slowest")
+	assert.Equal(t, 3*time.Millisecond, got.Slowest[SlowestNum-1].Elapsed)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, RenderBatchSummary(&buf, got))
+	assert.Contains(t, buf.String(), "total  12      1       1.078s")
+}
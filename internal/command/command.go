@@ -11,16 +11,22 @@
 
 	"github.com/obalunenko/advent-of-code/internal/puzzles"
 	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/vault"
 )
 
-// ErrUnauthorized returns when session is empty or invalid.
-var ErrUnauthorized = errors.New("unauthorized")
+var (
+	// ErrUnauthorized returns when session is empty or invalid.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrSolverFailed returns when solver failed on the input.
+	ErrSolverFailed = errors.New("solver failed")
+)
 
 // Run runs puzzle solving for passed year/day date.
 func Run(ctx context.Context, year, day string) (puzzles.Result, error) {
-	const timeout = time.Second * 30
-
-	cli := input.NewFetcher(http.DefaultClient, timeout)
+	cli, err := NewFetcher(ctx)
+	if err != nil {
+		return puzzles.Result{}, err
+	}
 
 	result, err := run(ctx, cli, year, day)
 	if err != nil {
@@ -34,6 +40,35 @@ func Run(ctx context.Context, year, day string) (puzzles.Result, error) {
 	return result, nil
 }
 
+// NewFetcher creates inputs fetcher configured by context: base URL of the site and inputs cache.
+func NewFetcher(ctx context.Context) (input.Fetcher, error) {
+	const timeout = time.Second * 30
+
+	cli := input.NewFetcher(http.DefaultClient, timeout, input.WithBaseURL(BaseURLFromContext(ctx)))
+
+	dir := InputCacheFromContext(ctx)
+	if dir == "" {
+		return cli, nil
+	}
+
+	v, err := openVault()
+	if err != nil {
+		return nil, err
+	}
+
+	return input.NewCachedFetcher(cli, dir, v), nil
+}
+
+// openVault returns vault when its key is set, or nil otherwise.
+func openVault() (*vault.Vault, error) {
+	v, err := vault.FromEnv()
+	if err != nil && !errors.Is(err, vault.ErrNoKey) {
+		return nil, fmt.Errorf("failed to open vault: %w", err)
+	}
+
+	return v, nil
+}
+
 func run(ctx context.Context, cli input.Fetcher, year, day string) (puzzles.Result, error) {
 	s, err := puzzles.GetSolver(year, day)
 	if err != nil {
@@ -45,20 +80,111 @@ func run(ctx context.Context, cli input.Fetcher, year, day string) (puzzles.Resu
 		return puzzles.Result{}, fmt.Errorf("failed to make full name: %w", err)
 	}
 
-	asset, err := cli.Fetch(ctx, input.Date{
+	asset, err := fetch(ctx, cli, input.Date{
 		Year: year,
 		Day:  day,
-	}, SessionFromContext(ctx))
+	})
 	if err != nil {
 		return puzzles.Result{}, fmt.Errorf("failed to get input for puzzle: %w", err)
 	}
 
+	return solve(ctx, s, fullName, asset)
+}
+
+// solve runs solver on input with run options from context.
+func solve(ctx context.Context, s puzzles.Solver, fullName string, asset []byte) (puzzles.Result, error) {
 	opts := OptionsFromContext(ctx)
 
 	res, err := puzzles.Solve(s, bytes.NewReader(asset), opts...)
 	if err != nil {
-		return puzzles.Result{}, fmt.Errorf("failed to run [%s]: %w", fullName, err)
+		return puzzles.Result{}, fmt.Errorf("failed to run [%s]: %w: %w", fullName, ErrSolverFailed, err)
 	}
 
 	return res, nil
 }
+
+// Input is not always available right at unlock time: clocks differ and site is loaded at midnight, so fetch after
+// unlock is retried with growing backoff within a bounded window.
+var (
+	unlockRetryWindow  = 30 * time.Second
+	unlockRetryBackoff = time.Second
+)
+
+const unlockRetryMaxBackoff = 8 * time.Second
+
+// fetch gets puzzle input. When waiting for unlock is enabled in context, it blocks until
+// puzzle is unlocked and fetches input right after.
+func fetch(ctx context.Context, cli input.Fetcher, d input.Date) ([]byte, error) {
+	asset, err := cli.Fetch(ctx, d, SessionFromContext(ctx))
+	if err == nil {
+		return asset, nil
+	}
+
+	notify := UnlockWaitFromContext(ctx)
+
+	var unlockErr *input.NotYetUnlockedError
+
+	if notify == nil || !errors.As(err, &unlockErr) {
+		return nil, err
+	}
+
+	if err = waitUnlock(ctx, unlockErr.UnlockAt, notify); err != nil {
+		return nil, fmt.Errorf("wait for unlock: %w", err)
+	}
+
+	return fetchUnlocked(ctx, cli, d)
+}
+
+// fetchUnlocked fetches input of just unlocked puzzle. Fetch is retried while input is not found or puzzle is not
+// unlocked yet, until unlockRetryWindow passes.
+func fetchUnlocked(ctx context.Context, cli input.Fetcher, d input.Date) ([]byte, error) {
+	deadline := time.Now().Add(unlockRetryWindow)
+	backoff := unlockRetryBackoff
+
+	for {
+		asset, err := cli.Fetch(ctx, d, SessionFromContext(ctx))
+		if err == nil {
+			return asset, nil
+		}
+
+		if !errors.Is(err, input.ErrNotFound) && !errors.Is(err, input.ErrNotYetUnlocked) {
+			return nil, err
+		}
+
+		if time.Now().Add(backoff).After(deadline) {
+			return nil, err
+		}
+
+		t := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+
+		backoff = min(2*backoff, unlockRetryMaxBackoff)
+	}
+}
+
+func waitUnlock(ctx context.Context, unlock time.Time, notify UnlockNotifier) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		remaining := time.Until(unlock)
+		if remaining <= 0 {
+			return nil
+		}
+
+		notify(remaining)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
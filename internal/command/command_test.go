@@ -5,57 +5,19 @@
 	"errors"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/obalunenko/advent-of-code/internal/aocfake"
 	"github.com/obalunenko/advent-of-code/internal/puzzles"
 	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
 )
 
-// Custom type that allows setting the func that our Mock Do func will run instead
-type dofunc func(req *http.Request) (*http.Response, error)
-
-// MockClient is the mock client
-type mockHTTPClient struct {
-	MockDo dofunc
-}
-
-type returnParams struct {
-	status int
-	body   io.ReadCloser
-}
-
-func newMockHTTPClient(p returnParams) *mockHTTPClient {
-	return &mockHTTPClient{
-		MockDo: func(req *http.Request) (*http.Response, error) {
-			return &http.Response{
-				Status:           http.StatusText(p.status),
-				StatusCode:       p.status,
-				Proto:            req.Proto,
-				ProtoMajor:       req.ProtoMajor,
-				ProtoMinor:       req.ProtoMinor,
-				Header:           req.Header,
-				Body:             p.body,
-				ContentLength:    0,
-				TransferEncoding: nil,
-				Close:            false,
-				Uncompressed:     false,
-				Trailer:          nil,
-				Request:          nil,
-				TLS:              nil,
-			}, nil
-		},
-	}
-}
-
-// Overriding what the Do function should "do" in our MockClient
-func (m *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
-	return m.MockDo(req)
-}
-
 type mockSolver struct {
 	year string
 	name string
@@ -103,35 +65,43 @@ func read(in io.Reader) ([]string, error) {
 }
 
 func TestRun(t *testing.T) {
-	ctx := context.Background()
+	srv := aocfake.NewServer(t, "testdata")
+
+	ctx := ContextWithBaseURL(context.Background(), srv.URL)
 
 	year := "1992"
-	day := "31"
 
-	puzzles.Register(mockSolver{
-		year: year,
-		name: day,
-	})
+	for _, day := range []string{"28", "29", "30", "31"} {
+		puzzles.Register(mockSolver{
+			year: year,
+			name: day,
+		})
+	}
 
 	t.Cleanup(func() {
 		puzzles.UnregisterAllSolvers(t)
 	})
 
+	type args struct {
+		day     string
+		session string
+	}
+
 	type expected struct {
 		result  puzzles.Result
 		wantErr assert.ErrorAssertionFunc
 	}
 
 	tests := []struct {
-		name         string
-		returnParams returnParams
-		expected     expected
+		name     string
+		args     args
+		expected expected
 	}{
 		{
-			name: "",
-			returnParams: returnParams{
-				status: http.StatusOK,
-				body:   io.NopCloser(strings.NewReader("1,2,3")),
+			name: "ok",
+			args: args{
+				day:     "31",
+				session: "gopher-token",
 			},
 			expected: expected{
 				result: puzzles.Result{
@@ -144,47 +114,62 @@ type expected struct {
 			},
 		},
 		{
-			name: "",
-			returnParams: returnParams{
-				status: http.StatusNotFound,
-				body:   http.NoBody,
+			name: "input not found",
+			args: args{
+				day:     "30",
+				session: "gopher-token",
 			},
 			expected: expected{
-				result:  puzzles.Result{},
-				wantErr: assert.Error,
+				result: puzzles.Result{},
+				wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+					return assert.ErrorIs(t, err, input.ErrNotFound)
+				},
 			},
 		},
 		{
-			name: "",
-			returnParams: returnParams{
-				status: http.StatusUnauthorized,
-				body:   http.NoBody,
+			name: "invalid session",
+			args: args{
+				day:     "31",
+				session: "invalid",
 			},
 			expected: expected{
-				result:  puzzles.Result{},
-				wantErr: assert.Error,
+				result: puzzles.Result{},
+				wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+					return assert.ErrorIs(t, err, ErrUnauthorized)
+				},
 			},
 		},
 		{
-			name: "",
-			returnParams: returnParams{
-				status: http.StatusOK,
-				body:   http.NoBody,
+			name: "empty input",
+			args: args{
+				day:     "29",
+				session: "gopher-token",
 			},
 			expected: expected{
 				result:  puzzles.Result{},
 				wantErr: assert.Error,
 			},
 		},
+		{
+			name: "solver failed",
+			args: args{
+				day:     "28",
+				session: "gopher-token",
+			},
+			expected: expected{
+				result: puzzles.Result{},
+				wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+					return assert.ErrorIs(t, err, ErrSolverFailed)
+				},
+			},
+		},
 	}
 
 	for i := range tests {
 		tt := tests[i]
 
 		t.Run(tt.name, func(t *testing.T) {
-			cli := input.NewFetcher(newMockHTTPClient(tt.returnParams), time.Second*5)
-
-			got, err := run(ctx, cli, year, day)
+			got, err := Run(ContextWithSession(ctx, tt.args.session), year, tt.args.day)
 			if !tt.expected.wantErr(t, err) {
 				return
 			}
@@ -193,3 +178,90 @@ type expected struct {
 		})
 	}
 }
+
+func TestRun_notYetUnlocked(t *testing.T) {
+	year := "2999"
+	day := "1"
+
+	puzzles.Register(mockSolver{
+		year: year,
+		name: day,
+	})
+
+	t.Cleanup(func() {
+		puzzles.UnregisterAllSolvers(t)
+	})
+
+	srv := aocfake.NewServer(t, "testdata")
+
+	cli := input.NewFetcher(http.DefaultClient, time.Second*5, input.WithBaseURL(srv.URL))
+
+	_, err := run(context.Background(), cli, year, day)
+	assert.ErrorIs(t, err, input.ErrNotYetUnlocked)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var remaining time.Duration
+
+	ctx = ContextWithUnlockWait(ctx, func(r time.Duration) {
+		remaining = r
+
+		cancel()
+	})
+
+	_, err = run(ctx, cli, year, day)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Positive(t, remaining)
+}
+
+// flakyFetcher fails with errs one by one before answering with input.
+type flakyFetcher struct {
+	errs  []error
+	calls int
+}
+
+func (f *flakyFetcher) Fetch(_ context.Context, _ input.Date, _ string) ([]byte, error) {
+	f.calls++
+
+	if len(f.errs) != 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+
+		return nil, err
+	}
+
+	return []byte("input"), nil
+}
+
+func Test_fetchUnlocked(t *testing.T) {
+	window, backoff := unlockRetryWindow, unlockRetryBackoff
+
+	t.Cleanup(func() {
+		unlockRetryWindow, unlockRetryBackoff = window, backoff
+	})
+
+	unlockRetryWindow, unlockRetryBackoff = 50*time.Millisecond, time.Millisecond
+
+	ctx := context.Background()
+	d := input.Date{Year: "2021", Day: "1"}
+
+	f := &flakyFetcher{errs: []error{input.ErrNotFound, &input.NotYetUnlockedError{}}}
+
+	got, err := fetchUnlocked(ctx, f, d)
+	require.NoError(t, err)
+	assert.Equal(t, "input", string(got))
+	assert.Equal(t, 3, f.calls, "fetch is retried right after unlock")
+
+	f = &flakyFetcher{errs: []error{input.ErrUnauthorized}}
+
+	_, err = fetchUnlocked(ctx, f, d)
+	require.ErrorIs(t, err, input.ErrUnauthorized)
+	assert.Equal(t, 1, f.calls, "other errors are not retried")
+
+	f = &flakyFetcher{errs: slices.Repeat([]error{input.ErrNotFound}, 100)}
+
+	_, err = fetchUnlocked(ctx, f, d)
+	require.ErrorIs(t, err, input.ErrNotFound)
+	assert.Less(t, f.calls, 100, "fetch is retried within window")
+}
@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"time"
 
 	"github.com/obalunenko/advent-of-code/internal/puzzles"
 )
@@ -59,3 +60,81 @@ func OptionsFromContext(ctx context.Context) []puzzles.RunOption {
 
 	return opts
 }
+
+// UnlockNotifier is called every second while waiting for puzzle unlock with the remaining time.
+type UnlockNotifier func(remaining time.Duration)
+
+type waitCtxKey struct{}
+
+// ContextWithUnlockWait enables waiting for puzzle unlock instead of failing with input.ErrNotYetUnlocked.
+func ContextWithUnlockWait(ctx context.Context, notify UnlockNotifier) context.Context {
+	if notify == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, waitCtxKey{}, notify)
+}
+
+// UnlockWaitFromContext extracts unlock notifier from context. Returns nil when waiting is not enabled.
+func UnlockWaitFromContext(ctx context.Context) UnlockNotifier {
+	if ctx == nil {
+		return nil
+	}
+
+	notify, ok := ctx.Value(waitCtxKey{}).(UnlockNotifier)
+	if !ok {
+		return nil
+	}
+
+	return notify
+}
+
+type baseURLCtxKey struct{}
+
+// ContextWithBaseURL sets base URL of Advent of Code site to fetch inputs from, e.g. local fake server.
+func ContextWithBaseURL(ctx context.Context, u string) context.Context {
+	if u == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, baseURLCtxKey{}, u)
+}
+
+// BaseURLFromContext extracts base URL from context. Returns empty string when it is not set.
+func BaseURLFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	u, ok := ctx.Value(baseURLCtxKey{}).(string)
+	if !ok {
+		return ""
+	}
+
+	return u
+}
+
+type cacheCtxKey struct{}
+
+// ContextWithInputCache enables caching of inputs in dir.
+func ContextWithInputCache(ctx context.Context, dir string) context.Context {
+	if dir == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, cacheCtxKey{}, dir)
+}
+
+// InputCacheFromContext extracts inputs cache dir from context. Returns empty string when caching is disabled.
+func InputCacheFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	dir, ok := ctx.Value(cacheCtxKey{}).(string)
+	if !ok {
+		return ""
+	}
+
+	return dir
+}
@@ -3,8 +3,10 @@
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/obalunenko/advent-of-code/internal/command"
 	"github.com/obalunenko/advent-of-code/internal/puzzles"
@@ -40,4 +42,42 @@ func TestContext(t *testing.T) {
 
 	gotopts = command.OptionsFromContext(context.Background())
 	assert.Equal(t, []puzzles.RunOption{}, gotopts)
+
+	var notified bool
+
+	ctx = command.ContextWithUnlockWait(ctx, func(_ time.Duration) {
+		notified = true
+	})
+
+	notify := command.UnlockWaitFromContext(ctx)
+	require.NotNil(t, notify)
+
+	notify(time.Second)
+	assert.True(t, notified)
+
+	assert.Nil(t, command.UnlockWaitFromContext(nilContext()))
+	assert.Nil(t, command.UnlockWaitFromContext(context.Background()))
+	assert.Nil(t, command.UnlockWaitFromContext(command.ContextWithUnlockWait(context.Background(), nil)))
+}
+
+func TestContextWithBaseURL(t *testing.T) {
+	const u = "http://127.0.0.1:8080"
+
+	ctx := command.ContextWithBaseURL(context.Background(), u)
+	assert.Equal(t, u, command.BaseURLFromContext(ctx))
+
+	assert.Equal(t, "", command.BaseURLFromContext(nilContext()))
+	assert.Equal(t, "", command.BaseURLFromContext(context.Background()))
+	assert.Equal(t, "", command.BaseURLFromContext(command.ContextWithBaseURL(context.Background(), "")))
+}
+
+func TestContextWithInputCache(t *testing.T) {
+	const dir = "/tmp/aoc-cli/inputs"
+
+	ctx := command.ContextWithInputCache(context.Background(), dir)
+	assert.Equal(t, dir, command.InputCacheFromContext(ctx))
+
+	assert.Equal(t, "", command.InputCacheFromContext(nilContext()))
+	assert.Equal(t, "", command.InputCacheFromContext(context.Background()))
+	assert.Equal(t, "", command.InputCacheFromContext(command.ContextWithInputCache(context.Background(), "")))
 }
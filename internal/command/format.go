@@ -0,0 +1,275 @@
+package command
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+)
+
+// Format of results output.
+type Format string
+
+const (
+	// FormatTable is a human-readable table.
+	FormatTable Format = "table"
+	// FormatJSON is a JSON array.
+	FormatJSON Format = "json"
+	// FormatYAML is a YAML list.
+	FormatYAML Format = "yaml"
+	// FormatCSV is a CSV with header.
+	FormatCSV Format = "csv"
+	// FormatMarkdown is a markdown table.
+	FormatMarkdown Format = "markdown"
+)
+
+// ErrUnknownFormat returns when format is not supported.
+var ErrUnknownFormat = errors.New("unknown format")
+
+// Formats returns names of supported formats.
+func Formats() []string {
+	return []string{
+		string(FormatTable),
+		string(FormatJSON),
+		string(FormatYAML),
+		string(FormatCSV),
+		string(FormatMarkdown),
+	}
+}
+
+// ParseFormat parses format name. Empty name is parsed as FormatTable.
+func ParseFormat(s string) (Format, error) {
+	if s == "" {
+		return FormatTable, nil
+	}
+
+	if !slices.Contains(Formats(), s) {
+		return "", fmt.Errorf("%w %q, expected one of: %s", ErrUnknownFormat, s, strings.Join(Formats(), ", "))
+	}
+
+	return Format(s), nil
+}
+
+// batchResultView is a representation of BatchResult in JSON and YAML.
+type batchResultView struct {
+	Year      string          `json:"year" yaml:"year"`
+	Day       string          `json:"day" yaml:"day"`
+	Result    *puzzles.Result `json:"result,omitempty" yaml:"result,omitempty"`
+	ElapsedNS int64           `json:"elapsed_ns,omitempty" yaml:"elapsed_ns,omitempty"`
+	Error     string          `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func (r BatchResult) view() batchResultView {
+	v := batchResultView{
+		Year:      r.Year,
+		Day:       r.Day,
+		Result:    nil,
+		ElapsedNS: r.Elapsed.Nanoseconds(),
+		Error:     "",
+	}
+
+	if r.Err != nil {
+		v.Error = r.Err.Error()
+	} else {
+		v.Result = &r.Result
+	}
+
+	return v
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r BatchResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.view())
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (r BatchResult) MarshalYAML() (any, error) {
+	return r.view(), nil
+}
+
+var resultsHeader = []string{
+	"year", "day", "part1", "part2", "elapsed_ns",
+	"bench_n", "bench_ns_per_op", "bench_bytes_per_op", "bench_allocs_per_op", "error",
+}
+
+func resultRecord(r BatchResult) []string {
+	rec := []string{r.Year, r.Day, r.Result.Part1, r.Result.Part2, "", "", "", "", "", ""}
+
+	elapsed, ok := r.Result.Elapsed()
+	if r.Elapsed != 0 {
+		elapsed, ok = r.Elapsed, true
+	}
+
+	if ok {
+		rec[4] = strconv.FormatInt(elapsed.Nanoseconds(), 10)
+	}
+
+	if b, ok := r.Result.Benchmark(); ok {
+		rec[5] = strconv.Itoa(b.N)
+		rec[6] = strconv.FormatInt(b.NsPerOp(), 10)
+		rec[7] = strconv.FormatInt(b.AllocedBytesPerOp(), 10)
+		rec[8] = strconv.FormatInt(b.AllocsPerOp(), 10)
+	}
+
+	if r.Err != nil {
+		rec[9] = r.Err.Error()
+	}
+
+	return rec
+}
+
+// WriteResults writes results in passed format. Table format renders a line per result as RenderBatchResult does.
+func WriteResults(w io.Writer, f Format, results []BatchResult) error {
+	if f == FormatTable {
+		for _, r := range results {
+			if err := RenderBatchResult(w, r); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return writeFormatted(w, f, results, resultsHeader, resultRecord)
+}
+
+// matrixRowView is a representation of MatrixRow in JSON and YAML.
+type matrixRowView struct {
+	Account string          `json:"account" yaml:"account"`
+	Result  *puzzles.Result `json:"result,omitempty" yaml:"result,omitempty"`
+	Known   Answers         `json:"known" yaml:"known"`
+	Verdict [2]Verdict      `json:"verdict" yaml:"verdict"`
+	Error   string          `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func (r MatrixRow) view() matrixRowView {
+	v1, v2 := r.Verdicts()
+
+	v := matrixRowView{
+		Account: r.Account,
+		Result:  nil,
+		Known:   r.Known,
+		Verdict: [2]Verdict{v1, v2},
+		Error:   "",
+	}
+
+	if r.Err != nil {
+		v.Error = r.Err.Error()
+	} else {
+		v.Result = &r.Result
+	}
+
+	return v
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r MatrixRow) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.view())
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (r MatrixRow) MarshalYAML() (any, error) {
+	return r.view(), nil
+}
+
+var matrixHeader = []string{"account", "part1", "part2", "verdict1", "verdict2", "error"}
+
+func matrixRecord(r MatrixRow) []string {
+	v1, v2 := r.Verdicts()
+
+	rec := []string{r.Account, r.Result.Part1, r.Result.Part2, v1.String(), v2.String(), ""}
+
+	if r.Err != nil {
+		rec[5] = r.Err.Error()
+	}
+
+	return rec
+}
+
+// WriteMatrix writes matrix rows in passed format. Table format renders matrix as RenderMatrix does.
+func WriteMatrix(w io.Writer, f Format, rows []MatrixRow) error {
+	if f == FormatTable {
+		return RenderMatrix(w, rows)
+	}
+
+	return writeFormatted(w, f, rows, matrixHeader, matrixRecord)
+}
+
+func writeFormatted[T any](w io.Writer, f Format, rows []T, header []string, record func(T) []string) error {
+	switch f {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		if rows == nil {
+			rows = []T{}
+		}
+
+		if err := enc.Encode(rows); err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+
+		if err := enc.Encode(rows); err != nil {
+			return fmt.Errorf("encode yaml: %w", err)
+		}
+
+		if err := enc.Close(); err != nil {
+			return fmt.Errorf("close yaml encoder: %w", err)
+		}
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+
+		if err := cw.Write(header); err != nil {
+			return fmt.Errorf("write csv: %w", err)
+		}
+
+		for _, r := range rows {
+			if err := cw.Write(record(r)); err != nil {
+				return fmt.Errorf("write csv: %w", err)
+			}
+		}
+
+		cw.Flush()
+
+		if err := cw.Error(); err != nil {
+			return fmt.Errorf("flush csv: %w", err)
+		}
+	case FormatMarkdown:
+		lines := make([]string, 0, len(rows)+2)
+
+		lines = append(lines, markdownRow(header), markdownRow(slices.Repeat([]string{"---"}, len(header))))
+
+		for _, r := range rows {
+			lines = append(lines, markdownRow(record(r)))
+		}
+
+		if _, err := fmt.Fprintln(w, strings.Join(lines, "\n")); err != nil {
+			return fmt.Errorf("write markdown: %w", err)
+		}
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownFormat, f)
+	}
+
+	return nil
+}
+
+func markdownRow(cells []string) string {
+	escaped := make([]string, 0, len(cells))
+
+	for _, c := range cells {
+		escaped = append(escaped, strings.NewReplacer("|", `\|`, "\n", " ").Replace(c))
+	}
+
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
@@ -0,0 +1,154 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+)
+
+func TestParseFormat(t *testing.T) {
+	got, err := ParseFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, FormatTable, got)
+
+	for _, name := range Formats() {
+		got, err = ParseFormat(name)
+		require.NoError(t, err)
+		assert.Equal(t, Format(name), got)
+	}
+
+	_, err = ParseFormat("xml")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestWriteResults(t *testing.T) {
+	results := []BatchResult{
+		{
+			Year: "2021",
+			Day:  "5",
+			Result: puzzles.Result{
+				Year:  "2021",
+				Name:  "5",
+				Part1: "5306",
+				Part2: "17787",
+			},
+			Elapsed: 1500 * time.Microsecond,
+			Err:     nil,
+		},
+		{
+			Year:    "2021",
+			Day:     "6",
+			Result:  puzzles.Result{},
+			Elapsed: 0,
+			Err:     errors.New("input | not found"),
+		},
+	}
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{
+			format: FormatJSON,
+			want: `[
+  {
+    "year": "2021",
+    "day": "5",
+    "result": {
+      "year": "2021",
+      "day": "5",
+      "part1": "5306",
+      "part2": "17787"
+    },
+    "elapsed_ns": 1500000
+  },
+  {
+    "year": "2021",
+    "day": "6",
+    "error": "input | not found"
+  }
+]
+`,
+		},
+		{
+			format: FormatYAML,
+			want: `- year: "2021"
+  day: "5"
+  result:
+    year: "2021"
+    day: "5"
+    part1: "5306"
+    part2: "17787"
+  elapsed_ns: 1500000
+- year: "2021"
+  day: "6"
+  error: input | not found
+`,
+		},
+		{
+			format: FormatCSV,
+			want: `year,day,part1,part2,elapsed_ns,bench_n,bench_ns_per_op,bench_bytes_per_op,bench_allocs_per_op,error
+2021,5,5306,17787,1500000,,,,,
+2021,6,,,,,,,,input | not found
+`,
+		},
+		{
+			format: FormatMarkdown,
+			want: `| year | day | part1 | part2 | elapsed_ns | bench_n | bench_ns_per_op | bench_bytes_per_op | bench_allocs_per_op | error |
+| --- | --- | --- | --- | --- | --- | --- | --- | --- | --- |
+| 2021 | 5 | 5306 | 17787 | 1500000 |  |  |  |  |  |
+| 2021 | 6 |  |  |  |  |  |  |  | input \| not found |
+`,
+		},
+		{
+			format: FormatTable,
+			want: `ok    2021/5          1.5ms  part1: 5306  part2: 17787
+FAIL  2021/6             0s  input | not found
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+
+			require.NoError(t, WriteResults(&buf, tt.format, results))
+
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWriteMatrix(t *testing.T) {
+	rows := []MatrixRow{
+		{
+			Account: "alice",
+			Result:  puzzles.Result{Year: "2021", Name: "5", Part1: "1", Part2: "2"},
+			Known:   Answers{Part1: "1", Part2: "3"},
+			Err:     nil,
+		},
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteMatrix(&buf, FormatJSON, rows))
+
+	assert.JSONEq(t, `[{
+		"account": "alice",
+		"result": {"year": "2021", "day": "5", "part1": "1", "part2": "2"},
+		"known": {"1": "1", "2": "3"},
+		"verdict": ["correct", "wrong"]
+	}]`, buf.String())
+
+	buf.Reset()
+
+	require.NoError(t, WriteMatrix(&buf, FormatCSV, rows))
+
+	assert.Equal(t, "account,part1,part2,verdict1,verdict2,error\nalice,1,2,correct,wrong,\n", buf.String())
+}
@@ -0,0 +1,232 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+// answersFile is a name of known answers file in inputs dir: <dir>/<year>/day/<day>/answers.json.
+const answersFile = "answers.json"
+
+// Account is a source of puzzle input: either session or local inputs dir.
+type Account struct {
+	Name string
+	// Session to fetch input with.
+	Session string
+	// Dir with inputs in <dir>/<year>/day/<day>/input layout. Takes precedence over Session.
+	Dir string
+}
+
+// Answers holds answers for both puzzle parts.
+type Answers struct {
+	Part1 string `json:"1" yaml:"1"`
+	Part2 string `json:"2" yaml:"2"`
+}
+
+// KnownAnswers holds known correct answers by account name and puzzle "year/day".
+type KnownAnswers map[string]map[string]Answers
+
+// LoadKnownAnswers reads known answers from JSON file:
+//
+//	{"alice": {"2021/5": {"1": "5306", "2": "17787"}}}
+func LoadKnownAnswers(fpath string) (KnownAnswers, error) {
+	content, err := os.ReadFile(filepath.Clean(fpath))
+	if err != nil {
+		return nil, fmt.Errorf("read known answers: %w", err)
+	}
+
+	var known KnownAnswers
+
+	if err = json.Unmarshal(content, &known); err != nil {
+		return nil, fmt.Errorf("decode known answers: %w", err)
+	}
+
+	return known, nil
+}
+
+// Verdict of the answer compared with known one.
+type Verdict int
+
+const (
+	// VerdictUnknown means there is no known answer.
+	VerdictUnknown Verdict = iota
+	// VerdictCorrect means answer matches known one.
+	VerdictCorrect
+	// VerdictWrong means answer differs from known one.
+	VerdictWrong
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// String returns name of verdict.
+func (v Verdict) String() string {
+	switch v {
+	case VerdictCorrect:
+		return "correct"
+	case VerdictWrong:
+		return "wrong"
+	default:
+		return "unknown"
+	}
+}
+
+// MatrixRow is a result of running solver on input of one account.
+type MatrixRow struct {
+	Account string
+	Result  puzzles.Result
+	Known   Answers
+	Err     error
+}
+
+// Verdicts compares answers with known ones.
+func (r MatrixRow) Verdicts() (Verdict, Verdict) {
+	return verdict(r.Result.Part1, r.Known.Part1), verdict(r.Result.Part2, r.Known.Part2)
+}
+
+// OK reports whether solver succeeded and none of the answers contradicts known ones.
+func (r MatrixRow) OK() bool {
+	v1, v2 := r.Verdicts()
+
+	return r.Err == nil && v1 != VerdictWrong && v2 != VerdictWrong
+}
+
+func verdict(got, known string) Verdict {
+	switch {
+	case known == "":
+		return VerdictUnknown
+	case got == known:
+		return VerdictCorrect
+	default:
+		return VerdictWrong
+	}
+}
+
+// RunMatrix runs puzzle solving for passed year/day date on inputs of every account.
+// Failures are reported per account in MatrixRow.Err.
+func RunMatrix(ctx context.Context, year, day string, accounts []Account, known KnownAnswers) ([]MatrixRow, error) {
+	remote, err := NewFetcher(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := openVault()
+	if err != nil {
+		return nil, err
+	}
+
+	d := input.Date{
+		Year: year,
+		Day:  day,
+	}
+
+	rows := make([]MatrixRow, 0, len(accounts))
+
+	for _, acc := range accounts {
+		cli := remote
+		if acc.Dir != "" {
+			cli = input.NewDirFetcher(acc.Dir, v)
+		}
+
+		row := MatrixRow{
+			Account: acc.Name,
+			Result:  puzzles.Result{},
+			Known:   knownAnswers(known, acc, d),
+			Err:     nil,
+		}
+
+		row.Result, row.Err = run(ContextWithSession(ctx, acc.Session), cli, year, day)
+		if errors.Is(row.Err, input.ErrUnauthorized) {
+			row.Err = ErrUnauthorized
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// knownAnswers returns known answers of account, falling back to answers.json next to the input in account dir.
+func knownAnswers(known KnownAnswers, acc Account, d input.Date) Answers {
+	if a, ok := known[acc.Name][d.String()]; ok {
+		return a
+	}
+
+	return AnswersFromDir(acc.Dir, d)
+}
+
+// AnswersFromDir returns known answers stored in answers.json next to the input in inputs dir:
+// <dir>/<year>/day/<day>/answers.json. Empty answers are returned when file could not be read.
+func AnswersFromDir(dir string, d input.Date) Answers {
+	if dir == "" {
+		return Answers{}
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, d.Year, "day", d.Day, answersFile))
+	if err != nil {
+		return Answers{}
+	}
+
+	var a Answers
+
+	if err = json.Unmarshal(content, &a); err != nil {
+		return Answers{}
+	}
+
+	return a
+}
+
+// RenderMatrix writes matrix of answers per account. Answers matching known ones are marked with ✓,
+// contradicting ones with ✗ and the known answer.
+func RenderMatrix(w io.Writer, rows []MatrixRow) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if _, err := fmt.Fprintln(tw, "ACCOUNT\tPART 1\tPART 2"); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	for _, r := range rows {
+		var line string
+
+		if r.Err != nil {
+			line = fmt.Sprintf("%s\terror: %v", r.Account, r.Err)
+		} else {
+			v1, v2 := r.Verdicts()
+
+			line = fmt.Sprintf("%s\t%s\t%s", r.Account,
+				mark(r.Result.Part1, r.Known.Part1, v1), mark(r.Result.Part2, r.Known.Part2, v2))
+		}
+
+		if _, err := fmt.Fprintln(tw, line); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+
+	return nil
+}
+
+func mark(answer, known string, v Verdict) string {
+	switch v {
+	case VerdictCorrect:
+		return answer + " ✓"
+	case VerdictWrong:
+		return fmt.Sprintf("%s ✗ (want %s)", answer, known)
+	default:
+		return answer
+	}
+}
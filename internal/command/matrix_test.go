@@ -0,0 +1,125 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/aocfake"
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+func TestRunMatrix(t *testing.T) {
+	srv := aocfake.NewServer(t, "testdata")
+
+	ctx := ContextWithBaseURL(context.Background(), srv.URL)
+
+	year, day := "1992", "31"
+
+	puzzles.Register(mockSolver{
+		year: year,
+		name: day,
+	})
+
+	t.Cleanup(func() {
+		puzzles.UnregisterAllSolvers(t)
+	})
+
+	accounts := []Account{
+		{
+			Name:    "gopher",
+			Session: "gopher-token",
+			Dir:     "",
+		},
+		{
+			Name:    "bob",
+			Session: "",
+			Dir:     filepath.Join("testdata", "accounts", "bob"),
+		},
+		{
+			Name:    "stranger",
+			Session: "invalid",
+			Dir:     "",
+		},
+		{
+			Name:    "nobody",
+			Session: "",
+			Dir:     filepath.Join("testdata", "accounts", "nobody"),
+		},
+	}
+
+	known := KnownAnswers{
+		"gopher": {
+			"1992/31": {
+				Part1: "2",
+				Part2: "",
+			},
+		},
+	}
+
+	rows, err := RunMatrix(ctx, year, day, accounts, known)
+	require.NoError(t, err)
+	require.Len(t, rows, len(accounts))
+
+	gopher, bob, stranger, nobody := rows[0], rows[1], rows[2], rows[3]
+
+	require.NoError(t, gopher.Err)
+	assert.Equal(t, "2", gopher.Result.Part1)
+	assert.True(t, gopher.OK())
+
+	v1, v2 := gopher.Verdicts()
+	assert.Equal(t, VerdictCorrect, v1)
+	assert.Equal(t, VerdictUnknown, v2)
+
+	require.NoError(t, bob.Err)
+	assert.Equal(t, Answers{Part1: "5", Part2: "7"}, bob.Known, "known answers are read from account dir")
+	assert.False(t, bob.OK())
+
+	v1, v2 = bob.Verdicts()
+	assert.Equal(t, VerdictCorrect, v1)
+	assert.Equal(t, VerdictWrong, v2)
+
+	assert.ErrorIs(t, stranger.Err, ErrUnauthorized)
+	assert.ErrorIs(t, nobody.Err, input.ErrNotFound)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, RenderMatrix(&buf, rows))
+
+	want := "ACCOUNT   PART 1  PART 2\n" +
+		"gopher    2 ✓     3\n" +
+		"bob       5 ✓     6 ✗ (want 7)\n" +
+		"stranger  error: unauthorized\n" +
+		"nobody    error: " + nobody.Err.Error() + "\n"
+
+	assert.Equal(t, want, buf.String())
+}
+
+func TestLoadKnownAnswers(t *testing.T) {
+	dir := t.TempDir()
+
+	fpath := filepath.Join(dir, "answers.json")
+
+	require.NoError(t, os.WriteFile(fpath, []byte(`{"alice": {"2021/5": {"1": "5306", "2": "17787"}}}`), 0o600))
+
+	got, err := LoadKnownAnswers(fpath)
+	require.NoError(t, err)
+
+	assert.Equal(t, KnownAnswers{
+		"alice": {
+			"2021/5": {
+				Part1: "5306",
+				Part2: "17787",
+			},
+		},
+	}, got)
+
+	_, err = LoadKnownAnswers(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
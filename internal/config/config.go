@@ -0,0 +1,269 @@
+// Package config manages aoc-cli configuration file holding defaults of command line options,
+// e.g. output format or cache dir, so they are not repeated on each invocation.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/obalunenko/advent-of-code/internal/command"
+)
+
+// Keys of configuration values. Keys match names of flags they set defaults for.
+const (
+	KeyFormat    = "format"
+	KeyMetrics   = "metrics"
+	KeyCacheDir  = "cache-dir"
+	KeyTimeout   = "timeout"
+	KeyYear      = "year"
+	KeyProfile   = "profile"
+	KeyTemplates = "templates"
+)
+
+// Metrics could be enabled by default.
+const (
+	MetricElapsed = "elapsed"
+	MetricBench   = "bench"
+)
+
+const (
+	dirPerms  fs.FileMode = 0o700
+	filePerms fs.FileMode = 0o600
+
+	firstYear = 2015
+)
+
+var (
+	// ErrUnknownKey returns when there is no configuration value with passed key.
+	ErrUnknownKey = errors.New("unknown config key")
+	// ErrInvalidValue returns when configuration value could not be parsed.
+	ErrInvalidValue = errors.New("invalid config value")
+)
+
+// Config holds defaults of command line options. Empty values are not set.
+type Config struct {
+	path string
+
+	// Format is an output format of results, see command.Formats.
+	Format string `yaml:"format,omitempty"`
+	// Metrics are metrics enabled by default: elapsed, bench.
+	Metrics []string `yaml:"metrics,omitempty"`
+	// CacheDir is a directory of inputs cache.
+	CacheDir string `yaml:"cache-dir,omitempty"`
+	// Timeout is a timeout of each puzzle solved in batch.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// Year is a default event year.
+	Year string `yaml:"year,omitempty"`
+	// Profile is a name of stored session profile to use instead of the default one.
+	Profile string `yaml:"profile,omitempty"`
+	// Templates is a directory of user templates of new puzzle solution.
+	Templates string `yaml:"templates,omitempty"`
+}
+
+// DefaultPath returns default path to config file: $XDG_CONFIG_HOME/aoc-cli/config.yaml.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("get user config dir: %w", err)
+	}
+
+	return filepath.Join(dir, "aoc-cli", "config.yaml"), nil
+}
+
+// Load reads config from file. Empty config is returned when file not exist.
+// Values are not validated, so invalid one could be fixed by Set, see Validate.
+func Load(path string) (*Config, error) {
+	cfg := Config{
+		path:      path,
+		Format:    "",
+		Metrics:   nil,
+		CacheDir:  "",
+		Timeout:   0,
+		Year:      "",
+		Profile:   "",
+		Templates: "",
+	}
+
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &cfg, nil
+		}
+
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	if err = yaml.Unmarshal(content, &cfg); err != nil {
+		return nil, fmt.Errorf("decode config file: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks values edited by hand in the same way as Set does.
+func (c *Config) Validate() error {
+	for _, key := range Keys() {
+		value, err := c.Get(key)
+		if err != nil {
+			return err
+		}
+
+		if err = validate(key, value); err != nil {
+			return fmt.Errorf("config file %s: %w", c.path, err)
+		}
+	}
+
+	return nil
+}
+
+// Save writes config to file.
+func (c *Config) Save() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), dirPerms); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+
+	if err = os.WriteFile(c.path, content, filePerms); err != nil {
+		return fmt.Errorf("write config file: %w", err)
+	}
+
+	return nil
+}
+
+// Path returns path to the config file.
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Keys returns keys of configuration values.
+func Keys() []string {
+	return []string{KeyFormat, KeyMetrics, KeyCacheDir, KeyTimeout, KeyYear, KeyProfile, KeyTemplates}
+}
+
+// Get returns value by key as it is passed to Set, empty when value is not set.
+func (c *Config) Get(key string) (string, error) {
+	switch key {
+	case KeyFormat:
+		return c.Format, nil
+	case KeyMetrics:
+		return strings.Join(c.Metrics, ","), nil
+	case KeyCacheDir:
+		return c.CacheDir, nil
+	case KeyTimeout:
+		if c.Timeout == 0 {
+			return "", nil
+		}
+
+		return c.Timeout.String(), nil
+	case KeyYear:
+		return c.Year, nil
+	case KeyProfile:
+		return c.Profile, nil
+	case KeyTemplates:
+		return c.Templates, nil
+	default:
+		return "", fmt.Errorf("%w: %q, expected one of %s", ErrUnknownKey, key, strings.Join(Keys(), ", "))
+	}
+}
+
+// Set sets value by key. Empty value unsets it. Metrics are passed comma separated, e.g. elapsed,bench.
+func (c *Config) Set(key, value string) error {
+	if _, err := c.Get(key); err != nil {
+		return err
+	}
+
+	if err := validate(key, value); err != nil {
+		return err
+	}
+
+	switch key {
+	case KeyFormat:
+		c.Format = value
+	case KeyMetrics:
+		c.Metrics = splitList(value)
+	case KeyCacheDir:
+		c.CacheDir = value
+	case KeyTimeout:
+		// Validated above.
+		c.Timeout, _ = parseDuration(value)
+	case KeyYear:
+		c.Year = value
+	case KeyProfile:
+		c.Profile = value
+	case KeyTemplates:
+		c.Templates = value
+	}
+
+	return nil
+}
+
+func validate(key, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	var err error
+
+	switch key {
+	case KeyFormat:
+		_, err = command.ParseFormat(value)
+	case KeyMetrics:
+		for _, m := range splitList(value) {
+			if m != MetricElapsed && m != MetricBench {
+				err = fmt.Errorf("unknown metric %q, expected %s or %s", m, MetricElapsed, MetricBench)
+
+				break
+			}
+		}
+	case KeyTimeout:
+		_, err = parseDuration(value)
+	case KeyYear:
+		if y, aerr := strconv.Atoi(value); aerr != nil || y < firstYear {
+			err = fmt.Errorf("expected year since %d, got %q", firstYear, value)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidValue, key, err)
+	}
+
+	return nil
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("expected positive duration, got %s", s)
+	}
+
+	return d, nil
+}
+
+func splitList(s string) []string {
+	var res []string
+
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" && !slices.Contains(res, v) {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
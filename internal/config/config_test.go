@@ -0,0 +1,129 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/config"
+)
+
+func TestConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aoc-cli", "config.yaml")
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	for _, key := range config.Keys() {
+		value, err := cfg.Get(key)
+		require.NoError(t, err)
+		assert.Empty(t, value, key)
+	}
+
+	require.NoError(t, cfg.Set(config.KeyFormat, "json"))
+	require.NoError(t, cfg.Set(config.KeyMetrics, "elapsed, bench,elapsed"))
+	require.NoError(t, cfg.Set(config.KeyTimeout, "1m30s"))
+	require.NoError(t, cfg.Set(config.KeyYear, "2021"))
+	require.NoError(t, cfg.Set(config.KeyProfile, "work"))
+	require.NoError(t, cfg.Save())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := config.Load(path)
+	require.NoError(t, err)
+	require.NoError(t, loaded.Validate())
+
+	assert.Equal(t, "json", loaded.Format)
+	assert.Equal(t, []string{config.MetricElapsed, config.MetricBench}, loaded.Metrics)
+	assert.Equal(t, 90*time.Second, loaded.Timeout)
+	assert.Equal(t, "2021", loaded.Year)
+	assert.Equal(t, "work", loaded.Profile)
+
+	value, err := loaded.Get(config.KeyTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, "1m30s", value)
+
+	require.NoError(t, loaded.Set(config.KeyProfile, ""))
+	assert.Empty(t, loaded.Profile, "empty value unsets key")
+}
+
+func TestConfig_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "unknown key",
+			key:     "color",
+			value:   "red",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "unknown format",
+			key:     config.KeyFormat,
+			value:   "xml",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "unknown metric",
+			key:     config.KeyMetrics,
+			value:   "elapsed,memory",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "invalid timeout",
+			key:     config.KeyTimeout,
+			value:   "30",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "negative timeout",
+			key:     config.KeyTimeout,
+			value:   "-1s",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "year before first event",
+			key:     config.KeyYear,
+			value:   "2014",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "cache dir",
+			key:     config.KeyCacheDir,
+			value:   "/tmp/aoc",
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.Load(filepath.Join(t.TempDir(), "config.yaml"))
+			require.NoError(t, err)
+
+			tt.wantErr(t, cfg.Set(tt.key, tt.value))
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte("format: xml\n"), 0o600))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err, "invalid value is loaded to be fixed by Set")
+
+	assert.ErrorIs(t, cfg.Validate(), config.ErrInvalidValue)
+
+	require.NoError(t, cfg.Set(config.KeyFormat, "csv"))
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,262 @@
+// Package download downloads inputs of all registered puzzles, so solutions could be run offline.
+//
+// Inputs are stored as <dir>/<year>/day/<day>/input, the same layout as inputs cache and aocfake fixtures,
+// and listed in manifest.json with their SHA-256 sums. Download could be interrupted and resumed:
+// inputs that are already in dir are skipped and listed in manifest when missing there.
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	log "github.com/obalunenko/logger"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/vault"
+)
+
+const (
+	// DefaultInterval is a minimal interval between requests to adventofcode.com.
+	DefaultInterval = 3 * time.Second
+
+	dirPerms  = 0o700
+	filePerms = 0o600
+)
+
+// Option configures Downloader.
+type Option func(d *Downloader)
+
+// WithInterval sets minimal interval between requests.
+func WithInterval(interval time.Duration) Option {
+	return func(d *Downloader) {
+		d.interval = interval
+	}
+}
+
+// WithVault makes Downloader store inputs encrypted.
+func WithVault(v *vault.Vault) Option {
+	return func(d *Downloader) {
+		d.vault = v
+	}
+}
+
+// Downloader downloads inputs into dir.
+type Downloader struct {
+	f        input.Fetcher
+	dir      string
+	vault    *vault.Vault
+	interval time.Duration
+	now      func() time.Time
+	sleep    func(ctx context.Context, d time.Duration) error
+}
+
+// New creates Downloader storing inputs in dir.
+func New(f input.Fetcher, dir string, opts ...Option) *Downloader {
+	d := Downloader{
+		f:        f,
+		dir:      dir,
+		vault:    nil,
+		interval: DefaultInterval,
+		now:      time.Now,
+		sleep:    sleep,
+	}
+
+	for _, opt := range opts {
+		opt(&d)
+	}
+
+	return &d
+}
+
+// Report summarizes download.
+type Report struct {
+	Downloaded []string
+	Skipped    []string
+	// Failed holds errors by "year/day" of inputs that could not be downloaded, e.g. not yet unlocked.
+	Failed map[string]error
+}
+
+// Dates returns dates of all registered puzzles. When years passed, only puzzles of these years are returned.
+func Dates(years ...string) []input.Date {
+	var dates []input.Date
+
+	for _, year := range puzzles.GetYears() {
+		if len(years) != 0 && !slices.Contains(years, year) {
+			continue
+		}
+
+		for _, day := range puzzles.DaysByYear(year) {
+			dates = append(dates, input.Date{
+				Year: year,
+				Day:  day,
+			})
+		}
+	}
+
+	return dates
+}
+
+// Download downloads inputs for passed dates. Inputs that are already downloaded are skipped.
+// Download stops on invalid session or context cancellation, other failures are collected in the Report.
+func (d *Downloader) Download(ctx context.Context, dates []input.Date, session string) (Report, error) {
+	report := Report{
+		Downloaded: nil,
+		Skipped:    nil,
+		Failed:     make(map[string]error),
+	}
+
+	m, err := LoadManifest(d.dir)
+	if err != nil {
+		return report, err
+	}
+
+	var last time.Time
+
+	for _, date := range dates {
+		key := date.String()
+		rel := filepath.ToSlash(input.InputPath(date))
+
+		if fpath, ok := d.existing(rel); ok {
+			// Inputs stored before manifest was written, e.g. by inputs cache, are listed as well.
+			if _, listed := m.Inputs[key]; !listed {
+				if err = d.list(m, key, rel, fpath); err != nil {
+					log.WithError(ctx, err).WithField("puzzle", key).Warn("Failed to list existing input in manifest")
+				}
+			}
+
+			report.Skipped = append(report.Skipped, key)
+
+			continue
+		}
+
+		if !last.IsZero() {
+			if err = d.sleep(ctx, last.Add(d.interval).Sub(d.now())); err != nil {
+				return report, err
+			}
+		}
+
+		last = d.now()
+
+		content, err := d.f.Fetch(ctx, date, session)
+		if err != nil {
+			if errors.Is(err, input.ErrUnauthorized) || ctx.Err() != nil {
+				return report, fmt.Errorf("download %s: %w", key, err)
+			}
+
+			log.WithError(ctx, err).WithField("puzzle", key).Warn("Failed to download input")
+
+			report.Failed[key] = err
+
+			continue
+		}
+
+		if err = d.store(rel, content); err != nil {
+			return report, fmt.Errorf("store %s: %w", key, err)
+		}
+
+		m.Inputs[key] = newEntry(rel, content, d.now())
+
+		if err = m.Save(d.dir); err != nil {
+			return report, err
+		}
+
+		log.WithField(ctx, "puzzle", key).Info("Downloaded input")
+
+		report.Downloaded = append(report.Downloaded, key)
+	}
+
+	return report, nil
+}
+
+// existing returns path of input stored in dir, plain or encrypted.
+func (d *Downloader) existing(rel string) (string, bool) {
+	fpath := filepath.Join(d.dir, filepath.FromSlash(rel))
+
+	for _, p := range []string{fpath, fpath + vault.Ext} {
+		if _, err := os.Stat(p); err == nil {
+			return p, true
+		}
+	}
+
+	return "", false
+}
+
+// list adds entry of existing input at fpath to the manifest. Input is hashed as it was downloaded, so encrypted
+// input is decrypted first, and its modification time is used as download time.
+func (d *Downloader) list(m Manifest, key, rel, fpath string) error {
+	info, err := os.Stat(fpath)
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+
+	if filepath.Ext(fpath) == vault.Ext {
+		if d.vault == nil {
+			return fmt.Errorf("%s is encrypted: %w", fpath, vault.ErrNoKey)
+		}
+
+		content, err = d.vault.ReadFile(fpath)
+	} else {
+		content, err = os.ReadFile(filepath.Clean(fpath))
+	}
+
+	if err != nil {
+		return fmt.Errorf("read input: %w", err)
+	}
+
+	m.Inputs[key] = newEntry(rel, content, info.ModTime())
+
+	return m.Save(d.dir)
+}
+
+func newEntry(rel string, content []byte, at time.Time) Entry {
+	sum := sha256.Sum256(content)
+
+	return Entry{
+		Path:         rel,
+		SHA256:       hex.EncodeToString(sum[:]),
+		Size:         len(content),
+		DownloadedAt: at.UTC(),
+	}
+}
+
+func (d *Downloader) store(rel string, content []byte) error {
+	fpath := filepath.Join(d.dir, filepath.FromSlash(rel))
+
+	if d.vault != nil {
+		sealed, err := d.vault.Seal(content)
+		if err != nil {
+			return err
+		}
+
+		fpath += vault.Ext
+		content = sealed
+	}
+
+	return writeFileAtomic(fpath, content)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
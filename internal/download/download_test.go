@@ -0,0 +1,192 @@
+package download
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/aocfake"
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/vault"
+)
+
+func writeFile(tb testing.TB, fpath, content string) {
+	tb.Helper()
+
+	require.NoError(tb, os.MkdirAll(filepath.Dir(fpath), 0o700))
+	require.NoError(tb, os.WriteFile(fpath, []byte(content), 0o600))
+}
+
+func newFakeFetcher(tb testing.TB) input.Fetcher {
+	tb.Helper()
+
+	fixtures := tb.TempDir()
+
+	writeFile(tb, filepath.Join(fixtures, "2021", "day", "1", "input"), "1,2,3")
+	writeFile(tb, filepath.Join(fixtures, "2021", "day", "2", "input"), "4,5,6")
+	writeFile(tb, filepath.Join(fixtures, "2021", "day", "4", "input"), "7,8,9")
+
+	srv := aocfake.NewServer(tb, fixtures)
+
+	return input.NewFetcher(http.DefaultClient, 5*time.Second, input.WithBaseURL(srv.URL))
+}
+
+func dates(days ...string) []input.Date {
+	res := make([]input.Date, 0, len(days))
+
+	for _, day := range days {
+		res = append(res, input.Date{Year: "2021", Day: day})
+	}
+
+	return res
+}
+
+func TestDownloader_Download(t *testing.T) {
+	dir := t.TempDir()
+
+	// Already downloaded before interruption.
+	writeFile(t, filepath.Join(dir, "2021", "day", "4", "input"), "7,8,9")
+
+	var sleeps []time.Duration
+
+	d := New(newFakeFetcher(t), dir, WithInterval(time.Second))
+	d.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+
+		return nil
+	}
+
+	report, err := d.Download(context.Background(), dates("1", "2", "3", "4"), "token")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"2021/1", "2021/2"}, report.Downloaded)
+	assert.Equal(t, []string{"2021/4"}, report.Skipped)
+	assert.ErrorIs(t, report.Failed["2021/3"], input.ErrNotFound)
+
+	assert.Len(t, sleeps, 2, "rate limit is applied between requests")
+
+	for _, s := range sleeps {
+		assert.LessOrEqual(t, s, time.Second)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "2021", "day", "2", "input"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "4,5,6", string(got))
+
+	m, err := LoadManifest(dir)
+	require.NoError(t, err)
+
+	require.Len(t, m.Inputs, 3)
+
+	entry := m.Inputs["2021/1"]
+	assert.Equal(t, "2021/day/1/input", entry.Path)
+	// sha256 of "1,2,3".
+	assert.Equal(t, "8a6ae15122001229edb8866f56e342af12ae8187203c3e3b33931743e7c0c48d", entry.SHA256)
+	assert.Equal(t, 5, entry.Size)
+
+	// Skipped input is listed as well.
+	entry = m.Inputs["2021/4"]
+	assert.Equal(t, "2021/day/4/input", entry.Path)
+	// sha256 of "7,8,9".
+	assert.Equal(t, "57a770bf9f606fd425994d412b11428dd9ce3306e14491df578d1d5298762246", entry.SHA256)
+	assert.Equal(t, 5, entry.Size)
+
+	// Resume: downloaded inputs are skipped.
+	report, err = d.Download(context.Background(), dates("1", "2"), "token")
+	require.NoError(t, err)
+
+	assert.Empty(t, report.Downloaded)
+	assert.Equal(t, []string{"2021/1", "2021/2"}, report.Skipped)
+}
+
+func TestDownloader_Download_unauthorized(t *testing.T) {
+	dir := t.TempDir()
+
+	d := New(newFakeFetcher(t), dir, WithInterval(0))
+
+	report, err := d.Download(context.Background(), dates("1", "2"), "")
+	require.ErrorIs(t, err, input.ErrUnauthorized)
+
+	assert.Empty(t, report.Downloaded)
+}
+
+func TestDownloader_Download_encrypted(t *testing.T) {
+	const key = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+	v, err := vault.Parse(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+
+	d := New(newFakeFetcher(t), dir, WithInterval(0), WithVault(v))
+
+	_, err = d.Download(context.Background(), dates("1"), "token")
+	require.NoError(t, err)
+
+	got, err := v.ReadFile(filepath.Join(dir, "2021", "day", "1", "input"+vault.Ext))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1,2,3", string(got))
+
+	// Encrypted input stored without manifest, e.g. by inputs cache.
+	require.NoError(t, os.Remove(filepath.Join(dir, ManifestFile)))
+
+	report, err := d.Download(context.Background(), dates("1"), "token")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"2021/1"}, report.Skipped)
+
+	m, err := LoadManifest(dir)
+	require.NoError(t, err)
+
+	// sha256 of "1,2,3", the input is hashed decrypted.
+	assert.Equal(t, "8a6ae15122001229edb8866f56e342af12ae8187203c3e3b33931743e7c0c48d", m.Inputs["2021/1"].SHA256)
+}
+
+type mockSolver struct {
+	year string
+	day  string
+}
+
+func (m mockSolver) Year() string {
+	return m.year
+}
+
+func (m mockSolver) Day() string {
+	return m.day
+}
+
+func (m mockSolver) Part1(io.Reader) (string, error) {
+	return "", nil
+}
+
+func (m mockSolver) Part2(io.Reader) (string, error) {
+	return "", nil
+}
+
+func TestDates(t *testing.T) {
+	puzzles.Register(mockSolver{year: "2020", day: "1"})
+	puzzles.Register(mockSolver{year: "2021", day: "1"})
+	puzzles.Register(mockSolver{year: "2021", day: "2"})
+
+	t.Cleanup(func() {
+		puzzles.UnregisterAllSolvers(t)
+	})
+
+	assert.Equal(t, []input.Date{
+		{Year: "2020", Day: "1"},
+		{Year: "2021", Day: "1"},
+		{Year: "2021", Day: "2"},
+	}, Dates())
+
+	assert.Equal(t, dates("1", "2"), Dates("2021"))
+}
@@ -0,0 +1,104 @@
+package download
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ManifestFile is a name of manifest file in the download dir.
+const ManifestFile = "manifest.json"
+
+// Manifest lists downloaded inputs.
+type Manifest struct {
+	// Inputs by "year/day".
+	Inputs map[string]Entry `json:"inputs"`
+}
+
+// Entry describes downloaded input.
+type Entry struct {
+	// Path relative to the download dir.
+	Path         string    `json:"path"`
+	SHA256       string    `json:"sha256"`
+	Size         int       `json:"size"`
+	DownloadedAt time.Time `json:"downloaded_at"`
+}
+
+// LoadManifest reads manifest from dir. Missing manifest is returned empty.
+func LoadManifest(dir string) (Manifest, error) {
+	m := Manifest{
+		Inputs: make(map[string]Entry),
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return m, nil
+		}
+
+		return Manifest{}, fmt.Errorf("read manifest: %w", err)
+	}
+
+	if err = json.Unmarshal(content, &m); err != nil {
+		return Manifest{}, fmt.Errorf("decode manifest: %w", err)
+	}
+
+	if m.Inputs == nil {
+		m.Inputs = make(map[string]Entry)
+	}
+
+	return m, nil
+}
+
+// Save writes manifest to dir. Manifest is replaced atomically, so it is never left partially written.
+func (m Manifest) Save(dir string) error {
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+
+	if err = writeFileAtomic(filepath.Join(dir, ManifestFile), append(content, '\n')); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+
+	return nil
+}
+
+func writeFileAtomic(fpath string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(fpath), dirPerms); err != nil {
+		return fmt.Errorf("create dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(fpath), "."+filepath.Base(fpath)+".*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err = tmp.Write(content); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("write temp file: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+
+	if err = os.Chmod(tmp.Name(), filePerms); err != nil {
+		return fmt.Errorf("chmod: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), fpath); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	return nil
+}
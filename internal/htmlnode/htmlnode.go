@@ -0,0 +1,83 @@
+// Package htmlnode provides helpers to search and read nodes of parsed adventofcode.com pages.
+package htmlnode
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Element returns matcher of element nodes with tag a.
+func Element(a atom.Atom) func(n *html.Node) bool {
+	return func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.DataAtom == a
+	}
+}
+
+// Find returns the first node matched in depth-first order, nil when there is no such node.
+func Find(n *html.Node, match func(n *html.Node) bool) *html.Node {
+	if match(n) {
+		return n
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := Find(c, match); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// FindAll returns all matched nodes in depth-first order. Children of matched node are not searched.
+func FindAll(n *html.Node, match func(n *html.Node) bool) []*html.Node {
+	var res []*html.Node
+
+	if match(n) {
+		return append(res, n)
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		res = append(res, FindAll(c, match)...)
+	}
+
+	return res
+}
+
+// HasClass reports whether node has class in its class attribute.
+func HasClass(n *html.Node, class string) bool {
+	for _, cls := range strings.Fields(Attr(n, "class")) {
+		if cls == class {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Attr returns value of node attribute with key, empty when node has no such attribute.
+func Attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+// Text returns text content of node and all its descendants.
+func Text(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+
+	var sb strings.Builder
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(Text(c))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,35 @@
+package htmlnode_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/obalunenko/advent-of-code/internal/htmlnode"
+)
+
+func TestHelpers(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(
+		`<div class="user x">alice <span class="star-count">42*</span></div>` +
+			`<p><a href="/2021">one</a> <a href="/2022">two <em>2</em></a></p>`))
+	require.NoError(t, err)
+
+	user := htmlnode.Find(doc, func(n *html.Node) bool {
+		return htmlnode.Element(atom.Div)(n) && htmlnode.HasClass(n, "user")
+	})
+	require.NotNil(t, user)
+	assert.Equal(t, "alice 42*", htmlnode.Text(user))
+	assert.False(t, htmlnode.HasClass(user, "us"))
+
+	assert.Nil(t, htmlnode.Find(doc, htmlnode.Element(atom.Pre)))
+
+	links := htmlnode.FindAll(doc, htmlnode.Element(atom.A))
+	require.Len(t, links, 2)
+	assert.Equal(t, "/2022", htmlnode.Attr(links[1], "href"))
+	assert.Equal(t, "two 2", htmlnode.Text(links[1]))
+	assert.Empty(t, htmlnode.Attr(links[1], "class"))
+}
@@ -0,0 +1,195 @@
+package leaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/obalunenko/logger"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+// RefreshInterval is a minimal interval between leaderboard requests documented by adventofcode.com.
+const RefreshInterval = 15 * time.Minute
+
+var (
+	// ErrInvalidResponse returns when leaderboard response could not be decoded, usually because of invalid session.
+	ErrInvalidResponse = errors.New("invalid leaderboard response")
+	// ErrRefreshLimited returns when leaderboard is not cached and refresh failed less than RefreshInterval ago.
+	ErrRefreshLimited = errors.New("leaderboard refresh is limited")
+)
+
+// Client gets private leaderboards and caches them on disk to respect refresh interval.
+type Client struct {
+	f   input.LeaderboardFetcher
+	dir string
+	now func() time.Time
+}
+
+// NewClient constructor for Client. Leaderboards are cached in passed dir.
+func NewClient(f input.LeaderboardFetcher, dir string) *Client {
+	return &Client{
+		f:   f,
+		dir: dir,
+		now: time.Now,
+	}
+}
+
+// DefaultCacheDir returns default directory for cached leaderboards.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("get user cache dir: %w", err)
+	}
+
+	return filepath.Join(dir, "aoc-cli", "leaderboard"), nil
+}
+
+// Get returns leaderboard from cache when it is fresher than RefreshInterval, otherwise fetches it.
+// Failed fetch is not retried until RefreshInterval passes since it: stale cached leaderboard is returned
+// if any, ErrRefreshLimited with time to retry after otherwise.
+func (c *Client) Get(ctx context.Context, year, id, session string) (Leaderboard, time.Time, error) {
+	fpath := filepath.Join(c.dir, fmt.Sprintf("%s_%s.json", year, id))
+
+	cached, updated, cerr := c.readCache(fpath)
+
+	if last := c.lastAttempt(fpath, updated); c.now().Sub(last) < RefreshInterval {
+		if cerr != nil {
+			return Leaderboard{}, time.Time{}, fmt.Errorf("%w: retry after %s",
+				ErrRefreshLimited, last.Add(RefreshInterval).Format(time.TimeOnly))
+		}
+
+		return cached, updated, nil
+	}
+
+	lb, err := c.fetch(ctx, year, id, session)
+	if err != nil {
+		if aerr := c.writeAttempt(fpath); aerr != nil {
+			log.WithError(ctx, aerr).Warn("Failed to record leaderboard refresh attempt")
+		}
+
+		if cerr != nil {
+			return Leaderboard{}, time.Time{}, err
+		}
+
+		log.WithError(ctx, err).WithField("updated", updated).Warn("Failed to refresh leaderboard, cached one is used")
+
+		return cached, updated, nil
+	}
+
+	if err = c.writeCache(fpath, lb); err != nil {
+		log.WithError(ctx, err).Warn("Failed to cache leaderboard")
+	}
+
+	return lb, c.now(), nil
+}
+
+func (c *Client) fetch(ctx context.Context, year, id, session string) (Leaderboard, error) {
+	body, err := c.f.FetchLeaderboard(ctx, year, id, session)
+	if err != nil {
+		return Leaderboard{}, fmt.Errorf("fetch leaderboard: %w", err)
+	}
+
+	var lb Leaderboard
+
+	if err = json.Unmarshal(body, &lb); err != nil {
+		return Leaderboard{}, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+
+	return lb, nil
+}
+
+func (c *Client) readCache(fpath string) (Leaderboard, time.Time, error) {
+	stat, err := os.Stat(fpath)
+	if err != nil {
+		return Leaderboard{}, time.Time{}, fmt.Errorf("stat: %w", err)
+	}
+
+	content, err := os.ReadFile(filepath.Clean(fpath))
+	if err != nil {
+		return Leaderboard{}, time.Time{}, fmt.Errorf("read: %w", err)
+	}
+
+	var lb Leaderboard
+
+	if err = json.Unmarshal(content, &lb); err != nil {
+		return Leaderboard{}, time.Time{}, fmt.Errorf("decode: %w", err)
+	}
+
+	return lb, stat.ModTime(), nil
+}
+
+// attemptPath returns path of file modification time of which is the last failed refresh of cache at fpath.
+// Attempt is recorded when there is no cache yet as well.
+func attemptPath(fpath string) string {
+	return fpath + ".attempt"
+}
+
+// lastAttempt returns time of the last refresh of cache at fpath updated at passed time, failed or not.
+// Zero time is returned when there was no refresh, updated is zero for missing cache.
+func (c *Client) lastAttempt(fpath string, updated time.Time) time.Time {
+	stat, err := os.Stat(attemptPath(fpath))
+	if err != nil || stat.ModTime().Before(updated) {
+		return updated
+	}
+
+	return stat.ModTime()
+}
+
+func (c *Client) writeAttempt(fpath string) error {
+	const (
+		dirPerms  = 0o700
+		filePerms = 0o600
+	)
+
+	if err := os.MkdirAll(filepath.Dir(fpath), dirPerms); err != nil {
+		return fmt.Errorf("create dir: %w", err)
+	}
+
+	apath := attemptPath(fpath)
+
+	if err := os.WriteFile(apath, nil, filePerms); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	now := c.now()
+
+	if err := os.Chtimes(apath, now, now); err != nil {
+		return fmt.Errorf("set modification time: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) writeCache(fpath string, lb Leaderboard) error {
+	const (
+		dirPerms  = 0o700
+		filePerms = 0o600
+	)
+
+	if err := os.MkdirAll(filepath.Dir(fpath), dirPerms); err != nil {
+		return fmt.Errorf("create dir: %w", err)
+	}
+
+	content, err := json.Marshal(lb)
+	if err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+
+	if err = os.WriteFile(fpath, content, filePerms); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	now := c.now()
+
+	if err = os.Chtimes(fpath, now, now); err != nil {
+		return fmt.Errorf("set modification time: %w", err)
+	}
+
+	return nil
+}
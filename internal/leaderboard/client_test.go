@@ -0,0 +1,98 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fetcherMock struct {
+	calls int
+	body  []byte
+	err   error
+}
+
+func (f *fetcherMock) FetchLeaderboard(_ context.Context, _, _, _ string) ([]byte, error) {
+	f.calls++
+
+	return f.body, f.err
+}
+
+func TestClient_Get(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "leaderboard.json"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Date(2021, time.December, 3, 0, 0, 0, 0, time.UTC)
+
+	f := &fetcherMock{body: body}
+
+	c := NewClient(f, t.TempDir())
+	c.now = func() time.Time { return now }
+
+	lb, updated, err := c.Get(ctx, "2021", "1", "session")
+	require.NoError(t, err)
+	assert.Len(t, lb.Members, 3)
+	assert.Equal(t, now, updated)
+	assert.Equal(t, 1, f.calls)
+
+	now = now.Add(RefreshInterval / 2)
+
+	cached, _, err := c.Get(ctx, "2021", "1", "session")
+	require.NoError(t, err)
+	assert.Equal(t, lb, cached)
+	assert.Equal(t, 1, f.calls, "cached leaderboard should be used")
+
+	now = now.Add(RefreshInterval)
+	f.err = errors.New("network error")
+
+	stale, _, err := c.Get(ctx, "2021", "1", "session")
+	require.NoError(t, err)
+	assert.Equal(t, lb, stale, "stale leaderboard should be used on error")
+	assert.Equal(t, 2, f.calls)
+
+	now = now.Add(RefreshInterval / 2)
+
+	_, _, err = c.Get(ctx, "2021", "1", "session")
+	require.NoError(t, err)
+	assert.Equal(t, 2, f.calls, "refresh should not be retried before interval passes since failed attempt")
+
+	now = now.Add(RefreshInterval)
+	f.err = nil
+
+	_, updated, err = c.Get(ctx, "2021", "1", "session")
+	require.NoError(t, err)
+	assert.Equal(t, 3, f.calls)
+	assert.Equal(t, now, updated)
+
+	f.err = errors.New("network error")
+
+	_, _, err = c.Get(ctx, "2021", "2", "session")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrRefreshLimited)
+	assert.Equal(t, 4, f.calls)
+
+	now = now.Add(RefreshInterval / 2)
+	f.err = nil
+
+	_, _, err = c.Get(ctx, "2021", "2", "session")
+	require.ErrorIs(t, err, ErrRefreshLimited, "failed attempt is recorded without cached leaderboard")
+	assert.ErrorContains(t, err, "retry after "+now.Add(RefreshInterval/2).Format(time.TimeOnly))
+	assert.Equal(t, 4, f.calls)
+
+	now = now.Add(RefreshInterval / 2)
+
+	_, _, err = c.Get(ctx, "2021", "2", "session")
+	require.NoError(t, err)
+	assert.Equal(t, 5, f.calls)
+	f.body = []byte("<html>login</html>")
+
+	_, _, err = c.Get(ctx, "2021", "3", "session")
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+}
@@ -0,0 +1,106 @@
+// Package leaderboard provides access to private leaderboards of adventofcode.com and renders them in terminal.
+package leaderboard
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Days is a number of puzzles in event.
+const Days = 25
+
+// Leaderboard is a private leaderboard as returned by adventofcode.com JSON API.
+type Leaderboard struct {
+	OwnerID int               `json:"owner_id"`
+	Event   string            `json:"event"`
+	Members map[string]Member `json:"members"`
+}
+
+// Member is a leaderboard member.
+type Member struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Stars       int    `json:"stars"`
+	LocalScore  int    `json:"local_score"`
+	GlobalScore int    `json:"global_score"`
+	LastStarTs  int64  `json:"last_star_ts"`
+	// CompletionDayLevel holds stars by day and part: "1" -> "2" -> star.
+	CompletionDayLevel map[string]map[string]Star `json:"completion_day_level"`
+}
+
+// Star holds info about obtained star.
+type Star struct {
+	GetStarTs int64 `json:"get_star_ts"`
+	StarIndex int64 `json:"star_index"`
+}
+
+// DisplayName returns member name or placeholder for anonymous users.
+func (m Member) DisplayName() string {
+	if m.Name != "" {
+		return m.Name
+	}
+
+	return fmt.Sprintf("(anonymous user #%d)", m.ID)
+}
+
+// StarTime returns the time when member got star for passed day and part.
+func (m Member) StarTime(day, part int) (time.Time, bool) {
+	parts, ok := m.CompletionDayLevel[strconv.Itoa(day)]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	s, ok := parts[strconv.Itoa(part)]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.Unix(s.GetStarTs, 0), true
+}
+
+// DayStars returns number of stars member got for passed day: 0, 1 or 2.
+func (m Member) DayStars(day int) int {
+	return len(m.CompletionDayLevel[strconv.Itoa(day)])
+}
+
+// Ranking returns members sorted by local score, then by stars and by the time of the last star.
+func (l Leaderboard) Ranking() []Member {
+	list := make([]Member, 0, len(l.Members))
+
+	for _, m := range l.Members {
+		list = append(list, m)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+
+		if a.LocalScore != b.LocalScore {
+			return a.LocalScore > b.LocalScore
+		}
+
+		if a.Stars != b.Stars {
+			return a.Stars > b.Stars
+		}
+
+		if a.LastStarTs != b.LastStarTs {
+			return a.LastStarTs < b.LastStarTs
+		}
+
+		return a.ID < b.ID
+	})
+
+	return list
+}
+
+// Member returns member found by name or id.
+func (l Leaderboard) Member(nameOrID string) (Member, bool) {
+	for _, m := range l.Members {
+		if m.Name == nameOrID || strconv.Itoa(m.ID) == nameOrID {
+			return m, true
+		}
+	}
+
+	return Member{}, false
+}
@@ -0,0 +1,67 @@
+package leaderboard_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/leaderboard"
+)
+
+func loadLeaderboard(tb testing.TB) leaderboard.Leaderboard {
+	tb.Helper()
+
+	content, err := os.ReadFile(filepath.Join("testdata", "leaderboard.json"))
+	require.NoError(tb, err)
+
+	var lb leaderboard.Leaderboard
+
+	require.NoError(tb, json.Unmarshal(content, &lb))
+
+	return lb
+}
+
+func TestLeaderboard_Ranking(t *testing.T) {
+	lb := loadLeaderboard(t)
+
+	ranking := lb.Ranking()
+	require.Len(t, ranking, 3)
+
+	names := make([]string, 0, len(ranking))
+
+	for _, m := range ranking {
+		names = append(names, m.DisplayName())
+	}
+
+	assert.Equal(t, []string{"Alice", "Bob", "(anonymous user #3)"}, names)
+}
+
+func TestMember(t *testing.T) {
+	lb := loadLeaderboard(t)
+
+	bob, ok := lb.Member("Bob")
+	require.True(t, ok)
+
+	byID, ok := lb.Member("2")
+	require.True(t, ok)
+	assert.Equal(t, bob, byID)
+
+	_, ok = lb.Member("Carol")
+	assert.False(t, ok)
+
+	assert.Equal(t, 2, bob.DayStars(1))
+	assert.Equal(t, 1, bob.DayStars(2))
+	assert.Equal(t, 0, bob.DayStars(3))
+
+	got, ok := bob.StarTime(2, 1)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2021, time.December, 2, 6, 0, 0, 0, time.UTC), got.UTC())
+
+	_, ok = bob.StarTime(2, 2)
+	assert.False(t, ok)
+}
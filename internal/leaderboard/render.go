@@ -0,0 +1,178 @@
+package leaderboard
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+const (
+	starBoth = "★"
+	starOne  = "☆"
+	starNone = "·"
+	noValue  = "-"
+)
+
+// Render writes leaderboard rankings with per-day star grid:
+// ★ - both parts solved, ☆ - only the first part, · - not solved.
+// Δ column shows the score gap to the member ranked above.
+func Render(w io.Writer, lb Leaderboard) error {
+	const padding = 2
+
+	tw := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+
+	tens, ones := gridHeader()
+
+	lines := []string{
+		fmt.Sprintf("\t\t\t\t%s", tens),
+		fmt.Sprintf("#\tScore\tΔ\tStars\t%s\tName", ones),
+	}
+
+	ranking := lb.Ranking()
+
+	for i, m := range ranking {
+		delta := ""
+		if i > 0 {
+			delta = strconv.Itoa(m.LocalScore - ranking[i-1].LocalScore)
+		}
+
+		lines = append(lines, fmt.Sprintf("%d)\t%d\t%s\t%d\t%s\t%s",
+			i+1, m.LocalScore, delta, m.Stars, grid(m), m.DisplayName()))
+	}
+
+	for _, l := range lines {
+		if _, err := fmt.Fprintln(tw, l); err != nil {
+			return fmt.Errorf("print line: %w", err)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+
+	return nil
+}
+
+// RenderDeltas writes per-day comparison of two members: time spent on each part since puzzle unlock
+// and the difference between them. Negative difference means that the first member was faster.
+func RenderDeltas(w io.Writer, lb Leaderboard, a, b Member) error {
+	const padding = 2
+
+	tw := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+
+	if _, err := fmt.Fprintf(tw, "Day\tPart\t%s\t%s\tΔ\n", a.DisplayName(), b.DisplayName()); err != nil {
+		return fmt.Errorf("print header: %w", err)
+	}
+
+	for day := 1; day <= Days; day++ {
+		if a.DayStars(day) == 0 && b.DayStars(day) == 0 {
+			continue
+		}
+
+		unlock, err := input.UnlockTime(input.Date{Year: lb.Event, Day: strconv.Itoa(day)})
+		if err != nil {
+			return fmt.Errorf("get unlock time: %w", err)
+		}
+
+		for part := 1; part <= 2; part++ {
+			ta, okA := a.StarTime(day, part)
+			tb, okB := b.StarTime(day, part)
+
+			delta := noValue
+			if okA && okB {
+				delta = signed(ta.Sub(tb))
+			}
+
+			if _, err = fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\n", day, part,
+				spent(unlock, ta, okA), spent(unlock, tb, okB), delta); err != nil {
+				return fmt.Errorf("print line: %w", err)
+			}
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+
+	return nil
+}
+
+func gridHeader() (string, string) {
+	var tens, ones strings.Builder
+
+	for day := 1; day <= Days; day++ {
+		const ten = 10
+
+		if day < ten {
+			tens.WriteString(" ")
+		} else {
+			tens.WriteString(strconv.Itoa(day / ten))
+		}
+
+		ones.WriteString(strconv.Itoa(day % ten))
+	}
+
+	return tens.String(), ones.String()
+}
+
+func grid(m Member) string {
+	var sb strings.Builder
+
+	for day := 1; day <= Days; day++ {
+		switch m.DayStars(day) {
+		case 0:
+			sb.WriteString(starNone)
+		case 1:
+			sb.WriteString(starOne)
+		default:
+			sb.WriteString(starBoth)
+		}
+	}
+
+	return sb.String()
+}
+
+func spent(unlock, at time.Time, ok bool) string {
+	if !ok {
+		return noValue
+	}
+
+	return FormatDuration(at.Sub(unlock))
+}
+
+func signed(d time.Duration) string {
+	if d < 0 {
+		return "-" + FormatDuration(-d)
+	}
+
+	return "+" + FormatDuration(d)
+}
+
+// FormatDuration formats duration as hh:mm:ss, prefixed with days when it is longer than a day.
+func FormatDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+
+	d = d.Round(time.Second)
+
+	days := d / day
+	d -= days * day
+
+	h := d / time.Hour
+	d -= h * time.Hour
+
+	m := d / time.Minute
+	d -= m * time.Minute
+
+	s := d / time.Second
+
+	if days > 0 {
+		return fmt.Sprintf("%dd %02d:%02d:%02d", days, h, m, s)
+	}
+
+	return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+}
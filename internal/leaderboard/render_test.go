@@ -0,0 +1,56 @@
+package leaderboard_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/leaderboard"
+)
+
+func TestRender(t *testing.T) {
+	var buf strings.Builder
+
+	require.NoError(t, leaderboard.Render(&buf, loadLeaderboard(t)))
+
+	want := "" +
+		"                               1111111111222222\n" +
+		"#   Score  Δ   Stars  1234567890123456789012345  Name\n" +
+		"1)  11         4      ★★·······················  Alice\n" +
+		"2)  8      -3  3      ★☆·······················  Bob\n" +
+		"3)  0      -8  0      ·························  (anonymous user #3)\n"
+
+	assert.Equal(t, want, buf.String())
+}
+
+func TestRenderDeltas(t *testing.T) {
+	lb := loadLeaderboard(t)
+
+	alice, ok := lb.Member("Alice")
+	require.True(t, ok)
+
+	bob, ok := lb.Member("Bob")
+	require.True(t, ok)
+
+	var buf strings.Builder
+
+	require.NoError(t, leaderboard.RenderDeltas(&buf, lb, alice, bob))
+
+	want := "" +
+		"Day  Part  Alice     Bob       Δ\n" +
+		"1    1     00:05:00  00:15:00  -00:10:00\n" +
+		"1    2     00:10:00  01:00:00  -00:50:00\n" +
+		"2    1     00:10:00  01:00:00  -00:50:00\n" +
+		"2    2     00:15:00  -         -\n"
+
+	assert.Equal(t, want, buf.String())
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "00:00:01", leaderboard.FormatDuration(time.Second))
+	assert.Equal(t, "01:02:03", leaderboard.FormatDuration(time.Hour+2*time.Minute+3*time.Second))
+	assert.Equal(t, "2d 00:00:05", leaderboard.FormatDuration(48*time.Hour+5*time.Second))
+}
@@ -0,0 +1,81 @@
+package progress
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/obalunenko/advent-of-code/internal/session"
+)
+
+const dayClassPrefix = "calendar-day"
+
+// ParseCalendar returns stars obtained by day from the event calendar page of logged-in user.
+// Days which are not unlocked yet have no stars.
+func ParseCalendar(page []byte) (map[int]int, error) {
+	// Stars are shown only to logged-in user, so anonymous page would report no progress.
+	if _, err := session.ParseAccount(page); err != nil {
+		return nil, err
+	}
+
+	doc, err := html.Parse(bytes.NewReader(page))
+	if err != nil {
+		return nil, fmt.Errorf("parse page: %w", err)
+	}
+
+	stars := make(map[int]int, Days)
+
+	var walk func(n *html.Node)
+
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			if day, count, ok := dayStars(n); ok {
+				stars[day] = count
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+
+	walk(doc)
+
+	return stars, nil
+}
+
+// dayStars parses calendar day element: class="calendar-day5 calendar-verycomplete".
+func dayStars(n *html.Node) (int, int, bool) {
+	var (
+		day   int
+		count int
+	)
+
+	for _, a := range n.Attr {
+		if a.Key != "class" {
+			continue
+		}
+
+		for _, cls := range strings.Fields(a.Val) {
+			switch cls {
+			case "calendar-verycomplete":
+				count = 2
+			case "calendar-complete":
+				count = 1
+			default:
+				if s, ok := strings.CutPrefix(cls, dayClassPrefix); ok {
+					day, _ = strconv.Atoi(s)
+				}
+			}
+		}
+	}
+
+	if day < 1 || day > Days {
+		return 0, 0, false
+	}
+
+	return day, count, true
+}
@@ -0,0 +1,119 @@
+// Package progress combines registered solutions with stars obtained on adventofcode.com
+// to show progress of each event at a glance.
+package progress
+
+import (
+	"strconv"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+)
+
+// Days is a number of puzzles in the event.
+const Days = 25
+
+// State is a state of puzzle solution in code.
+type State int
+
+const (
+	// NotStarted means there is no registered solver.
+	NotStarted State = iota
+	// Scaffolded means solver is registered, but no part is implemented.
+	Scaffolded
+	// PartOne means only part one is implemented.
+	PartOne
+	// Complete means both parts are implemented.
+	Complete
+)
+
+// String returns description of the state.
+func (s State) String() string {
+	switch s {
+	case NotStarted:
+		return "not started"
+	case Scaffolded:
+		return "scaffolded"
+	case PartOne:
+		return "part 1"
+	case Complete:
+		return "both parts"
+	default:
+		return "State(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// stars returns number of stars implemented parts are worth.
+func (s State) stars() int {
+	switch s {
+	case PartOne:
+		return 1
+	case Complete:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// Day is a progress of one puzzle.
+type Day struct {
+	Day   int
+	State State
+	// Stars is a number of stars obtained on the website, -1 when unknown.
+	Stars int
+}
+
+// Mismatch reports whether stars on the website differ from parts implemented in code,
+// e.g. puzzle is starred but has no solver, or the reverse.
+func (d Day) Mismatch() bool {
+	return d.Stars >= 0 && d.Stars != d.State.stars()
+}
+
+// Year is a progress of the event.
+type Year struct {
+	Year string
+	Days []Day
+}
+
+// Build returns progress of the year from implemented parts of registered solvers (see puzzles.Probe) and
+// stars by day parsed from the calendar page. Nil stars means website state is unknown.
+func Build(year string, parts []puzzles.Parts, stars map[int]int) Year {
+	res := Year{
+		Year: year,
+		Days: make([]Day, Days),
+	}
+
+	for i := range res.Days {
+		res.Days[i] = Day{
+			Day:   i + 1,
+			State: NotStarted,
+			Stars: -1,
+		}
+
+		if stars != nil {
+			res.Days[i].Stars = stars[i+1]
+		}
+	}
+
+	for _, p := range parts {
+		if p.Year != year {
+			continue
+		}
+
+		day, err := strconv.Atoi(p.Day)
+		if err != nil || day < 1 || day > Days {
+			continue
+		}
+
+		state := Scaffolded
+
+		switch {
+		case p.Part1 && p.Part2:
+			state = Complete
+		case p.Part1:
+			state = PartOne
+		}
+
+		res.Days[day-1].State = state
+	}
+
+	return res
+}
@@ -0,0 +1,88 @@
+package progress_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/progress"
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/session"
+)
+
+const calendar = `<header><div class="user">Alice <span class="star-count">5*</span></div></header>` +
+	`<main><pre class="calendar">` +
+	`<a aria-label="Day 1, two stars" href="/2021/day/1" class="calendar-day1 calendar-verycomplete">1</a>` +
+	`<a aria-label="Day 2, one star" href="/2021/day/2" class="calendar-day2 calendar-complete">2</a>` +
+	`<a aria-label="Day 3" href="/2021/day/3" class="calendar-day3">3</a>` +
+	`<a aria-label="Day 4, two stars" href="/2021/day/4" class="calendar-day4 calendar-verycomplete">4</a>` +
+	`<span class="calendar-day5">5</span>` +
+	`</pre></main>`
+
+func TestParseCalendar(t *testing.T) {
+	stars, err := progress.ParseCalendar([]byte(calendar))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[int]int{1: 2, 2: 1, 3: 0, 4: 2, 5: 0}, stars)
+
+	_, err = progress.ParseCalendar([]byte(`<main><pre class="calendar"></pre></main>`))
+	assert.ErrorIs(t, err, session.ErrInvalidSession)
+}
+
+func TestBuild(t *testing.T) {
+	parts := []puzzles.Parts{
+		{Year: "2021", Day: "1", Part1: true, Part2: true},
+		{Year: "2021", Day: "2", Part1: true, Part2: false},
+		{Year: "2021", Day: "3", Part1: false, Part2: false},
+		{Year: "2020", Day: "4", Part1: true, Part2: true},
+	}
+
+	t.Run("without stars", func(t *testing.T) {
+		y := progress.Build("2021", parts, nil)
+
+		require.Len(t, y.Days, progress.Days)
+		assert.Equal(t, progress.Day{Day: 1, State: progress.Complete, Stars: -1}, y.Days[0])
+		assert.Equal(t, progress.Day{Day: 2, State: progress.PartOne, Stars: -1}, y.Days[1])
+		assert.Equal(t, progress.Day{Day: 3, State: progress.Scaffolded, Stars: -1}, y.Days[2])
+		assert.Equal(t, progress.Day{Day: 4, State: progress.NotStarted, Stars: -1}, y.Days[3])
+
+		for _, d := range y.Days {
+			assert.False(t, d.Mismatch(), "day %d", d.Day)
+		}
+	})
+
+	t.Run("with stars", func(t *testing.T) {
+		y := progress.Build("2021", parts, map[int]int{1: 2, 2: 1, 3: 0, 4: 2})
+
+		var mismatched []int
+
+		for _, d := range y.Days {
+			if d.Mismatch() {
+				mismatched = append(mismatched, d.Day)
+			}
+		}
+
+		assert.Equal(t, []int{4}, mismatched)
+	})
+}
+
+func TestRender(t *testing.T) {
+	parts := []puzzles.Parts{
+		{Year: "2021", Day: "1", Part1: true, Part2: true},
+		{Year: "2021", Day: "2", Part1: true, Part2: false},
+	}
+
+	var sb strings.Builder
+
+	require.NoError(t, progress.Render(&sb, []progress.Year{
+		progress.Build("2021", parts, map[int]int{1: 2, 2: 2}),
+	}))
+
+	lines := strings.Split(sb.String(), "\n")
+
+	assert.True(t, strings.HasPrefix(lines[0], "     1  2  3 "), lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "2021 ★  ☆! · "), lines[1])
+	assert.Contains(t, sb.String(), "Mismatches:\n  2021/2: part 1 in code, 2 stars on the website\n")
+}
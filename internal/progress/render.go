@@ -0,0 +1,80 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	markNotStarted = "·"
+	markScaffolded = "○"
+	markPartOne    = "☆"
+	markComplete   = "★"
+	markMismatch   = "!"
+)
+
+// Render writes grid of the years with a column per day followed by the legend and list of mismatches
+// between code and the website.
+func Render(w io.Writer, years []Year) error {
+	var sb strings.Builder
+
+	sb.WriteString("    ")
+
+	for day := 1; day <= Days; day++ {
+		fmt.Fprintf(&sb, " %-2d", day)
+	}
+
+	sb.WriteString("\n")
+
+	var mismatches []string
+
+	for _, y := range years {
+		sb.WriteString(y.Year)
+
+		for _, d := range y.Days {
+			mark := " "
+
+			if d.Mismatch() {
+				mark = markMismatch
+
+				mismatches = append(mismatches, fmt.Sprintf("%s/%d: %s in code, %d stars on the website",
+					y.Year, d.Day, d.State, d.Stars))
+			}
+
+			sb.WriteString(" " + stateMark(d.State) + mark)
+		}
+
+		sb.WriteString("\n")
+	}
+
+	fmt.Fprintf(&sb, "\n%s not started  %s scaffolded  %s part 1  %s both parts  %s differs from the website\n",
+		markNotStarted, markScaffolded, markPartOne, markComplete, markMismatch)
+
+	if len(mismatches) != 0 {
+		sb.WriteString("\nMismatches:\n")
+
+		for _, m := range mismatches {
+			sb.WriteString("  " + m + "\n")
+		}
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	return nil
+}
+
+func stateMark(s State) string {
+	switch s {
+	case Scaffolded:
+		return markScaffolded
+	case PartOne:
+		return markPartOne
+	case Complete:
+		return markComplete
+	default:
+		return markNotStarted
+	}
+}
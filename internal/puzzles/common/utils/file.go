@@ -2,20 +2,34 @@
 package utils
 
 import (
+	"bytes"
+	"errors"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/vault"
 )
 
 // ReaderFromFile reads file from fpath and returns content as io.Reader.
 // File descriptor will be closed on tests teardown.
+//
+// When file does not exist, but its encrypted version (fpath with vault.Ext) does, it is decrypted
+// with the vault key from env. Test is skipped when the key is not set.
 func ReaderFromFile(tb testing.TB, fpath string) io.Reader {
 	tb.Helper()
 
 	file, err := os.Open(filepath.Clean(fpath))
+	if errors.Is(err, fs.ErrNotExist) {
+		if r, ok := readerFromVault(tb, fpath); ok {
+			return r
+		}
+	}
+
 	require.NoError(tb, err)
 
 	tb.Cleanup(func() {
@@ -24,3 +38,25 @@ func ReaderFromFile(tb testing.TB, fpath string) io.Reader {
 
 	return file
 }
+
+func readerFromVault(tb testing.TB, fpath string) (io.Reader, bool) {
+	tb.Helper()
+
+	encPath := fpath + vault.Ext
+
+	if _, err := os.Stat(encPath); err != nil {
+		return nil, false
+	}
+
+	v, err := vault.FromEnv()
+	if errors.Is(err, vault.ErrNoKey) {
+		tb.Skipf("%s is encrypted and %s is not set", fpath, vault.EnvKey)
+	}
+
+	require.NoError(tb, err)
+
+	content, err := v.ReadFile(encPath)
+	require.NoError(tb, err)
+
+	return bytes.NewReader(content), true
+}
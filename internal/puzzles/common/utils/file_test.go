@@ -8,6 +8,8 @@
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/vault"
 )
 
 func TestReaderFromFile(t *testing.T) {
@@ -45,3 +47,34 @@ type args struct {
 		})
 	}
 }
+
+func TestReaderFromFile_encrypted(t *testing.T) {
+	const key = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+	fpath := filepath.Join("testdata", "secret.txt")
+
+	t.Setenv(vault.EnvKeyFile, "")
+
+	t.Run("key is set", func(t *testing.T) {
+		t.Setenv(vault.EnvKey, key)
+
+		got, err := io.ReadAll(ReaderFromFile(t, fpath))
+		require.NoError(t, err)
+
+		assert.Equal(t, "Hello from vault!\n", string(got))
+	})
+
+	var skipped bool
+
+	t.Run("key is not set", func(t *testing.T) {
+		t.Setenv(vault.EnvKey, "")
+
+		t.Cleanup(func() {
+			skipped = t.Skipped()
+		})
+
+		ReaderFromFile(t, fpath)
+	})
+
+	assert.True(t, skipped)
+}
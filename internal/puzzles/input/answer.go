@@ -0,0 +1,105 @@
+package input
+
+import (
+	"context"
+	"errors"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// AnswerStatus is a status of submitted answer.
+type AnswerStatus string
+
+// Statuses of submitted answer.
+const (
+	AnswerRight   AnswerStatus = "right"
+	AnswerWrong   AnswerStatus = "wrong"
+	AnswerTooHigh AnswerStatus = "too-high"
+	AnswerTooLow  AnswerStatus = "too-low"
+	// AnswerTooRecent means that answer is not checked as previous wrong answer was submitted too recently.
+	AnswerTooRecent AnswerStatus = "too-recent"
+	// AnswerWrongLevel means that part is already solved or not unlocked yet.
+	AnswerWrongLevel AnswerStatus = "wrong-level"
+)
+
+// ErrUnknownVerdict returns when answer page has no known verdict.
+var ErrUnknownVerdict = errors.New("unknown answer verdict")
+
+// Verdict is a check result of submitted answer.
+type Verdict struct {
+	Status AnswerStatus
+	// Message is a text of the verdict shown by the site.
+	Message string
+}
+
+// AnswerSubmitter is an answer submit client.
+type AnswerSubmitter interface {
+	SubmitAnswer(ctx context.Context, d Date, part, answer, session string) ([]byte, error)
+}
+
+// NewAnswerSubmitter constructor for AnswerSubmitter.
+func NewAnswerSubmitter(c IHTTPClient, timeout time.Duration, opts ...Option) AnswerSubmitter {
+	return newClient(c, timeout, opts...)
+}
+
+// SubmitAnswer posts answer of the puzzle part and returns page with verdict, see ParseVerdict.
+func (c *client) SubmitAnswer(ctx context.Context, d Date, part, answer, session string) ([]byte, error) {
+	const (
+		day      = "day"
+		endpoint = "answer"
+	)
+
+	if session == "" {
+		return nil, ErrUnauthorized
+	}
+
+	form := url.Values{
+		"level":  {part},
+		"answer": {answer},
+	}
+
+	return c.send(ctx, http.MethodPost, d, session, form, d.Year, day, d.Day, endpoint)
+}
+
+var (
+	articleRe = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRe     = regexp.MustCompile(`<[^>]*>`)
+)
+
+// ParseVerdict returns verdict from the page returned on answer submission.
+func ParseVerdict(page []byte) (Verdict, error) {
+	m := articleRe.FindSubmatch(page)
+	if m == nil {
+		return Verdict{}, ErrUnknownVerdict
+	}
+
+	msg := strings.Join(strings.Fields(html.UnescapeString(tagRe.ReplaceAllString(string(m[1]), ""))), " ")
+
+	var status AnswerStatus
+
+	switch {
+	case strings.Contains(msg, "That's the right answer"):
+		status = AnswerRight
+	case strings.Contains(msg, "your answer is too high"):
+		status = AnswerTooHigh
+	case strings.Contains(msg, "your answer is too low"):
+		status = AnswerTooLow
+	case strings.Contains(msg, "That's not the right answer"):
+		status = AnswerWrong
+	case strings.Contains(msg, "You gave an answer too recently"):
+		status = AnswerTooRecent
+	case strings.Contains(msg, "You don't seem to be solving the right level"):
+		status = AnswerWrongLevel
+	default:
+		return Verdict{}, ErrUnknownVerdict
+	}
+
+	return Verdict{
+		Status:  status,
+		Message: msg,
+	}, nil
+}
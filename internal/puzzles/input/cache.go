@@ -0,0 +1,129 @@
+package input
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	log "github.com/obalunenko/logger"
+
+	"github.com/obalunenko/advent-of-code/internal/vault"
+)
+
+const (
+	cacheFile = "input"
+
+	cacheDirPerms  = 0o700
+	cacheFilePerms = 0o600
+)
+
+// DefaultCacheDir returns directory for cached inputs in user cache dir.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("get user cache dir: %w", err)
+	}
+
+	return filepath.Join(dir, "aoc-cli", "inputs"), nil
+}
+
+// cacheHits and cacheMisses count lookups of inputs cache by all cached fetchers of the process.
+var cacheHits, cacheMisses atomic.Uint64
+
+// CacheStats returns number of inputs found in cache and fetched since process start.
+func CacheStats() (hits, misses uint64) {
+	return cacheHits.Load(), cacheMisses.Load()
+}
+
+type cachedFetcher struct {
+	f     Fetcher
+	dir   string
+	vault *vault.Vault
+}
+
+// NewCachedFetcher wraps Fetcher to store inputs in dir, so each input is downloaded only once.
+// Inputs differ by account, so they are cached per session.
+// When v is not nil, inputs are stored encrypted and encrypted cache is read as well.
+func NewCachedFetcher(f Fetcher, dir string, v *vault.Vault) Fetcher {
+	return &cachedFetcher{
+		f:     f,
+		dir:   dir,
+		vault: v,
+	}
+}
+
+// Fetch returns cached input or fetches and caches it.
+func (c *cachedFetcher) Fetch(ctx context.Context, d Date, session string) ([]byte, error) {
+	fpath := CachePath(c.dir, d, session)
+
+	content, err := readInput(fpath, c.vault)
+	if err == nil {
+		cacheHits.Add(1)
+
+		return content, nil
+	}
+
+	cacheMisses.Add(1)
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		log.WithError(ctx, err).WithField("path", fpath).Warn("Failed to read cached input")
+	}
+
+	content, err = c.f.Fetch(ctx, d, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.write(fpath, content); err != nil {
+		log.WithError(ctx, err).WithField("path", fpath).Warn("Failed to cache input")
+	}
+
+	return content, nil
+}
+
+// CachePath returns path of cached input in dir: <dir>/<account>/<year>/day/<day>/input.
+func CachePath(dir string, d Date, session string) string {
+	return filepath.Join(CacheDir(dir, session), InputPath(d))
+}
+
+// CacheDir returns directory of cached inputs of the account in dir. Account is derived from session.
+func CacheDir(dir, session string) string {
+	sum := sha256.Sum256([]byte(session))
+
+	const accountLen = 8
+
+	return filepath.Join(dir, hex.EncodeToString(sum[:accountLen]))
+}
+
+// InputPath returns relative path of input file, the same as its URL path: <year>/day/<day>/input.
+func InputPath(d Date) string {
+	return filepath.Join(d.Year, "day", d.Day, cacheFile)
+}
+
+func (c *cachedFetcher) write(fpath string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(fpath), cacheDirPerms); err != nil {
+		return fmt.Errorf("create dir: %w", err)
+	}
+
+	if c.vault != nil {
+		sealed, err := c.vault.Seal(content)
+		if err != nil {
+			return err
+		}
+
+		fpath += vault.Ext
+		content = sealed
+	}
+
+	if err := os.WriteFile(fpath, content, cacheFilePerms); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package input_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/vault"
+)
+
+type countingFetcher struct {
+	calls int
+	err   error
+}
+
+func (f *countingFetcher) Fetch(_ context.Context, d input.Date, session string) ([]byte, error) {
+	f.calls++
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return []byte(d.String() + ":" + session), nil
+}
+
+func TestCachedFetcher(t *testing.T) {
+	const key = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+	v, err := vault.Parse(key)
+	require.NoError(t, err)
+
+	d := input.Date{Year: "2021", Day: "1"}
+
+	tests := []struct {
+		name  string
+		vault *vault.Vault
+		ext   string
+	}{
+		{
+			name:  "plain",
+			vault: nil,
+			ext:   "",
+		},
+		{
+			name:  "encrypted",
+			vault: v,
+			ext:   vault.Ext,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dir := t.TempDir()
+
+			f := &countingFetcher{}
+
+			cli := input.NewCachedFetcher(f, dir, tt.vault)
+
+			hits, misses := input.CacheStats()
+
+			for range 2 {
+				got, err := cli.Fetch(ctx, d, "alice")
+				require.NoError(t, err)
+
+				assert.Equal(t, "2021/1:alice", string(got))
+			}
+
+			assert.Equal(t, 1, f.calls)
+
+			gotHits, gotMisses := input.CacheStats()
+			assert.Equal(t, hits+1, gotHits)
+			assert.Equal(t, misses+1, gotMisses)
+
+			cached, err := os.ReadFile(input.CachePath(dir, d, "alice") + tt.ext)
+			require.NoError(t, err)
+
+			if tt.vault != nil {
+				assert.NotContains(t, string(cached), "alice")
+			}
+
+			got, err := cli.Fetch(ctx, d, "bob")
+			require.NoError(t, err)
+
+			assert.Equal(t, "2021/1:bob", string(got), "cache is per session")
+			assert.Equal(t, 2, f.calls)
+		})
+	}
+}
+
+func TestCachedFetcher_error(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+
+	dir := t.TempDir()
+
+	f := &countingFetcher{
+		err: errFetch,
+	}
+
+	_, err := input.NewCachedFetcher(f, dir, nil).Fetch(context.Background(), input.Date{Year: "2021", Day: "1"}, "alice")
+	require.ErrorIs(t, err, errFetch)
+
+	_, err = os.Stat(input.CachePath(dir, input.Date{Year: "2021", Day: "1"}, "alice"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,22 @@
+package input
+
+import (
+	"context"
+	"time"
+)
+
+// CalendarFetcher is an event calendar page get client.
+type CalendarFetcher interface {
+	FetchCalendar(ctx context.Context, year, session string) ([]byte, error)
+}
+
+// NewCalendarFetcher constructor for CalendarFetcher.
+func NewCalendarFetcher(c IHTTPClient, timeout time.Duration, opts ...Option) CalendarFetcher {
+	return newClient(c, timeout, opts...)
+}
+
+// FetchCalendar returns event calendar page HTML for passed year.
+// The page shows logged-in user and stars obtained for each day when session is valid.
+func (c *client) FetchCalendar(ctx context.Context, year, session string) ([]byte, error) {
+	return c.get(ctx, Date{Year: year, Day: ""}, session, year)
+}
@@ -1,4 +1,4 @@
-// Package input provides access to embedded puzzles input files.
+// Package input provides access to puzzles inputs and pages on adventofcode.com.
 package input
 
 import (
@@ -16,7 +16,7 @@
 )
 
 var (
-	// ErrNotFound returns when puzzle input is not yet unlocked or invalid date passed.
+	// ErrNotFound returns when puzzle page is not found, e.g. invalid date passed.
 	ErrNotFound = errors.New("puzzle inout not found")
 	// ErrUnauthorized returns when session is empty or invalid.
 	ErrUnauthorized = errors.New("unauthorized")
@@ -45,21 +45,42 @@ type Fetcher interface {
 type client struct {
 	cli     IHTTPClient
 	timeout time.Duration
+	now     func() time.Time
+	baseURL string
 }
 
 // NewFetcher constructor for Fetcher.
-func NewFetcher(c IHTTPClient, timeout time.Duration) Fetcher {
-	return &client{
-		cli:     c,
-		timeout: timeout,
-	}
+func NewFetcher(c IHTTPClient, timeout time.Duration, opts ...Option) Fetcher {
+	return newClient(c, timeout, opts...)
 }
 
 // Fetch returns puzzle input.
 func (c *client) Fetch(ctx context.Context, d Date, session string) ([]byte, error) {
-	req, err := createInputReq(ctx, d, session)
+	const (
+		day   = "day"
+		input = "input"
+	)
+
+	return c.get(ctx, d, session, d.Year, day, d.Day, input)
+}
+
+// get sends GET request to the adventofcode.com page built from passed path elements.
+func (c *client) get(ctx context.Context, d Date, session string, elems ...string) ([]byte, error) {
+	return c.send(ctx, http.MethodGet, d, session, nil, elems...)
+}
+
+// send sends request to the adventofcode.com page built from passed path elements.
+// Form is sent as request body when it is not nil.
+func (c *client) send(ctx context.Context, method string, d Date, session string, form url.Values,
+	elems ...string,
+) ([]byte, error) {
+	if err := checkUnlocked(d, c.now()); err != nil {
+		return nil, err
+	}
+
+	req, err := createReq(ctx, method, c.baseURL, session, form, elems...)
 	if err != nil {
-		return nil, fmt.Errorf("create input request: %w", err)
+		return nil, fmt.Errorf("create request: %w", err)
 	}
 
 	var cancel context.CancelFunc
@@ -99,31 +120,41 @@ func (c *client) Fetch(ctx context.Context, d Date, session string) ([]byte, err
 	case http.StatusBadRequest:
 		return nil, ErrUnauthorized
 	default:
-		return nil, fmt.Errorf("[%s] failed to get puzzle input[%s]", d, resp.Status)
+		return nil, fmt.Errorf("[%s] failed to get page %q[%s]", d, req.URL.Path, resp.Status)
 	}
 }
 
-// createInputReq creates an HTTP request for retrieving the Advent of Code
-// input given year/day.
-func createInputReq(ctx context.Context, d Date, sessionID string) (*http.Request, error) {
-	const (
-		baseurl = "https://adventofcode.com"
-		day     = "day"
-		input   = "input"
-	)
-
+// createReq creates an HTTP request for the Advent of Code
+// page given by path elements. Form is URL-encoded into request body when it is not nil.
+func createReq(ctx context.Context, method, baseurl, sessionID string, form url.Values,
+	elems ...string,
+) (*http.Request, error) {
 	u, err := url.Parse(baseurl)
 	if err != nil {
 		return nil, fmt.Errorf("parse base url: %w", err)
 	}
 
-	u.Path = path.Join(u.Path, d.Year, day, d.Day, input)
+	u.Path = path.Join(append([]string{u.Path}, elems...)...)
+
+	var body io.Reader = http.NoBody
+
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	if sessionID == "" {
+		return req, nil
+	}
+
 	req.AddCookie(&http.Cookie{
 		Name:       "session",
 		Value:      sessionID,
@@ -0,0 +1,51 @@
+package input
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/obalunenko/advent-of-code/internal/vault"
+)
+
+type dirFetcher struct {
+	dir   string
+	vault *vault.Vault
+}
+
+// NewDirFetcher creates Fetcher that reads inputs from local dir with <dir>/<year>/day/<day>/input layout,
+// e.g. filled by download command. Encrypted inputs are read when v is not nil.
+// Session is ignored.
+func NewDirFetcher(dir string, v *vault.Vault) Fetcher {
+	return &dirFetcher{
+		dir:   dir,
+		vault: v,
+	}
+}
+
+// Fetch reads input from dir. Returns ErrNotFound when there is no input.
+func (f *dirFetcher) Fetch(_ context.Context, d Date, _ string) ([]byte, error) {
+	content, err := readInput(filepath.Join(f.dir, InputPath(d)), f.vault)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("[%s] %w: %w", d, ErrNotFound, err)
+		}
+
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// readInput reads input file or its encrypted version when v is not nil.
+func readInput(fpath string, v *vault.Vault) ([]byte, error) {
+	content, err := os.ReadFile(filepath.Clean(fpath))
+	if err == nil || !errors.Is(err, fs.ErrNotExist) || v == nil {
+		return content, err
+	}
+
+	return v.ReadFile(fpath + vault.Ext)
+}
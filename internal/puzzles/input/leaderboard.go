@@ -0,0 +1,28 @@
+package input
+
+import (
+	"context"
+	"time"
+)
+
+// LeaderboardFetcher is a private leaderboard get client.
+type LeaderboardFetcher interface {
+	FetchLeaderboard(ctx context.Context, year, id, session string) ([]byte, error)
+}
+
+// NewLeaderboardFetcher constructor for LeaderboardFetcher.
+func NewLeaderboardFetcher(c IHTTPClient, timeout time.Duration, opts ...Option) LeaderboardFetcher {
+	return newClient(c, timeout, opts...)
+}
+
+// FetchLeaderboard returns private leaderboard JSON for passed year and leaderboard id.
+// Advent of Code asks to not request it more often than once per 15 minutes.
+func (c *client) FetchLeaderboard(ctx context.Context, year, id, session string) ([]byte, error) {
+	const (
+		leaderboard = "leaderboard"
+		private     = "private"
+		view        = "view"
+	)
+
+	return c.get(ctx, Date{Year: year, Day: ""}, session, year, leaderboard, private, view, id+".json")
+}
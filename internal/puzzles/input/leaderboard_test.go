@@ -0,0 +1,42 @@
+package input_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+func TestFetchLeaderboard(t *testing.T) {
+	var got *http.Request
+
+	mock := newMockHTTPClient(returnParams{
+		status: http.StatusOK,
+		body:   io.NopCloser(strings.NewReader(`{"event":"2021"}`)),
+	})
+	do := mock.MockDo
+
+	mock.MockDo = func(req *http.Request) (*http.Response, error) {
+		got = req
+
+		return do(req)
+	}
+
+	cli := input.NewLeaderboardFetcher(mock, time.Second*5)
+
+	body, err := cli.FetchLeaderboard(context.Background(), "2021", "12345", "123")
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"event":"2021"}`, string(body))
+	assert.Equal(t, "/2021/leaderboard/private/view/12345.json", got.URL.Path)
+
+	_, err = cli.FetchLeaderboard(context.Background(), "2999", "12345", "123")
+	assert.ErrorIs(t, err, input.ErrNotYetUnlocked)
+}
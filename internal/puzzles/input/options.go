@@ -0,0 +1,38 @@
+package input
+
+import (
+	"time"
+)
+
+// DefaultBaseURL is a base URL of Advent of Code site.
+const DefaultBaseURL = "https://adventofcode.com"
+
+// Option configures fetchers.
+type Option func(c *client)
+
+// WithBaseURL sets base URL of the site, e.g. to use local fake server in tests.
+// Empty URL is ignored.
+func WithBaseURL(u string) Option {
+	return func(c *client) {
+		if u == "" {
+			return
+		}
+
+		c.baseURL = u
+	}
+}
+
+func newClient(c IHTTPClient, timeout time.Duration, opts ...Option) *client {
+	cl := client{
+		cli:     c,
+		timeout: timeout,
+		now:     time.Now,
+		baseURL: DefaultBaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(&cl)
+	}
+
+	return &cl
+}
@@ -0,0 +1,64 @@
+package input_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+func TestWithBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []input.Option
+		wantURL string
+	}{
+		{
+			name:    "default",
+			opts:    nil,
+			wantURL: input.DefaultBaseURL + "/2021/day/1/input",
+		},
+		{
+			name:    "custom",
+			opts:    []input.Option{input.WithBaseURL("http://127.0.0.1:8080")},
+			wantURL: "http://127.0.0.1:8080/2021/day/1/input",
+		},
+		{
+			name:    "empty is ignored",
+			opts:    []input.Option{input.WithBaseURL("")},
+			wantURL: input.DefaultBaseURL + "/2021/day/1/input",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+
+			mock := newMockHTTPClient(returnParams{
+				status: http.StatusOK,
+				body:   io.NopCloser(strings.NewReader("1,2,3")),
+			})
+			do := mock.MockDo
+
+			mock.MockDo = func(req *http.Request) (*http.Response, error) {
+				got = req
+
+				return do(req)
+			}
+
+			cli := input.NewFetcher(mock, time.Second*5, tt.opts...)
+
+			_, err := cli.Fetch(context.Background(), input.Date{Year: "2021", Day: "1"}, "123")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantURL, got.URL.String())
+		})
+	}
+}
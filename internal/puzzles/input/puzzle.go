@@ -0,0 +1,24 @@
+package input
+
+import (
+	"context"
+	"time"
+)
+
+// PuzzleFetcher is a puzzle description page get client.
+type PuzzleFetcher interface {
+	FetchPuzzle(ctx context.Context, d Date, session string) ([]byte, error)
+}
+
+// NewPuzzleFetcher constructor for PuzzleFetcher.
+// Session is optional: without it only the first part of description is available.
+func NewPuzzleFetcher(c IHTTPClient, timeout time.Duration, opts ...Option) PuzzleFetcher {
+	return newClient(c, timeout, opts...)
+}
+
+// FetchPuzzle returns puzzle description page HTML.
+func (c *client) FetchPuzzle(ctx context.Context, d Date, session string) ([]byte, error) {
+	const day = "day"
+
+	return c.get(ctx, d, session, d.Year, day, d.Day)
+}
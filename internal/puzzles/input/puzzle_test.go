@@ -0,0 +1,101 @@
+package input_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+func TestFetchPuzzle(t *testing.T) {
+	type expected struct {
+		path    string
+		cookie  bool
+		want    []byte
+		wantErr assert.ErrorAssertionFunc
+	}
+
+	tests := []struct {
+		name         string
+		returnParams returnParams
+		session      string
+		expected     expected
+	}{
+		{
+			name: "with session",
+			returnParams: returnParams{
+				status: http.StatusOK,
+				body:   io.NopCloser(strings.NewReader("<main></main>")),
+			},
+			session: "123",
+			expected: expected{
+				path:    "/2021/day/5",
+				cookie:  true,
+				want:    []byte("<main></main>"),
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			name: "without session",
+			returnParams: returnParams{
+				status: http.StatusOK,
+				body:   io.NopCloser(strings.NewReader("<main></main>")),
+			},
+			session: "",
+			expected: expected{
+				path:    "/2021/day/5",
+				cookie:  false,
+				want:    []byte("<main></main>"),
+				wantErr: assert.NoError,
+			},
+		},
+		{
+			name: "not found",
+			returnParams: returnParams{
+				status: http.StatusNotFound,
+				body:   http.NoBody,
+			},
+			session: "123",
+			expected: expected{
+				path:    "/2021/day/5",
+				cookie:  true,
+				want:    nil,
+				wantErr: assert.Error,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+
+			mock := newMockHTTPClient(tt.returnParams)
+			do := mock.MockDo
+
+			mock.MockDo = func(req *http.Request) (*http.Response, error) {
+				got = req
+
+				return do(req)
+			}
+
+			cli := input.NewPuzzleFetcher(mock, time.Second*5)
+
+			page, err := cli.FetchPuzzle(context.Background(), input.Date{Year: "2021", Day: "5"}, tt.session)
+			if !tt.expected.wantErr(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.expected.want, page)
+			assert.Equal(t, tt.expected.path, got.URL.Path)
+
+			_, cerr := got.Cookie("session")
+			assert.Equal(t, tt.expected.cookie, cerr == nil)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package input
+
+import (
+	"context"
+	"time"
+)
+
+// StatsFetcher is a personal leaderboard statistics get client.
+type StatsFetcher interface {
+	FetchStats(ctx context.Context, year, session string) ([]byte, error)
+}
+
+// NewStatsFetcher constructor for StatsFetcher.
+func NewStatsFetcher(c IHTTPClient, timeout time.Duration, opts ...Option) StatsFetcher {
+	return newClient(c, timeout, opts...)
+}
+
+// FetchStats returns personal leaderboard statistics page of the year with solve times and ranks.
+func (c *client) FetchStats(ctx context.Context, year, session string) ([]byte, error) {
+	const (
+		leaderboard = "leaderboard"
+		self        = "self"
+	)
+
+	return c.get(ctx, Date{Year: year, Day: ""}, session, year, leaderboard, self)
+}
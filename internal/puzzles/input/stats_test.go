@@ -0,0 +1,43 @@
+package input_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+func TestFetchStats(t *testing.T) {
+	var got *http.Request
+
+	mock := newMockHTTPClient(returnParams{
+		status: http.StatusOK,
+		body:   io.NopCloser(strings.NewReader("<main></main>")),
+	})
+	do := mock.MockDo
+
+	mock.MockDo = func(req *http.Request) (*http.Response, error) {
+		got = req
+
+		return do(req)
+	}
+
+	cli := input.NewStatsFetcher(mock, time.Second*5)
+
+	body, err := cli.FetchStats(context.Background(), "2021", "123")
+	require.NoError(t, err)
+
+	assert.Equal(t, "<main></main>", string(body))
+	assert.Equal(t, "/2021/leaderboard/self", got.URL.Path)
+
+	cookie, err := got.Cookie("session")
+	require.NoError(t, err)
+	assert.Equal(t, "123", cookie.Value)
+}
@@ -0,0 +1,82 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrNotYetUnlocked returns when puzzle is requested before its unlock time.
+// Use errors.As with *NotYetUnlockedError to get the remaining time.
+var ErrNotYetUnlocked = errors.New("puzzle not yet unlocked")
+
+// NotYetUnlockedError holds details of the request made before puzzle unlock.
+type NotYetUnlockedError struct {
+	Date      Date
+	UnlockAt  time.Time
+	Remaining time.Duration
+}
+
+func (e *NotYetUnlockedError) Error() string {
+	return fmt.Sprintf("[%s]: %s, unlocks in %s", e.Date, ErrNotYetUnlocked, e.Remaining.Round(time.Second))
+}
+
+// Unwrap allows to match error with ErrNotYetUnlocked.
+func (e *NotYetUnlockedError) Unwrap() error {
+	return ErrNotYetUnlocked
+}
+
+// eastern is a US Eastern time zone in December, when puzzles are unlocked.
+var eastern = time.FixedZone("EST", -5*60*60)
+
+// UnlockTime returns the time when puzzle is unlocked: midnight US Eastern time of the puzzle day in December.
+// When day is empty, unlock time of the whole event is returned.
+func UnlockTime(d Date) (time.Time, error) {
+	year, err := strconv.Atoi(d.Year)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid year %q: %w", d.Year, err)
+	}
+
+	day := 1
+
+	if d.Day != "" {
+		day, err = strconv.Atoi(d.Day)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid day %q: %w", d.Day, err)
+		}
+	}
+
+	return time.Date(year, time.December, day, 0, 0, 0, 0, eastern), nil
+}
+
+// checkUnlocked returns *NotYetUnlockedError when puzzle is not unlocked at passed time.
+// Dates that could not be parsed are left for the server to decide.
+func checkUnlocked(d Date, now time.Time) error {
+	unlock, err := UnlockTime(d)
+	if err != nil {
+		return nil
+	}
+
+	if !now.Before(unlock) {
+		return nil
+	}
+
+	return &NotYetUnlockedError{
+		Date:      d,
+		UnlockAt:  unlock,
+		Remaining: unlock.Sub(now),
+	}
+}
+
+// LatestEvent returns year of the latest started event at passed time.
+func LatestEvent(now time.Time) string {
+	now = now.In(eastern)
+
+	year := now.Year()
+	if now.Month() < time.December {
+		year--
+	}
+
+	return strconv.Itoa(year)
+}
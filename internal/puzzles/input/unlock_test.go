@@ -0,0 +1,90 @@
+package input_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+func TestUnlockTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    input.Date
+		want    time.Time
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "day",
+			date:    input.Date{Year: "2023", Day: "5"},
+			want:    time.Date(2023, time.December, 5, 5, 0, 0, 0, time.UTC),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "event",
+			date:    input.Date{Year: "2023", Day: ""},
+			want:    time.Date(2023, time.December, 1, 5, 0, 0, 0, time.UTC),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid year",
+			date:    input.Date{Year: "year", Day: "5"},
+			want:    time.Time{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "invalid day",
+			date:    input.Date{Year: "2023", Day: "day"},
+			want:    time.Time{},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := input.UnlockTime(tt.date)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestFetch_notYetUnlocked(t *testing.T) {
+	var called bool
+
+	cli := input.NewFetcher(&mockHTTPClient{
+		MockDo: func(_ *http.Request) (*http.Response, error) {
+			called = true
+
+			return nil, assert.AnError
+		},
+	}, time.Second)
+
+	d := input.Date{Year: "2999", Day: "1"}
+
+	_, err := cli.Fetch(context.Background(), d, "123")
+	require.ErrorIs(t, err, input.ErrNotYetUnlocked)
+
+	var unlockErr *input.NotYetUnlockedError
+
+	require.ErrorAs(t, err, &unlockErr)
+
+	assert.Equal(t, d, unlockErr.Date)
+	assert.Positive(t, unlockErr.Remaining)
+	assert.False(t, called, "request should not be sent before unlock")
+}
+
+func TestLatestEvent(t *testing.T) {
+	assert.Equal(t, "2022", input.LatestEvent(time.Date(2023, time.November, 30, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2022", input.LatestEvent(time.Date(2023, time.December, 1, 4, 59, 0, 0, time.UTC)))
+	assert.Equal(t, "2023", input.LatestEvent(time.Date(2023, time.December, 1, 5, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2023", input.LatestEvent(time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)))
+}
@@ -51,6 +51,50 @@ func (f *metricsFlag) AddFlag(flag metricsFlag)     { *f |= flag }
 func (f *metricsFlag) ClearFlag(flag metricsFlag)   { *f &= ^flag }
 func (f *metricsFlag) ToggleFlag(flag metricsFlag)  { *f ^= flag }
 
+// Parts of the puzzle as reported in PartMetric.
+const (
+	PartOne = "1"
+	PartTwo = "2"
+)
+
+// PartMetric is a measurement of solving one part of the puzzle, see WithObserver.
+type PartMetric struct {
+	Year string
+	Day  string
+	Part string
+	// Answer is an answer of the part, empty on error.
+	Answer string
+	// Elapsed is a time spent by solver of the part.
+	Elapsed time.Duration
+	// Err is an error returned by solver, ErrNotImplemented when part is not solved yet.
+	Err error
+}
+
+// Observer receives measurement of each part as soon as it is solved.
+type Observer func(m PartMetric)
+
+// observe runs part solver and passes its measurement to observer when it is set.
+func observe(o Observer, s Solver, part string, solve func() (string, error)) (string, error) {
+	if o == nil {
+		return solve()
+	}
+
+	start := time.Now()
+
+	answer, err := solve()
+
+	o(PartMetric{
+		Year:    s.Year(),
+		Day:     s.Day(),
+		Part:    part,
+		Answer:  answer,
+		Elapsed: time.Since(start),
+		Err:     err,
+	})
+
+	return answer, err
+}
+
 type metrics []*metric
 
 func (m metrics) String() string {
@@ -82,6 +126,10 @@ func (m metrics) String() string {
 type metric struct {
 	mType    metricsFlag
 	metadata string
+	// elapsedTime is a value of elapsed metric.
+	elapsedTime time.Duration
+	// benchResult is a value of benchmark metric.
+	benchResult testing.BenchmarkResult
 }
 
 func (m *metric) String() string {
@@ -103,7 +151,8 @@ func (m *metric) elapsed() func() {
 	m.metadata = inProgress
 
 	return func() {
-		m.metadata = timeElapsed(start)
+		m.elapsedTime = time.Since(start)
+		m.metadata = m.elapsedTime.String()
 	}
 }
 
@@ -114,18 +163,13 @@ func (m *metric) bench(f benchFunc) func() {
 	m.metadata = inProgress
 
 	return func() {
-		m.metadata = bench(f)
+		m.benchResult = bench(f)
+		m.metadata = formatBench(m.benchResult)
 	}
 }
 
-func timeElapsed(start time.Time) string {
-	elapsed := time.Since(start)
-
-	return elapsed.String()
-}
-
-func bench(f benchFunc) string {
-	b := testing.Benchmark(func(b *testing.B) {
+func bench(f benchFunc) testing.BenchmarkResult {
+	return testing.Benchmark(func(b *testing.B) {
 		b.ResetTimer()
 
 		for i := 0; i < b.N; i++ {
@@ -134,9 +178,9 @@ func bench(f benchFunc) string {
 			}
 		}
 	})
+}
 
-	result := fmt.Sprintf("(N=%d, %d ns/op, %d bytes/op, %d allocs/op)",
+func formatBench(b testing.BenchmarkResult) string {
+	return fmt.Sprintf("(N=%d, %d ns/op, %d bytes/op, %d allocs/op)",
 		b.N, b.NsPerOp(), b.AllocedBytesPerOp(), b.AllocsPerOp())
-
-	return result
 }
@@ -0,0 +1,72 @@
+package puzzles
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// probeTimeout is a time given to solver to report that part is not implemented.
+const probeTimeout = time.Second
+
+// Parts reports which parts of the puzzle are implemented.
+type Parts struct {
+	Year  string
+	Day   string
+	Part1 bool
+	Part2 bool
+}
+
+// Probe reports implemented parts of solvers, in the same order as solvers passed.
+//
+// Part is implemented unless its solver returns ErrNotImplemented for empty input. Solvers that panic on
+// empty input or do not return within a second are considered implemented.
+func Probe(solvers []Solver) []Parts {
+	res := make([]Parts, len(solvers))
+
+	var wg sync.WaitGroup
+
+	for i, s := range solvers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			res[i] = Parts{
+				Year:  s.Year(),
+				Day:   s.Day(),
+				Part1: implemented(s.Part1),
+				Part2: implemented(s.Part2),
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return res
+}
+
+func implemented(part func(in io.Reader) (string, error)) bool {
+	done := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- nil
+			}
+		}()
+
+		_, err := part(strings.NewReader(""))
+
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return !errors.Is(err, ErrNotImplemented)
+	case <-time.After(probeTimeout):
+		return true
+	}
+}
@@ -1,10 +1,13 @@
 package puzzles
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
+	"testing"
 	"text/tabwriter"
+	"time"
 )
 
 // Result represents puzzle solution result.
@@ -84,6 +87,92 @@ func (r Result) String() string {
 	return content
 }
 
+// Elapsed returns value of elapsed metric, when it is enabled by WithElapsed.
+func (r Result) Elapsed() (time.Duration, bool) {
+	for _, m := range r.metrics {
+		if m != nil && m.mType.HasFlag(metricsFlagElapsed) {
+			return m.elapsedTime, true
+		}
+	}
+
+	return 0, false
+}
+
+// Benchmark returns value of benchmark metric, when it is enabled by WithBenchmark.
+func (r Result) Benchmark() (testing.BenchmarkResult, bool) {
+	for _, m := range r.metrics {
+		if m != nil && m.mType.HasFlag(metricsFlagBenchmark) {
+			return m.benchResult, true
+		}
+	}
+
+	return testing.BenchmarkResult{}, false
+}
+
+// resultView is a representation of Result in JSON and YAML.
+type resultView struct {
+	Year    string       `json:"year" yaml:"year"`
+	Day     string       `json:"day" yaml:"day"`
+	Part1   string       `json:"part1" yaml:"part1"`
+	Part2   string       `json:"part2" yaml:"part2"`
+	Metrics *metricsView `json:"metrics,omitempty" yaml:"metrics,omitempty"`
+}
+
+type metricsView struct {
+	ElapsedNS *int64         `json:"elapsed_ns,omitempty" yaml:"elapsed_ns,omitempty"`
+	Benchmark *benchmarkView `json:"benchmark,omitempty" yaml:"benchmark,omitempty"`
+}
+
+type benchmarkView struct {
+	N           int   `json:"n" yaml:"n"`
+	NsPerOp     int64 `json:"ns_per_op" yaml:"ns_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op" yaml:"bytes_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op" yaml:"allocs_per_op"`
+}
+
+func (r Result) view() resultView {
+	v := resultView{
+		Year:    r.Year,
+		Day:     r.Name,
+		Part1:   r.Part1,
+		Part2:   r.Part2,
+		Metrics: nil,
+	}
+
+	var mv metricsView
+
+	if d, ok := r.Elapsed(); ok {
+		ns := d.Nanoseconds()
+
+		mv.ElapsedNS = &ns
+	}
+
+	if b, ok := r.Benchmark(); ok {
+		mv.Benchmark = &benchmarkView{
+			N:           b.N,
+			NsPerOp:     b.NsPerOp(),
+			BytesPerOp:  b.AllocedBytesPerOp(),
+			AllocsPerOp: b.AllocsPerOp(),
+		}
+	}
+
+	if mv.ElapsedNS != nil || mv.Benchmark != nil {
+		v.Metrics = &mv
+	}
+
+	return v
+}
+
+// MarshalJSON implements json.Marshaler. Metrics are included when enabled by run options.
+func (r Result) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.view())
+}
+
+// MarshalYAML implements yaml.Marshaler in the same shape as MarshalJSON.
+func (r Result) MarshalYAML() (any, error) {
+	return r.view(), nil
+}
+
 func printTable(w io.Writer, table [][]string) error {
 	const padding = 3
 
@@ -1,10 +1,14 @@
 package puzzles
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestResult_String(t *testing.T) {
@@ -162,3 +166,58 @@ type fields struct {
 		})
 	}
 }
+
+func TestResult_MarshalJSON(t *testing.T) {
+	res := Result{
+		Year:  "2021",
+		Name:  "5",
+		Part1: "5306",
+		Part2: "17787",
+		metrics: metrics{
+			{
+				mType:       metricsFlagElapsed,
+				metadata:    "1.5ms",
+				elapsedTime: 1500 * time.Microsecond,
+				benchResult: testing.BenchmarkResult{},
+			},
+			{
+				mType:       metricsFlagBenchmark,
+				metadata:    "",
+				elapsedTime: 0,
+				benchResult: testing.BenchmarkResult{
+					N:         10,
+					T:         time.Millisecond,
+					Bytes:     0,
+					MemAllocs: 20,
+					MemBytes:  400,
+					Extra:     nil,
+				},
+			},
+		},
+	}
+
+	got, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"year": "2021",
+		"day": "5",
+		"part1": "5306",
+		"part2": "17787",
+		"metrics": {
+			"elapsed_ns": 1500000,
+			"benchmark": {"n": 10, "ns_per_op": 100000, "bytes_per_op": 40, "allocs_per_op": 2}
+		}
+	}`, string(got))
+
+	got, err = json.Marshal(Result{Year: "2021", Name: "5", Part1: "1", Part2: "2", metrics: nil})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"year": "2021", "day": "5", "part1": "1", "part2": "2"}`, string(got))
+
+	gotYAML, err := yaml.Marshal(res)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(gotYAML), "day: \"5\"\n")
+	assert.Contains(t, string(gotYAML), "  elapsed_ns: 1500000\n")
+}
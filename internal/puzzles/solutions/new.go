@@ -1,20 +1,41 @@
 package solutions
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"text/template"
 
+	log "github.com/obalunenko/logger"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
 	"github.com/obalunenko/advent-of-code/internal/puzzles/solutions/templates"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/spec"
 )
 
-func createNewFromTemplate(purl string) error {
+// CreateNew creates puzzle package with solution, tests and spec under root directory from templates.
+// Templates found in tmplDir override the embedded ones, see templates.Load.
+// Puzzle description is fetched to fill the spec and examples; placeholders are used when it is not available.
+// Existing files are kept untouched.
+func CreateNew(ctx context.Context, root, tmplDir, year, day, session string, opts ...input.Option) error {
+	pd, err := makePuzzleDate(year, day)
+	if err != nil {
+		return err
+	}
+
+	f := input.NewPuzzleFetcher(http.DefaultClient, fetchTimeout, opts...)
+
+	return createNewFromTemplate(ctx, f, root, tmplDir, pd.url(), session)
+}
+
+// createNewFromTemplate creates puzzle package with solution, tests and spec under root directory.
+// Templates found in tmplDir override the embedded ones.
+func createNewFromTemplate(ctx context.Context, f input.PuzzleFetcher, root, tmplDir, purl, session string) error {
 	const (
-		perms   = os.ModePerm
-		yearLen = 4
-		dayLen  = 2
+		perms = os.ModePerm
 	)
 
 	pd, err := parsePuzzleURL(purl)
@@ -22,32 +43,21 @@ func createNewFromTemplate(purl string) error {
 		return fmt.Errorf("parse puzzle url %q: %w", purl, err)
 	}
 
-	day := strconv.Itoa(pd.day)
-	if len(day) < dayLen {
-		day = "0" + day
-	}
-
-	if len(day) != dayLen {
-		return fmt.Errorf("invalid day: %s", day)
+	params, err := makeParams(pd, purl)
+	if err != nil {
+		return fmt.Errorf("make template params: %w", err)
 	}
 
-	year := strconv.Itoa(pd.year)
+	s, err := fetchSpec(ctx, f, pd, session)
+	if err != nil {
+		log.WithError(ctx, err).Warn("Failed to fetch puzzle description, placeholders will be used")
 
-	if len(year) != yearLen {
-		return fmt.Errorf("invalid year: %s", year)
+		s = spec.Spec{}
 	}
 
-	params := templates.Params{
-		Year:               year,
-		Day:                pd.day,
-		DayStr:             day,
-		URL:                purl,
-		Title:              "<!--- Pass here the title --->",
-		DescriptionPartOne: "<!--- Pass here the description for part one --->",
-		DescriptionPartTwo: "<!--- Pass here the description for part two --->",
-	}
+	params = withSpec(params, s)
 
-	path := filepath.Clean(filepath.Join(year, "day"+day))
+	path := puzzleDir(root, params)
 
 	if err = createPuzzleDir(path, perms); err != nil {
 		return fmt.Errorf("failed to create puzzle dir: %w", err)
@@ -59,14 +69,14 @@ func createNewFromTemplate(purl string) error {
 		return fmt.Errorf("failed to create testdata: %w", err)
 	}
 
-	tmplsFns := []func() (*template.Template, error){
-		templates.SolutionTmpl, templates.SolutionTestTmpl, templates.SpecTmpl,
+	if err = createExamples(testdata, s.Examples, perms); err != nil {
+		return fmt.Errorf("failed to create examples: %w", err)
 	}
 
-	for _, tmplFn := range tmplsFns {
+	for _, file := range templates.Files() {
 		var tmpl *template.Template
 
-		tmpl, err = tmplFn()
+		tmpl, err = templates.Load(tmplDir, file)
 		if err != nil {
 			return fmt.Errorf("failed to get template: %w", err)
 		}
@@ -79,6 +89,48 @@ func createNewFromTemplate(purl string) error {
 	return nil
 }
 
+func makeParams(pd puzzleDate, purl string) (templates.Params, error) {
+	const (
+		yearLen = 4
+		dayLen  = 2
+	)
+
+	day := strconv.Itoa(pd.day)
+	if len(day) < dayLen {
+		day = "0" + day
+	}
+
+	if len(day) != dayLen {
+		return templates.Params{}, fmt.Errorf("invalid day: %s", day)
+	}
+
+	year := strconv.Itoa(pd.year)
+
+	if len(year) != yearLen {
+		return templates.Params{}, fmt.Errorf("invalid year: %s", year)
+	}
+
+	return templates.Params{
+		Year:               year,
+		Day:                pd.day,
+		DayStr:             day,
+		URL:                purl,
+		Title:              "<!--- Pass here the title --->",
+		DescriptionPartOne: "<!--- Pass here the description for part one --->",
+		DescriptionPartTwo: "<!--- Pass here the description for part two --->",
+		ExamplePartOne:     inputFile,
+		ExamplePartTwo:     inputFile,
+		AnswerPartOne:      "",
+		AnswerPartTwo:      "",
+		Examples:           nil,
+	}, nil
+}
+
+// puzzleDir returns path of puzzle package directory under root.
+func puzzleDir(root string, params templates.Params) string {
+	return filepath.Clean(filepath.Join(root, params.Year, "day"+params.DayStr))
+}
+
 func createFromTemplate(tmpl *template.Template, path string, perms os.FileMode, params templates.Params) error {
 	fpath := filepath.Clean(filepath.Join(path, tmpl.Name()))
 
@@ -117,7 +169,7 @@ func createTestdata(path string, perms os.FileMode) error {
 		}
 	}
 
-	input := filepath.Clean(filepath.Join(path, "input.txt"))
+	input := filepath.Clean(filepath.Join(path, inputFile))
 
 	if !isExist(input) {
 		var f *os.File
@@ -135,6 +187,30 @@ func createTestdata(path string, perms os.FileMode) error {
 	return nil
 }
 
+// createExamples writes examples from description to testdata. Existing files are kept untouched.
+func createExamples(path string, examples []spec.Example, perms os.FileMode) error {
+	for i, e := range examples {
+		fpath := filepath.Clean(filepath.Join(path, exampleFile(i)))
+
+		if isExist(fpath) {
+			continue
+		}
+
+		if err := os.WriteFile(fpath, []byte(e.Input), perms); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+const inputFile = "input.txt"
+
+// exampleFile returns name of testdata file for example with passed index.
+func exampleFile(idx int) string {
+	return fmt.Sprintf("example_%d.txt", idx+1)
+}
+
 func isExist(path string) bool {
 	stat, err := os.Stat(path)
 	if err != nil && !os.IsNotExist(err) {
@@ -171,3 +247,16 @@ func parsePuzzleURL(url string) (puzzleDate, error) {
 		day:  day,
 	}, nil
 }
+
+func (pd puzzleDate) date() input.Date {
+	return input.Date{
+		Year: strconv.Itoa(pd.year),
+		Day:  strconv.Itoa(pd.day),
+	}
+}
+
+func (pd puzzleDate) url() string {
+	const urlFmt = "https://adventofcode.com/%d/day/%d"
+
+	return fmt.Sprintf(urlFmt, pd.year, pd.day)
+}
@@ -1,29 +1,11 @@
 package solutions
 
 import (
-	"errors"
 	"testing"
 
-	"github.com/obalunenko/getenv"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 )
 
-func Test_createNewFromTemplate(t *testing.T) {
-	const envName = "AOC_PUZZLE_URL"
-
-	purl, err := getenv.Env[string](envName)
-	if err != nil {
-		if errors.Is(err, getenv.ErrNotSet) {
-			t.Skipf("%s is not set", envName)
-		}
-
-		t.Fatalf("failed to get environment variable[%s]: %v", envName, err)
-	}
-
-	require.NoError(t, createNewFromTemplate(purl))
-}
-
 func Test_parsePuzzleURL(t *testing.T) {
 	type args struct {
 		url string
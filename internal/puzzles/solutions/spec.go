@@ -0,0 +1,197 @@
+package solutions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/solutions/templates"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/spec"
+)
+
+const fetchTimeout = time.Second * 30
+
+// FetchSpec fetches the puzzle description and renders it as spec.md content.
+// Template found in tmplDir overrides the embedded one.
+// Returned flag reports whether description of part two is available.
+func FetchSpec(ctx context.Context, tmplDir, year, day, session string, opts ...input.Option) ([]byte, bool, error) {
+	f := input.NewPuzzleFetcher(http.DefaultClient, fetchTimeout, opts...)
+
+	content, s, err := renderSpec(ctx, f, tmplDir, year, day, session)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return content, s.HasPartTwo(), nil
+}
+
+// RefreshSpec fetches the puzzle description and rewrites spec.md of the puzzle package located under root.
+// Template found in tmplDir overrides the embedded one.
+// Returned flag reports whether description of part two is available.
+func RefreshSpec(ctx context.Context, root, tmplDir, year, day, session string, opts ...input.Option) (bool, error) {
+	f := input.NewPuzzleFetcher(http.DefaultClient, fetchTimeout, opts...)
+
+	return refreshSpec(ctx, f, root, tmplDir, year, day, session)
+}
+
+func refreshSpec(ctx context.Context, f input.PuzzleFetcher, root, tmplDir, year, day, session string) (bool, error) {
+	content, s, err := renderSpec(ctx, f, tmplDir, year, day, session)
+	if err != nil {
+		return false, err
+	}
+
+	pd, err := makePuzzleDate(year, day)
+	if err != nil {
+		return false, err
+	}
+
+	params, err := makeParams(pd, pd.url())
+	if err != nil {
+		return false, fmt.Errorf("make template params: %w", err)
+	}
+
+	path := puzzleDir(root, params)
+
+	if !isExist(path) {
+		return false, fmt.Errorf("puzzle directory %q not exist", path)
+	}
+
+	fpath := filepath.Join(path, templates.SpecFile)
+
+	if err = os.WriteFile(fpath, content, os.ModePerm); err != nil {
+		return false, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	testdata := filepath.Join(path, "testdata")
+
+	if err = createPuzzleDir(testdata, os.ModePerm); err != nil {
+		return false, fmt.Errorf("failed to create testdata: %w", err)
+	}
+
+	if err = createExamples(testdata, s.Examples, os.ModePerm); err != nil {
+		return false, fmt.Errorf("failed to create examples: %w", err)
+	}
+
+	return s.HasPartTwo(), nil
+}
+
+func renderSpec(
+	ctx context.Context,
+	f input.PuzzleFetcher,
+	tmplDir, year, day, session string,
+) ([]byte, spec.Spec, error) {
+	pd, err := makePuzzleDate(year, day)
+	if err != nil {
+		return nil, spec.Spec{}, err
+	}
+
+	s, err := fetchSpec(ctx, f, pd, session)
+	if err != nil {
+		return nil, spec.Spec{}, err
+	}
+
+	params, err := makeParams(pd, pd.url())
+	if err != nil {
+		return nil, spec.Spec{}, fmt.Errorf("make template params: %w", err)
+	}
+
+	tmpl, err := templates.Load(tmplDir, templates.SpecFile)
+	if err != nil {
+		return nil, spec.Spec{}, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	content, err := templates.SubstituteTemplate(tmpl, withSpec(params, s))
+	if err != nil {
+		return nil, spec.Spec{}, fmt.Errorf("failed to substitute template: %w", err)
+	}
+
+	return content, s, nil
+}
+
+func fetchSpec(ctx context.Context, f input.PuzzleFetcher, pd puzzleDate, session string) (spec.Spec, error) {
+	page, err := f.FetchPuzzle(ctx, pd.date(), session)
+	if err != nil {
+		return spec.Spec{}, fmt.Errorf("fetch puzzle page: %w", err)
+	}
+
+	s, err := spec.Parse(bytes.NewReader(page), pd.url())
+	if err != nil {
+		return spec.Spec{}, fmt.Errorf("parse puzzle page: %w", err)
+	}
+
+	return s, nil
+}
+
+// withSpec fills params with fetched description and examples.
+// Placeholders are kept for the parts that are not available.
+func withSpec(params templates.Params, s spec.Spec) templates.Params {
+	if s.Title != "" {
+		params.Title = s.Title
+	}
+
+	if s.PartOne != "" {
+		params.DescriptionPartOne = s.PartOne
+	}
+
+	if s.HasPartTwo() {
+		params.DescriptionPartTwo = s.PartTwo
+	}
+
+	if i := s.FirstExample(1); i >= 0 {
+		params.ExamplePartOne = exampleFile(i)
+	}
+
+	const partTwo = 2
+
+	if i := s.FirstExample(partTwo); i >= 0 {
+		params.ExamplePartTwo = exampleFile(i)
+	}
+
+	params.AnswerPartOne = s.AnswerPartOne
+	params.AnswerPartTwo = s.AnswerPartTwo
+
+	params.Examples = make([]templates.Example, 0, len(s.Examples))
+
+	for i, e := range s.Examples {
+		var want string
+
+		switch {
+		case e.Part == 1 && i == s.FirstExample(1):
+			want = s.AnswerPartOne
+		case e.Part == partTwo && i == s.FirstExample(partTwo):
+			want = s.AnswerPartTwo
+		}
+
+		params.Examples = append(params.Examples, templates.Example{
+			Part:  e.Part,
+			File:  exampleFile(i),
+			Input: e.Input,
+			Want:  want,
+		})
+	}
+
+	return params
+}
+
+func makePuzzleDate(year, day string) (puzzleDate, error) {
+	y, err := strconv.Atoi(year)
+	if err != nil {
+		return puzzleDate{}, fmt.Errorf("invalid year %q: %w", year, err)
+	}
+
+	d, err := strconv.Atoi(day)
+	if err != nil {
+		return puzzleDate{}, fmt.Errorf("invalid day %q: %w", day, err)
+	}
+
+	return puzzleDate{
+		year: y,
+		day:  d,
+	}, nil
+}
@@ -0,0 +1,175 @@
+package solutions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+type puzzleFetcherFunc func(ctx context.Context, d input.Date, session string) ([]byte, error)
+
+func (f puzzleFetcherFunc) FetchPuzzle(ctx context.Context, d input.Date, session string) ([]byte, error) {
+	return f(ctx, d, session)
+}
+
+func staticPage(page string) input.PuzzleFetcher {
+	return puzzleFetcherFunc(func(_ context.Context, _ input.Date, _ string) ([]byte, error) {
+		return []byte(page), nil
+	})
+}
+
+func Test_refreshSpec(t *testing.T) {
+	const (
+		partOne = `<article class="day-desc"><h2>--- Day 2: Cube Conundrum ---</h2><p>Part <em>one</em>.</p></article>`
+		partTwo = `<article class="day-desc"><h2>--- Part Two ---</h2><p>Part <code>two</code>.</p></article>`
+	)
+
+	root := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "2023", "day02"), os.ModePerm))
+
+	unlocked, err := refreshSpec(context.Background(), staticPage(partOne), root, "", "2023", "2", "")
+	require.NoError(t, err)
+	assert.False(t, unlocked)
+
+	got, err := os.ReadFile(filepath.Join(root, "2023", "day02", "spec.md"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(got), "# Puzzle https://adventofcode.com/2023/day/2")
+	assert.Contains(t, string(got), "# --- Day 2: Cube Conundrum ---")
+	assert.Contains(t, string(got), "Part **one**.")
+	assert.Contains(t, string(got), "<!--- Pass here the description for part two --->")
+
+	unlocked, err = refreshSpec(context.Background(), staticPage(partOne+partTwo), root, "", "2023", "2", "")
+	require.NoError(t, err)
+	assert.True(t, unlocked)
+
+	got, err = os.ReadFile(filepath.Join(root, "2023", "day02", "spec.md"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(got), "Part `two`.")
+	assert.NotContains(t, string(got), "Pass here the description")
+
+	_, err = refreshSpec(context.Background(), staticPage(partOne), root, "", "2023", "3", "")
+	assert.Error(t, err, "puzzle is not scaffolded")
+
+	_, err = refreshSpec(context.Background(), staticPage(partOne), root, "", "year", "3", "")
+	assert.Error(t, err)
+}
+
+func Test_createNewFromTemplate_examples(t *testing.T) {
+	const page = `<article class="day-desc"><h2>--- Day 1: Trebuchet?! ---</h2>` +
+		"<pre><code>1abc2\n</code></pre><p>Total is <em><code>142</code></em>.</p></article>" +
+		`<article class="day-desc"><h2>--- Part Two ---</h2>` +
+		"<pre><code>two1nine\n</code></pre><p>Total is <code><em>281</em></code>.</p></article>"
+
+	root := t.TempDir()
+
+	err := createNewFromTemplate(context.Background(), staticPage(page), root, "", "https://adventofcode.com/2023/day/1", "")
+	require.NoError(t, err)
+
+	dir := filepath.Join(root, "2023", "day01")
+
+	for file, want := range map[string]string{
+		"example_1.txt": "1abc2\n",
+		"example_2.txt": "two1nine\n",
+		"input.txt":     "",
+	} {
+		got, rerr := os.ReadFile(filepath.Join(dir, "testdata", file))
+		require.NoError(t, rerr)
+
+		assert.Equal(t, want, string(got), file)
+	}
+
+	test, err := os.ReadFile(filepath.Join(dir, "solution_test.go"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(test), `filepath.Join("testdata", "example_1.txt")`)
+	assert.Contains(t, string(test), `filepath.Join("testdata", "example_2.txt")`)
+	assert.Contains(t, string(test), `want:    "142"`)
+	assert.Contains(t, string(test), `want:    "281"`)
+}
+
+func Test_createNewFromTemplate_userTemplates(t *testing.T) {
+	const page = `<article class="day-desc"><h2>--- Day 1: Trebuchet?! ---</h2>` +
+		"<pre><code>1abc2\n</code></pre><p>Total is <em><code>142</code></em>.</p>" +
+		"<pre><code>pqr3stu8vwx\n</code></pre></article>"
+
+	root := t.TempDir()
+	tmplDir := t.TempDir()
+
+	const tmpl = `package day{{ .DayStr }}
+
+// {{ .Title }}
+{{ range .Examples }}// part {{ .Part }}: {{ .File }} want {{ printf "%q" .Want }}
+{{ end }}`
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmplDir, "solution.go.tmpl"), []byte(tmpl), os.ModePerm))
+
+	err := createNewFromTemplate(context.Background(), staticPage(page), root, tmplDir,
+		"https://adventofcode.com/2023/day/1", "")
+	require.NoError(t, err)
+
+	dir := filepath.Join(root, "2023", "day01")
+
+	got, err := os.ReadFile(filepath.Join(dir, "solution.go"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `package day01
+
+// Trebuchet?!
+// part 1: example_1.txt want "142"
+// part 1: example_2.txt want ""
+`, string(got))
+
+	test, err := os.ReadFile(filepath.Join(dir, "solution_test.go"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(test), "func Test_solution_Part1(t *testing.T) {", "embedded template is used")
+}
+
+func TestTitle(t *testing.T) {
+	tests := []struct {
+		name string
+		year string
+		day  string
+		want string
+	}{
+		{
+			name: "heading with dashes",
+			year: "2015",
+			day:  "1",
+			want: "Not Quite Lisp",
+		},
+		{
+			name: "heading without dashes",
+			year: "2019",
+			day:  "2",
+			want: "1202 Program Alarm",
+		},
+		{
+			name: "no spec",
+			year: "2015",
+			day:  "25",
+			want: "",
+		},
+		{
+			name: "invalid day",
+			year: "2015",
+			day:  "day01",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Title(tt.year, tt.day))
+		})
+	}
+}
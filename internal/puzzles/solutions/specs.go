@@ -0,0 +1,62 @@
+package solutions
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// specs are spec.md files of solved puzzles, so descriptions are available offline.
+//
+//go:embed */day*/spec.md
+var specs embed.FS
+
+// specTitleRe matches heading of spec.md, with or without dashes of the original description:
+// "# --- Day 5: Hydrothermal Venture ---" or "# Day 2: 1202 Program Alarm".
+var specTitleRe = regexp.MustCompile(`^#\s+(?:---\s*)?Day\s+\d+:\s*(.*?)\s*(?:---)?$`)
+
+// Spec returns content of spec.md of the puzzle embedded at build time.
+func Spec(year, day string) ([]byte, error) {
+	d, err := strconv.Atoi(day)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day %q: %w", day, err)
+	}
+
+	content, err := specs.ReadFile(path.Join(year, fmt.Sprintf("day%02d", d), "spec.md"))
+	if err != nil {
+		return nil, fmt.Errorf("read spec of %s/%s: %w", year, day, err)
+	}
+
+	return content, nil
+}
+
+// Title returns title of the puzzle from heading of its spec.md, empty when spec or title is missing.
+func Title(year, day string) string {
+	content, err := Spec(year, day)
+	if err != nil {
+		return ""
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(content))
+
+	for sc.Scan() {
+		m := specTitleRe.FindStringSubmatch(strings.TrimSpace(sc.Text()))
+		if len(m) != 2 {
+			continue
+		}
+
+		// Title placeholder of the template is left as is when description was not fetched.
+		if strings.HasPrefix(m[1], "<!--") {
+			return ""
+		}
+
+		return m[1]
+	}
+
+	return ""
+}
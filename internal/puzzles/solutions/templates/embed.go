@@ -1,61 +1,173 @@
 // Package templates contains templates for solution.go, solution_test.go and spec.md files.
+//
+// Embedded templates could be overridden by the user: template <file>.tmpl found in the templates directory
+// (see DefaultDir) is used instead of the embedded one. Use Export to get the embedded templates for editing.
 package templates
 
 import (
 	"bytes"
-	_ "embed"
+	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"text/template"
 )
 
-var (
-	//go:embed solution.go.tmpl
-	solutionTmpl string
-	//go:embed solution_test.go.tmpl
-	solutionTestTmpl string
-	//go:embed spec.md.tmpl
-	specTmpl string
+const (
+	// SolutionFile is a name of solution file.
+	SolutionFile = "solution.go"
+	// SolutionTestFile is a name of solution tests file.
+	SolutionTestFile = "solution_test.go"
+	// SpecFile is a name of puzzle description file.
+	SpecFile = "spec.md"
+
+	ext = ".tmpl"
 )
 
+// ErrUnknownTemplate returns when there is no template for passed file.
+var ErrUnknownTemplate = errors.New("unknown template")
+
+//go:embed *.tmpl
+var embedded embed.FS
+
 // Params contains parameters for templates.
 type Params struct {
-	Year               string // e.g. "2023"
-	Day                int    // e.g. 2
-	DayStr             string // e.g. "02"
-	URL                string // e.g. "https://adventofcode.com/2023/day/2"
-	Title              string // For now it's empty.
-	DescriptionPartOne string // For now it's empty.
-	DescriptionPartTwo string // For now it's empty.
+	Year               string    // e.g. "2023"
+	Day                int       // e.g. 2
+	DayStr             string    // e.g. "02"
+	URL                string    // e.g. "https://adventofcode.com/2023/day/2"
+	Title              string    // e.g. "Cube Conundrum", placeholder when description is not fetched.
+	DescriptionPartOne string    // Markdown description of part one, placeholder when not fetched.
+	DescriptionPartTwo string    // Markdown description of part two, placeholder until part two is unlocked.
+	ExamplePartOne     string    // e.g. "example_1.txt", "input.txt" when description has no examples.
+	ExamplePartTwo     string    // e.g. "example_2.txt", "input.txt" when description has no examples.
+	AnswerPartOne      string    // Guessed answer for the example of part one, empty when not guessed.
+	AnswerPartTwo      string    // Guessed answer for the example of part two, empty when not guessed.
+	Examples           []Example // All examples from description, empty when description is not fetched.
+}
+
+// Example is an example from puzzle description saved to testdata.
+type Example struct {
+	Part  int    // Part where example is given: 1 or 2.
+	File  string // e.g. "example_1.txt".
+	Input string // Content of the example.
+	// Want is an expected answer for the example, empty when not guessed.
+	// Only the answer of the first example of the part is guessed.
+	Want string
+}
+
+// Files returns names of files created from templates, in order of creation.
+func Files() []string {
+	return []string{SolutionFile, SolutionTestFile, SpecFile}
 }
 
-// SolutionTmpl returns template for solution.go file.
-func SolutionTmpl() (*template.Template, error) {
-	tmpl, err := template.New("solution.go").Parse(solutionTmpl)
+// DefaultDir returns default directory of user templates: $XDG_CONFIG_HOME/aoc-cli/templates.
+func DefaultDir() (string, error) {
+	dir, err := os.UserConfigDir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse solution template: %w", err)
+		return "", fmt.Errorf("get user config dir: %w", err)
 	}
 
-	return tmpl, nil
+	return filepath.Join(dir, "aoc-cli", "templates"), nil
 }
 
-// SolutionTestTmpl returns template for solution_test.go file.
-func SolutionTestTmpl() (*template.Template, error) {
-	tmpl, err := template.New("solution_test.go").Parse(solutionTestTmpl)
+// Load returns template for the file: <file>.tmpl from dir when it exists there, embedded template otherwise.
+// Empty dir means embedded template.
+func Load(dir, file string) (*template.Template, error) {
+	path, err := Path(dir, file)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse solution test template: %w", err)
+		return nil, err
+	}
+
+	var content []byte
+
+	if path == "" {
+		content, err = Default(file)
+	} else {
+		content, err = os.ReadFile(filepath.Clean(path))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s template: %w", file, err)
+	}
+
+	tmpl, err := template.New(file).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s template: %w", file, err)
 	}
 
 	return tmpl, nil
 }
 
-// SpecTmpl returns template for spec.md file.
-func SpecTmpl() (*template.Template, error) {
-	tmpl, err := template.New("spec.md").Parse(specTmpl)
+// Path returns path of user template for the file in dir, or empty string when embedded template is used.
+func Path(dir, file string) (string, error) {
+	if !isKnown(file) {
+		return "", fmt.Errorf("%w: %s", ErrUnknownTemplate, file)
+	}
+
+	if dir == "" {
+		return "", nil
+	}
+
+	path := filepath.Join(dir, file+ext)
+
+	_, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("failed to stat %s template: %w", file, err)
+	}
+
+	return path, nil
+}
+
+// Default returns content of embedded template for the file.
+func Default(file string) ([]byte, error) {
+	if !isKnown(file) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTemplate, file)
+	}
+
+	content, err := embedded.ReadFile(file + ext)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse spec template: %w", err)
+		return nil, fmt.Errorf("failed to read embedded %s template: %w", file, err)
 	}
 
-	return tmpl, nil
+	return content, nil
+}
+
+// Export writes embedded templates to dir as <file>.tmpl, so they could be edited.
+// Existing templates are kept unless overwrite is set. Returns paths of written templates.
+func Export(dir string, overwrite bool) ([]string, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create templates dir: %w", err)
+	}
+
+	var written []string
+
+	for _, file := range Files() {
+		path := filepath.Join(dir, file+ext)
+
+		if _, err := os.Stat(path); err == nil && !overwrite {
+			continue
+		}
+
+		content, err := Default(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = os.WriteFile(path, content, os.ModePerm); err != nil {
+			return nil, fmt.Errorf("failed to write %s template: %w", file, err)
+		}
+
+		written = append(written, path)
+	}
+
+	return written, nil
 }
 
 // SubstituteTemplate substitutes template with given parameters.
@@ -69,3 +181,13 @@ func SubstituteTemplate(tmpl *template.Template, p Params) ([]byte, error) {
 
 	return buf.Bytes(), nil
 }
+
+func isKnown(file string) bool {
+	for _, f := range Files() {
+		if f == file {
+			return true
+		}
+	}
+
+	return false
+}
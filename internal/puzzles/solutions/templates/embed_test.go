@@ -0,0 +1,140 @@
+package templates
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, SpecFile+ext), []byte("# {{ .Title }}\n"), os.ModePerm))
+
+	p := Params{Title: "Cube Conundrum"}
+
+	tests := []struct {
+		name    string
+		dir     string
+		file    string
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "user template",
+			dir:     dir,
+			file:    SpecFile,
+			want:    "# Cube Conundrum\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "embedded template when user one is absent",
+			dir:     dir,
+			file:    SolutionFile,
+			want:    "func (s solution) Part1(input io.Reader) (string, error) {",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "embedded template without dir",
+			dir:     "",
+			file:    SpecFile,
+			want:    "# --- Day 0: Cube Conundrum ---",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "unknown template",
+			dir:     dir,
+			file:    "main.go",
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := Load(tt.dir, tt.file)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			if err != nil {
+				assert.ErrorIs(t, err, ErrUnknownTemplate)
+
+				return
+			}
+
+			got, err := SubstituteTemplate(tmpl, p)
+			require.NoError(t, err)
+
+			assert.Contains(t, string(got), tt.want)
+			assert.Equal(t, tt.file, tmpl.Name())
+		})
+	}
+}
+
+func TestSubstituteTemplate_answersQuoted(t *testing.T) {
+	tmpl, err := Load("", SolutionTestFile)
+	require.NoError(t, err)
+
+	got, err := SubstituteTemplate(tmpl, Params{
+		Year:           "2023",
+		Day:            2,
+		DayStr:         "02",
+		ExamplePartOne: "example_1.txt",
+		ExamplePartTwo: "example_2.txt",
+		AnswerPartOne:  `say "hi"`,
+		AnswerPartTwo:  `a\b`,
+	})
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), SolutionTestFile, got, parser.AllErrors)
+	require.NoError(t, err, "rendered test file should be valid go source")
+
+	assert.Contains(t, string(got), `want:    "say \"hi\"",`)
+	assert.Contains(t, string(got), `want:    "a\\b",`)
+}
+
+func TestExport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "templates")
+
+	written, err := Export(dir, false)
+	require.NoError(t, err)
+	assert.Len(t, written, len(Files()))
+
+	for _, file := range Files() {
+		want, derr := Default(file)
+		require.NoError(t, derr)
+
+		got, rerr := os.ReadFile(filepath.Join(dir, file+ext))
+		require.NoError(t, rerr)
+
+		assert.Equal(t, string(want), string(got), file)
+	}
+
+	edited := filepath.Join(dir, SpecFile+ext)
+
+	require.NoError(t, os.WriteFile(edited, []byte("edited"), os.ModePerm))
+
+	written, err = Export(dir, false)
+	require.NoError(t, err)
+	assert.Empty(t, written, "existing templates are kept")
+
+	written, err = Export(dir, true)
+	require.NoError(t, err)
+	assert.Len(t, written, len(Files()))
+	assert.NotContains(t, readFile(t, edited), "edited")
+}
+
+func readFile(tb testing.TB, path string) string {
+	tb.Helper()
+
+	content, err := os.ReadFile(path)
+	require.NoError(tb, err)
+
+	return string(content)
+}
@@ -6,6 +6,7 @@
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"sort"
 	"sync"
 	"testing"
@@ -19,6 +20,21 @@ type Solver interface {
 	Year() string
 }
 
+// Tagger is implemented by solvers labeled with tags, e.g. "slow" or "grid", to run them selectively.
+type Tagger interface {
+	Tags() []string
+}
+
+// SolverTags returns tags of solver, or nil when solver does not implement Tagger.
+func SolverTags(s Solver) []string {
+	t, ok := s.(Tagger)
+	if !ok {
+		return nil
+	}
+
+	return t.Tags()
+}
+
 var (
 	solversMu sync.RWMutex
 	solvers   = make(map[string]map[string]Solver)
@@ -96,6 +112,36 @@ func GetYears() []string {
 	return list
 }
 
+// Solvers returns registered solvers ordered by year and day as DaysByYear does.
+// When years passed, only solvers of these years are returned.
+// When tags passed, only solvers having any of these tags are returned.
+func Solvers(years, tags []string) []Solver {
+	var list []Solver
+
+	for _, year := range GetYears() {
+		if len(years) != 0 && !slices.Contains(years, year) {
+			continue
+		}
+
+		for _, day := range DaysByYear(year) {
+			s, err := GetSolver(year, day)
+			if err != nil {
+				continue
+			}
+
+			if len(tags) != 0 && !slices.ContainsFunc(SolverTags(s), func(tag string) bool {
+				return slices.Contains(tags, tag)
+			}) {
+				continue
+			}
+
+			list = append(list, s)
+		}
+	}
+
+	return list
+}
+
 var (
 	// ErrYearMissed returns when year is empty.
 	ErrYearMissed = errors.New("empty puzzle year")
@@ -136,6 +182,13 @@ func GetSolver(year, day string) (Solver, error) {
 
 type runParams struct {
 	withMetrics metricsFlag
+	observer    Observer
+	parts       []string
+}
+
+// solves reports whether part should be solved.
+func (p runParams) solves(part string) bool {
+	return len(p.parts) == 0 || slices.Contains(p.parts, part)
 }
 
 // RunOption provides run options pattern.
@@ -153,6 +206,22 @@ func WithBenchmark() RunOption {
 	return withBenchmark{}
 }
 
+// WithObserver sets observer of each part solved, e.g. to export metrics of long-running service.
+// Parts solved repeatedly by benchmark are not observed.
+func WithObserver(o Observer) RunOption {
+	return withObserver{
+		observer: o,
+	}
+}
+
+// WithParts sets parts to solve, PartOne or PartTwo: answers of other parts are left empty.
+// All parts are solved by default.
+func WithParts(parts ...string) RunOption {
+	return withParts{
+		parts: parts,
+	}
+}
+
 type withElapsed struct{}
 
 func (w withElapsed) Apply(opts *runParams) {
@@ -165,6 +234,22 @@ func (w withBenchmark) Apply(opts *runParams) {
 	opts.withMetrics.AddFlag(metricsFlagBenchmark)
 }
 
+type withObserver struct {
+	observer Observer
+}
+
+func (w withObserver) Apply(opts *runParams) {
+	opts.observer = w.observer
+}
+
+type withParts struct {
+	parts []string
+}
+
+func (w withParts) Apply(opts *runParams) {
+	opts.parts = append(opts.parts, w.parts...)
+}
+
 func makeRunParams(opts []RunOption) runParams {
 	var p runParams
 
@@ -195,10 +280,10 @@ func Solve(solver Solver, input io.Reader, opts ...RunOption) (Result, error) {
 
 	b := buf.Bytes()
 
-	apply := res.addMetrics(solver, b, params.withMetrics)
+	apply := res.addMetrics(solver, b, params)
 	defer apply()
 
-	if err := res.addAnswers(solver, b); err != nil {
+	if err := res.addAnswers(solver, b, params); err != nil {
 		return Result{}, fmt.Errorf("failed to add answers: %w", err)
 	}
 
@@ -207,7 +292,8 @@ func Solve(solver Solver, input io.Reader, opts ...RunOption) (Result, error) {
 
 type applyMetricFunc func()
 
-func (r *Result) addMetrics(solver Solver, input []byte, mf metricsFlag) func() {
+func (r *Result) addMetrics(solver Solver, input []byte, params runParams) func() {
+	mf := params.withMetrics
 	if mf.HasFlag(metricsFlagNone) {
 		return func() {
 			r.metrics = nil
@@ -232,7 +318,10 @@ func (r *Result) addMetrics(solver Solver, input []byte, mf metricsFlag) func()
 		r.metrics = append(r.metrics, &bm)
 
 		bf := benchFunc(func() error {
-			return r.addAnswers(solver, input)
+			bp := params
+			bp.observer = nil
+
+			return r.addAnswers(solver, input, bp)
 		})
 
 		metricFuncs = append(metricFuncs, bm.bench(bf))
@@ -245,15 +334,29 @@ func (r *Result) addMetrics(solver Solver, input []byte, mf metricsFlag) func()
 	}
 }
 
-func (r *Result) addAnswers(s Solver, input []byte) error {
-	part1, err := s.Part1(bytes.NewReader(input))
-	if err != nil && !errors.Is(err, ErrNotImplemented) {
-		return fmt.Errorf("failed to solve Part1: %w", err)
+func (r *Result) addAnswers(s Solver, input []byte, params runParams) error {
+	var part1, part2 string
+
+	if params.solves(PartOne) {
+		var err error
+
+		part1, err = observe(params.observer, s, PartOne, func() (string, error) {
+			return s.Part1(bytes.NewReader(input))
+		})
+		if err != nil && !errors.Is(err, ErrNotImplemented) {
+			return fmt.Errorf("failed to solve Part1: %w", err)
+		}
 	}
 
-	part2, err := s.Part2(bytes.NewReader(input))
-	if err != nil && !errors.Is(err, ErrNotImplemented) {
-		return fmt.Errorf("failed to solve Part2: %w", err)
+	if params.solves(PartTwo) {
+		var err error
+
+		part2, err = observe(params.observer, s, PartTwo, func() (string, error) {
+			return s.Part2(bytes.NewReader(input))
+		})
+		if err != nil && !errors.Is(err, ErrNotImplemented) {
+			return fmt.Errorf("failed to solve Part2: %w", err)
+		}
 	}
 
 	r.Part1 = part1
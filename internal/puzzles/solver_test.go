@@ -55,6 +55,10 @@ func (a anotherMockSolver) Day() string {
 	return a.name
 }
 
+func (a anotherMockSolver) Tags() []string {
+	return []string{"slow", "grid"}
+}
+
 func makeAndRegisterSolvers(tb testing.TB) {
 	solvers := map[string]map[string]puzzles.Solver{
 		"2019": {
@@ -209,6 +213,47 @@ type args struct {
 	}
 }
 
+func TestSolve_observer(t *testing.T) {
+	var got []puzzles.PartMetric
+
+	res, err := puzzles.Solve(mockSolver{
+		year: "2019",
+		name: "mockSolver",
+	}, strings.NewReader("testdata"), puzzles.WithBenchmark(), puzzles.WithObserver(func(m puzzles.PartMetric) {
+		got = append(got, m)
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "part 1 of mockSolver", res.Part1)
+
+	require.Len(t, got, 2, "parts solved by benchmark are not observed")
+
+	for i, part := range []string{puzzles.PartOne, puzzles.PartTwo} {
+		assert.Equal(t, "2019", got[i].Year)
+		assert.Equal(t, "mockSolver", got[i].Day)
+		assert.Equal(t, part, got[i].Part)
+		assert.NoError(t, got[i].Err)
+	}
+}
+
+func TestSolve_parts(t *testing.T) {
+	s := mockSolver{
+		year: "2019",
+		name: "mockSolver",
+	}
+
+	var got []string
+
+	res, err := puzzles.Solve(s, strings.NewReader("testdata"), puzzles.WithParts(puzzles.PartTwo),
+		puzzles.WithObserver(func(m puzzles.PartMetric) {
+			got = append(got, m.Part)
+		}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "", res.Part1)
+	assert.Equal(t, "part 2 of mockSolver", res.Part2)
+	assert.Equal(t, []string{puzzles.PartTwo}, got)
+}
+
 func TestSolversYears(t *testing.T) {
 	makeAndRegisterSolvers(t)
 
@@ -234,3 +279,28 @@ func TestSolversByYear(t *testing.T) {
 
 	assert.ElementsMatch(t, expectedSolvers, solvers)
 }
+
+func TestSolvers(t *testing.T) {
+	puzzles.UnregisterAllSolvers(t)
+	defer puzzles.UnregisterAllSolvers(t)
+
+	makeAndRegisterSolvers(t)
+
+	names := func(list []puzzles.Solver) []string {
+		res := make([]string, 0, len(list))
+
+		for _, s := range list {
+			res = append(res, s.Year()+"/"+s.Day())
+		}
+
+		return res
+	}
+
+	assert.Equal(t, []string{"2017/mock1", "2019/anotherMock", "2019/mock"}, names(puzzles.Solvers(nil, nil)))
+	assert.Equal(t, []string{"2019/anotherMock", "2019/mock"}, names(puzzles.Solvers([]string{"2019"}, nil)))
+	assert.Equal(t, []string{"2019/anotherMock"}, names(puzzles.Solvers(nil, []string{"grid", "unknown"})))
+	assert.Empty(t, puzzles.Solvers([]string{"2017"}, []string{"slow"}))
+
+	assert.Nil(t, puzzles.SolverTags(mockSolver{}))
+	assert.Equal(t, []string{"slow", "grid"}, puzzles.SolverTags(anotherMockSolver{}))
+}
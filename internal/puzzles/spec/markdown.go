@@ -0,0 +1,185 @@
+package spec
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/obalunenko/advent-of-code/internal/htmlnode"
+)
+
+const codeFence = "```"
+
+var spacesRe = regexp.MustCompile(`\s+`)
+
+// toMarkdown converts description article to markdown in the style of existing spec.md files:
+// headings are skipped, <pre> blocks become text code fences, <em> becomes bold and <code> inline code.
+func toMarkdown(article *html.Node, base *url.URL) string {
+	blocks := make([]string, 0)
+
+	for c := article.FirstChild; c != nil; c = c.NextSibling {
+		if b := block(c, base); b != "" {
+			blocks = append(blocks, b)
+		}
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+func block(n *html.Node, base *url.URL) string {
+	if n.Type == html.TextNode {
+		return strings.TrimSpace(spacesRe.ReplaceAllString(n.Data, " "))
+	}
+
+	if n.Type != html.ElementNode {
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.H2:
+		return ""
+	case atom.Pre:
+		return codeBlock(n)
+	case atom.Ul, atom.Ol:
+		return list(n, base, "")
+	default:
+		return trimLines(inline(n, base))
+	}
+}
+
+func codeBlock(n *html.Node) string {
+	code := strings.TrimRight(htmlnode.Text(n), "\n")
+
+	return codeFence + "text\n" + code + "\n" + codeFence
+}
+
+func list(n *html.Node, base *url.URL, indent string) string {
+	items := make([]string, 0)
+
+	num := 0
+
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.DataAtom != atom.Li {
+			continue
+		}
+
+		num++
+
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = strconv.Itoa(num) + ". "
+		}
+
+		var (
+			text   strings.Builder
+			nested []string
+		)
+
+		for c := li.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && (c.DataAtom == atom.Ul || c.DataAtom == atom.Ol) {
+				nested = append(nested, list(c, base, indent+"  "))
+
+				continue
+			}
+
+			text.WriteString(inlineNode(c, base))
+		}
+
+		item := indent + marker + trimLines(text.String())
+
+		items = append(items, item)
+		items = append(items, nested...)
+	}
+
+	return strings.Join(items, "\n")
+}
+
+func inline(n *html.Node, base *url.URL) string {
+	var sb strings.Builder
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(inlineNode(c, base))
+	}
+
+	return sb.String()
+}
+
+func inlineNode(n *html.Node, base *url.URL) string {
+	if n.Type == html.TextNode {
+		return spacesRe.ReplaceAllString(n.Data, " ")
+	}
+
+	if n.Type != html.ElementNode {
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Code:
+		return inlineCode(htmlnode.Text(n))
+	case atom.Em, atom.Strong, atom.B:
+		return wrap(inline(n, base), "**")
+	case atom.I:
+		return wrap(inline(n, base), "_")
+	case atom.A:
+		return link(inline(n, base), htmlnode.Attr(n, "href"), base)
+	case atom.Br:
+		return "\n"
+	default:
+		return inline(n, base)
+	}
+}
+
+func inlineCode(code string) string {
+	fence := "`"
+
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+
+	return fence + code + fence
+}
+
+// wrap surrounds text with marker keeping leading and trailing spaces outside,
+// as markdown emphasis is not recognized when it starts or ends with space.
+func wrap(text, marker string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+
+	lead := text[:strings.Index(text, trimmed)]
+	trail := text[len(lead)+len(trimmed):]
+
+	return lead + marker + trimmed + marker + trail
+}
+
+func link(text, href string, base *url.URL) string {
+	if href == "" {
+		return text
+	}
+
+	u, err := url.Parse(href)
+	if err == nil && base != nil {
+		href = base.ResolveReference(u).String()
+	}
+
+	return "[" + strings.TrimSpace(text) + "](" + href + ")"
+}
+
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,172 @@
+// Package spec parses puzzle description pages of adventofcode.com and converts them to markdown.
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/obalunenko/advent-of-code/internal/htmlnode"
+)
+
+// ErrNoDescription returns when page has no puzzle description.
+var ErrNoDescription = errors.New("puzzle description not found")
+
+// Spec holds puzzle description converted to markdown.
+type Spec struct {
+	// Title is a puzzle title, e.g. "Hydrothermal Venture".
+	Title string
+	// PartOne is a markdown description of the first part.
+	PartOne string
+	// PartTwo is a markdown description of the second part. Empty until part two is unlocked.
+	PartTwo string
+	// Examples are contents of <pre><code> blocks of both parts in order of appearance.
+	Examples []Example
+	// AnswerPartOne is a guessed answer for the example of part one, empty when not guessed.
+	AnswerPartOne string
+	// AnswerPartTwo is a guessed answer for the example of part two, empty when not guessed.
+	AnswerPartTwo string
+}
+
+// Example is a code block from puzzle description, usually an example input.
+type Example struct {
+	// Part is a number of the part where example is given: 1 or 2.
+	Part int
+	// Input is a content of code block.
+	Input string
+}
+
+// FirstExample returns index of the first example of passed part in Examples.
+// Part two falls back to the first example of part one, as it usually reuses it.
+// Returns -1 when there are no examples.
+func (s Spec) FirstExample(part int) int {
+	for i, e := range s.Examples {
+		if e.Part == part {
+			return i
+		}
+	}
+
+	const partTwo = 2
+
+	if part == partTwo {
+		return s.FirstExample(1)
+	}
+
+	return -1
+}
+
+// HasPartTwo reports whether description of part two is available.
+func (s Spec) HasPartTwo() bool {
+	return s.PartTwo != ""
+}
+
+var titleRe = regexp.MustCompile(`^---\s*Day\s+\d+:\s*(.*?)\s*---$`)
+
+// Parse reads puzzle page HTML and extracts descriptions of both parts from <article class="day-desc"> blocks.
+// Relative links are resolved against pageURL.
+func Parse(r io.Reader, pageURL string) (Spec, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return Spec{}, fmt.Errorf("parse page url: %w", err)
+	}
+
+	doc, err := html.Parse(r)
+	if err != nil {
+		return Spec{}, fmt.Errorf("parse html: %w", err)
+	}
+
+	articles := htmlnode.FindAll(doc, isDayDesc)
+	if len(articles) == 0 {
+		return Spec{}, ErrNoDescription
+	}
+
+	var s Spec
+
+	if h := htmlnode.Find(articles[0], isHeading); h != nil {
+		s.Title = titleFromHeading(htmlnode.Text(h))
+	}
+
+	s.PartOne = toMarkdown(articles[0], base)
+	s.Examples = append(s.Examples, examples(articles[0], 1)...)
+	s.AnswerPartOne = guessAnswer(articles[0])
+
+	if len(articles) > 1 {
+		const partTwo = 2
+
+		s.PartTwo = toMarkdown(articles[1], base)
+		s.Examples = append(s.Examples, examples(articles[1], partTwo)...)
+		s.AnswerPartTwo = guessAnswer(articles[1])
+	}
+
+	return s, nil
+}
+
+func examples(article *html.Node, part int) []Example {
+	blocks := htmlnode.FindAll(article, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.DataAtom == atom.Pre
+	})
+
+	res := make([]Example, 0, len(blocks))
+
+	for _, b := range blocks {
+		res = append(res, Example{
+			Part:  part,
+			Input: htmlnode.Text(b),
+		})
+	}
+
+	return res
+}
+
+// guessAnswer returns the last emphasised code of the description, that is usually
+// an answer for the example: <code><em>42</em></code> or <em><code>42</code></em>.
+func guessAnswer(article *html.Node) string {
+	emphasised := htmlnode.FindAll(article, func(n *html.Node) bool {
+		if n.Type != html.ElementNode || n.DataAtom != atom.Code {
+			return false
+		}
+
+		if n.Parent != nil && n.Parent.DataAtom == atom.Em {
+			return true
+		}
+
+		c := n.FirstChild
+
+		return c != nil && c == n.LastChild && c.Type == html.ElementNode && c.DataAtom == atom.Em
+	})
+
+	if len(emphasised) == 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(htmlnode.Text(emphasised[len(emphasised)-1]))
+}
+
+func titleFromHeading(h string) string {
+	h = strings.TrimSpace(h)
+
+	m := titleRe.FindStringSubmatch(h)
+	if len(m) != 2 {
+		return h
+	}
+
+	return m[1]
+}
+
+func isDayDesc(n *html.Node) bool {
+	if n.Type != html.ElementNode || n.DataAtom != atom.Article {
+		return false
+	}
+
+	return htmlnode.HasClass(n, "day-desc")
+}
+
+func isHeading(n *html.Node) bool {
+	return n.Type == html.ElementNode && n.DataAtom == atom.H2
+}
@@ -0,0 +1,88 @@
+package spec_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/common/utils"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/spec"
+)
+
+const pageURL = "https://adventofcode.com/2021/day/5"
+
+func TestParse(t *testing.T) {
+	partOne, err := os.ReadFile(filepath.Join("testdata", "day_part_one.md"))
+	require.NoError(t, err)
+
+	got, err := spec.Parse(utils.ReaderFromFile(t, filepath.Join("testdata", "day.html")), pageURL)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hydrothermal Venture", got.Title)
+	assert.Equal(t, string(partOne), got.PartOne)
+	assert.True(t, got.HasPartTwo())
+	assert.Equal(t, "Unfortunately, considering only horizontal and vertical lines doesn't give you the full picture; "+
+		"you need to also consider **diagonal lines**.\n\n"+
+		"See [previous](https://adventofcode.com/2021/day/4) puzzle.\n\n"+
+		"In the above example, this is still anywhere in the diagram with a `2` or larger - now a total of `12` points.",
+		got.PartTwo)
+
+	assert.Equal(t, []spec.Example{
+		{
+			Part:  1,
+			Input: "0,9 -> 5,9\n8,0 -> 0,8\n9,4 -> 3,4\n",
+		},
+	}, got.Examples)
+	assert.Equal(t, "5", got.AnswerPartOne)
+	assert.Equal(t, "12", got.AnswerPartTwo)
+	assert.Equal(t, 0, got.FirstExample(1))
+	assert.Equal(t, 0, got.FirstExample(2))
+}
+
+func TestParse_partOneOnly(t *testing.T) {
+	page := `<main><article class="day-desc"><h2>--- Day 1: Trebuchet?! ---</h2>` +
+		`<p>Something is <em>wrong</em> with global snow production.</p></article></main>`
+
+	got, err := spec.Parse(strings.NewReader(page), "https://adventofcode.com/2023/day/1")
+	require.NoError(t, err)
+
+	assert.Equal(t, spec.Spec{
+		Title:         "Trebuchet?!",
+		PartOne:       "Something is **wrong** with global snow production.",
+		PartTwo:       "",
+		Examples:      nil,
+		AnswerPartOne: "",
+		AnswerPartTwo: "",
+	}, got)
+	assert.Equal(t, -1, got.FirstExample(1))
+	assert.Equal(t, -1, got.FirstExample(2))
+	assert.False(t, got.HasPartTwo())
+}
+
+func TestParse_examples(t *testing.T) {
+	page := `<article class="day-desc"><h2>--- Day 1: Trebuchet?! ---</h2>` +
+		`<pre><code>1abc2</code></pre><p>Total is <em><code>142</code></em>.</p></article>` +
+		`<article class="day-desc"><h2>--- Part Two ---</h2>` +
+		`<pre><code>two1nine</code></pre><p>Not <code><em>1</em></code>, but <code><em>281</em></code>.</p></article>`
+
+	got, err := spec.Parse(strings.NewReader(page), "https://adventofcode.com/2023/day/1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []spec.Example{
+		{Part: 1, Input: "1abc2"},
+		{Part: 2, Input: "two1nine"},
+	}, got.Examples)
+	assert.Equal(t, "142", got.AnswerPartOne)
+	assert.Equal(t, "281", got.AnswerPartTwo)
+	assert.Equal(t, 0, got.FirstExample(1))
+	assert.Equal(t, 1, got.FirstExample(2))
+}
+
+func TestParse_noDescription(t *testing.T) {
+	_, err := spec.Parse(strings.NewReader("<main><p>404 Not Found</p></main>"), pageURL)
+	assert.ErrorIs(t, err, spec.ErrNoDescription)
+}
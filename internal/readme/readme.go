@@ -0,0 +1,209 @@
+// Package readme renders tables of implemented puzzles in README.md from the registry of solvers.
+//
+// Tables are placed between a pair of `<!--- advent_readme_stars table [<year>] --->` markers, one block per year.
+package readme
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+)
+
+const (
+	solutionsDir = "internal/puzzles/solutions"
+	star         = "⭐"
+)
+
+// ErrInvalidMarkers returns when table markers are not paired.
+var ErrInvalidMarkers = errors.New("invalid table markers")
+
+var markerRe = regexp.MustCompile(`<!--- advent_readme_stars table \[(\d{4})\] --->`)
+
+// Day is a registered puzzle with its implemented parts.
+type Day struct {
+	Year  string
+	Day   string
+	Part1 bool
+	Part2 bool
+}
+
+// Collect walks the registry and returns registered puzzles by year, ordered by day.
+// Implemented parts are detected by puzzles.Probe.
+func Collect() map[string][]Day {
+	res := make(map[string][]Day)
+
+	for _, p := range puzzles.Probe(puzzles.Solvers(nil, nil)) {
+		res[p.Year] = append(res[p.Year], Day(p))
+	}
+
+	for _, days := range res {
+		slices.SortFunc(days, func(a, b Day) int {
+			return cmp.Compare(dayNum(a.Day), dayNum(b.Day))
+		})
+	}
+
+	return res
+}
+
+// Render renders table of the year.
+func Render(year string, days []Day) string {
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, "### %s Results\n\n", year)
+	buf.WriteString("| Day | Part 1 | Part 2 | Solution |\n")
+	buf.WriteString("| :---: | :---: | :---: | :---: |\n")
+
+	for _, d := range days {
+		pkg := fmt.Sprintf("day%02d", dayNum(d.Day))
+
+		fmt.Fprintf(&buf, "| [Day %s](https://adventofcode.com/%s/day/%s) | %s | %s | [%s](%s/%s/%s) |\n",
+			d.Day, d.Year, d.Day, stars(d.Part1), stars(d.Part2), pkg, solutionsDir, d.Year, pkg)
+	}
+
+	return buf.String()
+}
+
+// Update rewrites tables between markers in content. Tables of years missing in content are added
+// keeping years in descending order.
+func Update(content []byte, years map[string][]Day) ([]byte, error) {
+	matches := markerRe.FindAllSubmatchIndex(content, -1)
+	if len(matches) == 0 || len(matches)%2 != 0 {
+		return nil, fmt.Errorf("%w: %d markers found", ErrInvalidMarkers, len(matches))
+	}
+
+	type block struct {
+		year       string
+		start, end int // offsets of the opening marker start and the closing marker end.
+	}
+
+	blocks := make([]block, 0, len(matches)/2)
+
+	for i := 0; i < len(matches); i += 2 {
+		open, closing := matches[i], matches[i+1]
+
+		year := string(content[open[2]:open[3]])
+		if cy := string(content[closing[2]:closing[3]]); cy != year {
+			return nil, fmt.Errorf("%w: table of %s closed by marker of %s", ErrInvalidMarkers, year, cy)
+		}
+
+		blocks = append(blocks, block{
+			year:  year,
+			start: open[0],
+			end:   closing[1],
+		})
+	}
+
+	var (
+		buf  bytes.Buffer
+		last int
+	)
+
+	written := make(map[string]bool, len(years))
+
+	// writeMissing writes tables of not written years newer than year.
+	writeMissing := func(year string) {
+		for _, y := range sortedYears(years) {
+			if written[y] || y <= year {
+				continue
+			}
+
+			buf.WriteString(table(y, years[y]))
+			buf.WriteString("\n\n")
+
+			written[y] = true
+		}
+	}
+
+	for _, b := range blocks {
+		buf.Write(content[last:b.start])
+
+		writeMissing(b.year)
+
+		buf.WriteString(table(b.year, years[b.year]))
+
+		written[b.year] = true
+		last = b.end
+	}
+
+	// Years older than all tables in content.
+	for _, y := range sortedYears(years) {
+		if !written[y] {
+			buf.WriteString("\n\n")
+			buf.WriteString(table(y, years[y]))
+		}
+	}
+
+	buf.Write(content[last:])
+
+	return buf.Bytes(), nil
+}
+
+// UpdateFile rewrites tables in file at path with puzzles from the registry.
+// Returned flag reports whether file was changed.
+func UpdateFile(path string) (bool, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return false, fmt.Errorf("read readme: %w", err)
+	}
+
+	res, err := Update(content, Collect())
+	if err != nil {
+		return false, err
+	}
+
+	if bytes.Equal(content, res) {
+		return false, nil
+	}
+
+	if err = os.WriteFile(filepath.Clean(path), res, os.ModePerm); err != nil {
+		return false, fmt.Errorf("write readme: %w", err)
+	}
+
+	return true, nil
+}
+
+func table(year string, days []Day) string {
+	marker := fmt.Sprintf("<!--- advent_readme_stars table [%s] --->", year)
+
+	return marker + "\n" + Render(year, days) + marker
+}
+
+// sortedYears returns years in descending order.
+func sortedYears(years map[string][]Day) []string {
+	list := make([]string, 0, len(years))
+
+	for y := range years {
+		list = append(list, y)
+	}
+
+	slices.Sort(list)
+	slices.Reverse(list)
+
+	return list
+}
+
+func stars(ok bool) string {
+	if ok {
+		return star
+	}
+
+	return " "
+}
+
+func dayNum(day string) int {
+	n, err := strconv.Atoi(day)
+	if err != nil {
+		return 0
+	}
+
+	return n
+}
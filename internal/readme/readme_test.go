@@ -0,0 +1,172 @@
+package readme
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+)
+
+type mockSolver struct {
+	year  string
+	day   string
+	part2 func(in io.Reader) (string, error)
+}
+
+func (m mockSolver) Year() string {
+	return m.year
+}
+
+func (m mockSolver) Day() string {
+	return m.day
+}
+
+func (m mockSolver) Part1(_ io.Reader) (string, error) {
+	return "", io.ErrUnexpectedEOF
+}
+
+func (m mockSolver) Part2(in io.Reader) (string, error) {
+	return m.part2(in)
+}
+
+func notImplemented(_ io.Reader) (string, error) {
+	return "", puzzles.ErrNotImplemented
+}
+
+func panicking(_ io.Reader) (string, error) {
+	panic("empty input")
+}
+
+func TestCollect(t *testing.T) {
+	puzzles.UnregisterAllSolvers(t)
+	t.Cleanup(func() {
+		puzzles.UnregisterAllSolvers(t)
+	})
+
+	puzzles.Register(mockSolver{year: "2021", day: "10", part2: notImplemented})
+	puzzles.Register(mockSolver{year: "2021", day: "2", part2: panicking})
+	puzzles.Register(mockSolver{year: "2020", day: "1", part2: notImplemented})
+
+	assert.Equal(t, map[string][]Day{
+		"2020": {
+			{Year: "2020", Day: "1", Part1: true, Part2: false},
+		},
+		"2021": {
+			{Year: "2021", Day: "2", Part1: true, Part2: true},
+			{Year: "2021", Day: "10", Part1: true, Part2: false},
+		},
+	}, Collect())
+}
+
+func TestUpdate(t *testing.T) {
+	years := map[string][]Day{
+		"2022": {
+			{Year: "2022", Day: "1", Part1: true, Part2: false},
+		},
+		"2021": {
+			{Year: "2021", Day: "7", Part1: true, Part2: true},
+		},
+		"2019": {
+			{Year: "2019", Day: "4", Part1: true, Part2: true},
+		},
+		"2015": nil,
+	}
+
+	const content = `## Implemented solutions
+
+<!--- advent_readme_stars table [2021] --->
+### 2021 Results
+
+| Day | Part 1 | Part 2 |
+| :---: | :---: | :---: |
+| [Day 1](https://adventofcode.com/2021/day/1) | ⭐ | ⭐ |
+<!--- advent_readme_stars table [2021] --->
+
+<!--- advent_readme_stars table [2020] --->
+### 2020 Results
+<!--- advent_readme_stars table [2020] --->
+
+## Usage
+`
+
+	got, err := Update([]byte(content), years)
+	require.NoError(t, err)
+
+	assert.Equal(t, `## Implemented solutions
+
+<!--- advent_readme_stars table [2022] --->
+### 2022 Results
+
+| Day | Part 1 | Part 2 | Solution |
+| :---: | :---: | :---: | :---: |
+| [Day 1](https://adventofcode.com/2022/day/1) | ⭐ |   | [day01](internal/puzzles/solutions/2022/day01) |
+<!--- advent_readme_stars table [2022] --->
+
+<!--- advent_readme_stars table [2021] --->
+### 2021 Results
+
+| Day | Part 1 | Part 2 | Solution |
+| :---: | :---: | :---: | :---: |
+| [Day 7](https://adventofcode.com/2021/day/7) | ⭐ | ⭐ | [day07](internal/puzzles/solutions/2021/day07) |
+<!--- advent_readme_stars table [2021] --->
+
+<!--- advent_readme_stars table [2020] --->
+### 2020 Results
+
+| Day | Part 1 | Part 2 | Solution |
+| :---: | :---: | :---: | :---: |
+<!--- advent_readme_stars table [2020] --->
+
+<!--- advent_readme_stars table [2019] --->
+### 2019 Results
+
+| Day | Part 1 | Part 2 | Solution |
+| :---: | :---: | :---: | :---: |
+| [Day 4](https://adventofcode.com/2019/day/4) | ⭐ | ⭐ | [day04](internal/puzzles/solutions/2019/day04) |
+<!--- advent_readme_stars table [2019] --->
+
+<!--- advent_readme_stars table [2015] --->
+### 2015 Results
+
+| Day | Part 1 | Part 2 | Solution |
+| :---: | :---: | :---: | :---: |
+<!--- advent_readme_stars table [2015] --->
+
+## Usage
+`, string(got))
+
+	again, err := Update(got, years)
+	require.NoError(t, err)
+	assert.Equal(t, string(got), string(again), "update is idempotent")
+}
+
+func TestUpdate_invalidMarkers(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "no markers",
+			content: "# README\n",
+		},
+		{
+			name:    "not closed",
+			content: "<!--- advent_readme_stars table [2021] --->\n",
+		},
+		{
+			name: "closed by another year",
+			content: "<!--- advent_readme_stars table [2021] --->\n" +
+				"<!--- advent_readme_stars table [2020] --->\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Update([]byte(tt.content), nil)
+			assert.ErrorIs(t, err, ErrInvalidMarkers)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// EventType is a type of run event.
+type EventType string
+
+const (
+	// EventStart is sent when puzzle solving starts.
+	EventStart EventType = "start"
+	// EventFinish is sent when puzzle is solved.
+	EventFinish EventType = "finish"
+	// EventError is sent when puzzle solving failed.
+	EventError EventType = "error"
+)
+
+// Event is a run event written as one line of NDJSON.
+type Event struct {
+	Time time.Time `json:"time"`
+	Type EventType `json:"type"`
+	Year string    `json:"year"`
+	Day  string    `json:"day"`
+	// Parts holds results of both parts in finish event.
+	Parts []PartEvent `json:"parts,omitempty"`
+	// DurationNS is a time spent on the puzzle in finish and error events.
+	DurationNS int64  `json:"duration_ns,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// PartEvent is a result of one puzzle part in finish event.
+type PartEvent struct {
+	Part       int    `json:"part"`
+	Answer     string `json:"answer"`
+	Expected   string `json:"expected,omitempty"`
+	DurationNS int64  `json:"duration_ns"`
+	// OK is false when answer differs from expected one.
+	OK bool `json:"ok"`
+}
+
+// EventWriter writes events as NDJSON. It is safe for concurrent use.
+type EventWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	now func() time.Time
+}
+
+// NewEventWriter creates EventWriter writing to w.
+func NewEventWriter(w io.Writer) *EventWriter {
+	return &EventWriter{
+		mu:  sync.Mutex{},
+		enc: json.NewEncoder(w),
+		now: time.Now,
+	}
+}
+
+// Start writes start event of the puzzle.
+func (w *EventWriter) Start(year, day string) error {
+	return w.write(Event{
+		Time:       time.Time{},
+		Type:       EventStart,
+		Year:       year,
+		Day:        day,
+		Parts:      nil,
+		DurationNS: 0,
+		Error:      "",
+	})
+}
+
+// Finish writes finish event with results of puzzle parts, or error event when any of cases has an error.
+func (w *EventWriter) Finish(year, day string, duration time.Duration, cases []Case) error {
+	e := Event{
+		Time:       time.Time{},
+		Type:       EventFinish,
+		Year:       year,
+		Day:        day,
+		Parts:      nil,
+		DurationNS: duration.Nanoseconds(),
+		Error:      "",
+	}
+
+	for _, c := range cases {
+		if c.Err != nil {
+			e.Type = EventError
+			e.Parts = nil
+			e.Error = c.Err.Error()
+
+			break
+		}
+
+		e.Parts = append(e.Parts, PartEvent{
+			Part:       c.Part,
+			Answer:     c.Answer,
+			Expected:   c.Expected,
+			DurationNS: c.Duration.Nanoseconds(),
+			OK:         !c.Failed(),
+		})
+	}
+
+	return w.write(e)
+}
+
+func (w *EventWriter) write(e Event) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if e.Time.IsZero() {
+		e.Time = w.now().UTC()
+	}
+
+	if err := w.enc.Encode(e); err != nil {
+		return fmt.Errorf("write event: %w", err)
+	}
+
+	return nil
+}
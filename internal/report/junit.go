@@ -0,0 +1,214 @@
+// Package report writes results of puzzle runs in formats consumed by CI and log pipelines:
+// JUnit XML with a testcase per puzzle part, and NDJSON stream of run events.
+package report
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Case is a result of solving one puzzle part.
+type Case struct {
+	Year string
+	Day  string
+	// Part is 1 or 2.
+	Part   int
+	Answer string
+	// Expected is a known correct answer. Empty when unknown, then any answer passes.
+	Expected string
+	Duration time.Duration
+	// Err is an error of solving the puzzle, e.g. input not found or timeout.
+	Err error
+}
+
+// Failed reports whether answer differs from expected one.
+func (c Case) Failed() bool {
+	return c.Err == nil && c.Expected != "" && c.Answer != c.Expected
+}
+
+type testsuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Time     string      `xml:"time,attr"`
+	Suites   []testsuite `xml:"testsuite"`
+}
+
+type testsuite struct {
+	Name     string     `xml:"name,attr"`
+	Tests    int        `xml:"tests,attr"`
+	Failures int        `xml:"failures,attr"`
+	Errors   int        `xml:"errors,attr"`
+	Time     string     `xml:"time,attr"`
+	Cases    []testcase `xml:"testcase"`
+}
+
+type testcase struct {
+	Name       string     `xml:"name,attr"`
+	Classname  string     `xml:"classname,attr"`
+	Time       string     `xml:"time,attr"`
+	Properties []property `xml:"properties>property,omitempty"`
+	Failure    *problem   `xml:"failure,omitempty"`
+	Error      *problem   `xml:"error,omitempty"`
+	SystemOut  string     `xml:"system-out,omitempty"`
+}
+
+type property struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type problem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes cases as JUnit XML report named name: a testsuite per year and a testcase per puzzle part,
+// ordered by year, day and part.
+func WriteJUnit(w io.Writer, name string, cases []Case) error {
+	root := testsuites{
+		XMLName:  xml.Name{},
+		Name:     name,
+		Tests:    0,
+		Failures: 0,
+		Errors:   0,
+		Time:     "",
+		Suites:   nil,
+	}
+
+	cases = slices.Clone(cases)
+	slices.SortStableFunc(cases, compareCases)
+
+	var total time.Duration
+
+	byYear := make(map[string]int)
+	durations := make(map[string]time.Duration)
+
+	for _, c := range cases {
+		i, ok := byYear[c.Year]
+		if !ok {
+			i = len(root.Suites)
+			byYear[c.Year] = i
+
+			root.Suites = append(root.Suites, testsuite{
+				Name:     c.Year,
+				Tests:    0,
+				Failures: 0,
+				Errors:   0,
+				Time:     "",
+				Cases:    nil,
+			})
+		}
+
+		tc := newTestcase(c)
+
+		suite := &root.Suites[i]
+		suite.Tests++
+		root.Tests++
+
+		switch {
+		case tc.Error != nil:
+			suite.Errors++
+			root.Errors++
+		case tc.Failure != nil:
+			suite.Failures++
+			root.Failures++
+		}
+
+		suite.Cases = append(suite.Cases, tc)
+
+		durations[c.Year] += c.Duration
+		total += c.Duration
+	}
+
+	for i := range root.Suites {
+		root.Suites[i].Time = seconds(durations[root.Suites[i].Name])
+	}
+
+	root.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(root); err != nil {
+		return fmt.Errorf("encode junit: %w", err)
+	}
+
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	return nil
+}
+
+func newTestcase(c Case) testcase {
+	tc := testcase{
+		Name:      "part" + strconv.Itoa(c.Part),
+		Classname: fmt.Sprintf("aoc.%s.day%s", c.Year, c.Day),
+		Time:      seconds(c.Duration),
+		Properties: []property{
+			{
+				Name:  "answer",
+				Value: c.Answer,
+			},
+			{
+				Name:  "expected",
+				Value: c.Expected,
+			},
+		},
+		Failure:   nil,
+		Error:     nil,
+		SystemOut: fmt.Sprintf("answer: %s\nexpected: %s", c.Answer, c.Expected),
+	}
+
+	switch {
+	case c.Err != nil:
+		tc.Error = &problem{
+			Message: c.Err.Error(),
+			Type:    "error",
+			Text:    c.Err.Error(),
+		}
+	case c.Failed():
+		msg := fmt.Sprintf("answer %q, expected %q", c.Answer, c.Expected)
+
+		tc.Failure = &problem{
+			Message: msg,
+			Type:    "wrong answer",
+			Text:    msg,
+		}
+	}
+
+	return tc
+}
+
+// compareCases orders cases by year, day and part.
+func compareCases(a, b Case) int {
+	if c := cmp.Compare(a.Year, b.Year); c != 0 {
+		return c
+	}
+
+	da, _ := strconv.Atoi(a.Day)
+	db, _ := strconv.Atoi(b.Day)
+
+	if c := cmp.Compare(da, db); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.Part, b.Part)
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 6, 64)
+}
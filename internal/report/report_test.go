@@ -0,0 +1,59 @@
+package report_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/report"
+)
+
+func testCases() []report.Case {
+	return []report.Case{
+		{Year: "2021", Day: "10", Part: 1, Answer: "1", Expected: "", Duration: time.Millisecond, Err: nil},
+		{Year: "2021", Day: "10", Part: 2, Answer: "2", Expected: "", Duration: time.Millisecond, Err: nil},
+		{Year: "2021", Day: "5", Part: 2, Answer: "17787", Expected: "17788", Duration: 2 * time.Millisecond, Err: nil},
+		{Year: "2021", Day: "5", Part: 1, Answer: "5306", Expected: "5306", Duration: 3 * time.Millisecond, Err: nil},
+		{Year: "2020", Day: "1", Part: 1, Answer: "", Expected: "", Duration: 0, Err: errors.New("puzzle timed out")},
+		{Year: "2020", Day: "1", Part: 2, Answer: "", Expected: "", Duration: 0, Err: errors.New("puzzle timed out")},
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, report.WriteJUnit(&buf, "aoc", testCases()))
+
+	want, err := os.ReadFile(filepath.Join("testdata", "junit.xml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(want), buf.String())
+}
+
+func TestEventWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := report.NewEventWriter(&buf)
+
+	cases := testCases()
+
+	require.NoError(t, w.Start("2021", "5"))
+	require.NoError(t, w.Finish("2021", "5", 5*time.Millisecond, cases[2:4]))
+	require.NoError(t, w.Finish("2020", "1", time.Second, cases[4:]))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	assert.Contains(t, lines[0], `"type":"start","year":"2021","day":"5"}`)
+	assert.Contains(t, lines[1], `"type":"finish","year":"2021","day":"5","parts":[`+
+		`{"part":2,"answer":"17787","expected":"17788","duration_ns":2000000,"ok":false},`+
+		`{"part":1,"answer":"5306","expected":"5306","duration_ns":3000000,"ok":true}],"duration_ns":5000000}`)
+	assert.Contains(t, lines[2], `"type":"error","year":"2020","day":"1","duration_ns":1000000000,"error":"puzzle timed out"}`)
+}
@@ -0,0 +1,432 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/solutions"
+)
+
+// Stages of progress notifications.
+const (
+	StageFetch  = "fetch"
+	StageSolve  = "solve"
+	StagePart   = "part"
+	StageBench  = "bench"
+	StageSubmit = "submit"
+)
+
+const (
+	metricElapsed = "elapsed"
+	metricBench   = "bench"
+)
+
+var (
+	errSolverFailed = errors.New("solver failed")
+	errNoSession    = errors.New("session is not set")
+)
+
+// Progress is a params of progress notification.
+type Progress struct {
+	// ID is an ID of request in progress.
+	ID      json.RawMessage `json:"id"`
+	Stage   string          `json:"stage"`
+	Message string          `json:"message"`
+	// Part, Answer and ElapsedNS are set on StagePart, when part is solved.
+	Part      string `json:"part,omitempty"`
+	Answer    string `json:"answer,omitempty"`
+	ElapsedNS int64  `json:"elapsed_ns,omitempty"`
+}
+
+// Option configures Server.
+type Option func(s *Server)
+
+// WithFetcher sets inputs fetcher used by fetchInput and solve without input.
+func WithFetcher(f input.Fetcher) Option {
+	return func(s *Server) {
+		s.fetcher = f
+	}
+}
+
+// WithSubmitter sets answers submitter used by submit.
+func WithSubmitter(sub input.AnswerSubmitter) Option {
+	return func(s *Server) {
+		s.submitter = sub
+	}
+}
+
+// WithSession sets session of the account to fetch inputs and submit answers.
+func WithSession(session string) Option {
+	return func(s *Server) {
+		s.session = session
+	}
+}
+
+// Server serves JSON-RPC methods of registered solvers.
+type Server struct {
+	fetcher   input.Fetcher
+	submitter input.AnswerSubmitter
+	session   string
+}
+
+// NewServer creates Server. Without fetcher and submitter only puzzles with passed input could be solved.
+func NewServer(opts ...Option) *Server {
+	s := Server{
+		fetcher:   nil,
+		submitter: nil,
+		session:   "",
+	}
+
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	return &s
+}
+
+// SolverInfo describes registered puzzle.
+type SolverInfo struct {
+	Year  string   `json:"year"`
+	Day   string   `json:"day"`
+	Title string   `json:"title"`
+	Tags  []string `json:"tags"`
+}
+
+type listSolversParams struct {
+	Year string `json:"year"`
+}
+
+type dateParams struct {
+	Year string `json:"year"`
+	Day  string `json:"day"`
+}
+
+type solveParams struct {
+	dateParams
+	// Input is an input of the puzzle, e.g. content of editor buffer.
+	Input *string `json:"input"`
+	// Path is a path of input file, used when input is not passed.
+	Path    string   `json:"path"`
+	Parts   []int    `json:"parts"`
+	Metrics []string `json:"metrics"`
+}
+
+type fetchInputResult struct {
+	Input string `json:"input"`
+}
+
+type submitParams struct {
+	dateParams
+	Part   int    `json:"part"`
+	Answer string `json:"answer"`
+}
+
+type submitResult struct {
+	Status  input.AnswerStatus `json:"status"`
+	Message string             `json:"message"`
+}
+
+// call runs method. Solver could panic on malformed input, it should not stop the server.
+func (s *Server) call(ctx context.Context, method string, params json.RawMessage, notify func(Progress)) (res any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			res, err = nil, fmt.Errorf("%w: panic: %v", errSolverFailed, p)
+		}
+	}()
+
+	switch method {
+	case MethodListSolvers:
+		var p listSolversParams
+
+		if err = decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+
+		return listSolvers(p), nil
+	case MethodSolve:
+		var p solveParams
+
+		if err = decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+
+		return s.solve(ctx, p, notify)
+	case MethodFetchInput:
+		var p dateParams
+
+		if err = decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+
+		in, err := s.fetch(ctx, p, notify)
+		if err != nil {
+			return nil, err
+		}
+
+		return fetchInputResult{
+			Input: string(in),
+		}, nil
+	case MethodSubmit:
+		var p submitParams
+
+		if err = decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+
+		return s.submit(ctx, p, notify)
+	default:
+		return nil, newError(CodeMethodNotFound, "method %q not found", method)
+	}
+}
+
+// decodeParams decodes params into v. Params could be omitted when none of them is required.
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(params, v); err != nil {
+		return newError(CodeInvalidParams, "invalid params: %v", err)
+	}
+
+	return nil
+}
+
+func listSolvers(p listSolversParams) []SolverInfo {
+	years := puzzles.GetYears()
+	if p.Year != "" {
+		years = []string{p.Year}
+	}
+
+	res := make([]SolverInfo, 0)
+
+	for _, year := range years {
+		for _, day := range puzzles.DaysByYear(year) {
+			s, err := puzzles.GetSolver(year, day)
+			if err != nil {
+				continue
+			}
+
+			// Untagged solvers have empty tags, so clients do not handle null.
+			tags := puzzles.SolverTags(s)
+			if tags == nil {
+				tags = []string{}
+			}
+
+			res = append(res, SolverInfo{
+				Year:  year,
+				Day:   day,
+				Title: solutions.Title(year, day),
+				Tags:  tags,
+			})
+		}
+	}
+
+	return res
+}
+
+func (s *Server) solve(ctx context.Context, p solveParams, notify func(Progress)) (puzzles.Result, error) {
+	solver, err := puzzles.GetSolver(p.Year, p.Day)
+	if err != nil {
+		return puzzles.Result{}, err
+	}
+
+	opts, err := runOptions(p, notify)
+	if err != nil {
+		return puzzles.Result{}, err
+	}
+
+	var in []byte
+
+	switch {
+	case p.Input != nil:
+		in = []byte(*p.Input)
+	case p.Path != "":
+		in, err = os.ReadFile(filepath.Clean(p.Path))
+		if err != nil {
+			return puzzles.Result{}, newError(CodeInvalidParams, "read input: %v", err)
+		}
+	default:
+		in, err = s.fetch(ctx, p.dateParams, notify)
+		if err != nil {
+			return puzzles.Result{}, err
+		}
+	}
+
+	notify(Progress{
+		ID:        nil,
+		Stage:     StageSolve,
+		Message:   fmt.Sprintf("Solving %s/%s", p.Year, p.Day),
+		Part:      "",
+		Answer:    "",
+		ElapsedNS: 0,
+	})
+
+	res, err := puzzles.Solve(solver, bytes.NewReader(in), opts...)
+	if err != nil {
+		return puzzles.Result{}, fmt.Errorf("%w: %w", errSolverFailed, err)
+	}
+
+	return res, nil
+}
+
+// runOptions returns options of solve: parts, metrics and observer reporting parts solved.
+func runOptions(p solveParams, notify func(Progress)) ([]puzzles.RunOption, error) {
+	var opts []puzzles.RunOption
+
+	for _, part := range p.Parts {
+		if part != 1 && part != 2 {
+			return nil, newError(CodeInvalidParams, "invalid part %d, expected 1 or 2", part)
+		}
+
+		opts = append(opts, puzzles.WithParts(strconv.Itoa(part)))
+	}
+
+	for _, m := range p.Metrics {
+		switch m {
+		case metricElapsed:
+			opts = append(opts, puzzles.WithElapsed())
+		case metricBench:
+			opts = append(opts, puzzles.WithBenchmark())
+		default:
+			return nil, newError(CodeInvalidParams, "unknown metric %q, expected %s or %s", m, metricElapsed, metricBench)
+		}
+	}
+
+	// Benchmark runs when all parts are solved.
+	parts := len(slices.Compact(slices.Sorted(slices.Values(p.Parts))))
+	if parts == 0 {
+		parts = 2
+	}
+
+	var solved int
+
+	opts = append(opts, puzzles.WithObserver(func(m puzzles.PartMetric) {
+		msg := "Part " + m.Part + " solved"
+		if m.Err != nil {
+			msg = "Part " + m.Part + ": " + m.Err.Error()
+		}
+
+		notify(Progress{
+			ID:        nil,
+			Stage:     StagePart,
+			Message:   msg,
+			Part:      m.Part,
+			Answer:    m.Answer,
+			ElapsedNS: m.Elapsed.Nanoseconds(),
+		})
+
+		if solved++; solved == parts && slices.Contains(p.Metrics, metricBench) {
+			notify(Progress{
+				ID:        nil,
+				Stage:     StageBench,
+				Message:   "Benchmarking",
+				Part:      "",
+				Answer:    "",
+				ElapsedNS: 0,
+			})
+		}
+	}))
+
+	return opts, nil
+}
+
+func (s *Server) fetch(ctx context.Context, p dateParams, notify func(Progress)) ([]byte, error) {
+	if _, err := puzzles.GetSolver(p.Year, p.Day); err != nil {
+		return nil, err
+	}
+
+	if s.fetcher == nil || s.session == "" {
+		return nil, errNoSession
+	}
+
+	notify(Progress{
+		ID:        nil,
+		Stage:     StageFetch,
+		Message:   fmt.Sprintf("Fetching input of %s/%s", p.Year, p.Day),
+		Part:      "",
+		Answer:    "",
+		ElapsedNS: 0,
+	})
+
+	return s.fetcher.Fetch(ctx, input.Date{Year: p.Year, Day: p.Day}, s.session)
+}
+
+func (s *Server) submit(ctx context.Context, p submitParams, notify func(Progress)) (submitResult, error) {
+	if p.Part != 1 && p.Part != 2 {
+		return submitResult{}, newError(CodeInvalidParams, "invalid part %d, expected 1 or 2", p.Part)
+	}
+
+	if p.Answer == "" {
+		return submitResult{}, newError(CodeInvalidParams, "answer is empty")
+	}
+
+	if _, err := puzzles.GetSolver(p.Year, p.Day); err != nil {
+		return submitResult{}, err
+	}
+
+	if s.submitter == nil || s.session == "" {
+		return submitResult{}, errNoSession
+	}
+
+	notify(Progress{
+		ID:        nil,
+		Stage:     StageSubmit,
+		Message:   fmt.Sprintf("Submitting answer of %s/%s part %d", p.Year, p.Day, p.Part),
+		Part:      strconv.Itoa(p.Part),
+		Answer:    "",
+		ElapsedNS: 0,
+	})
+
+	page, err := s.submitter.SubmitAnswer(ctx, input.Date{Year: p.Year, Day: p.Day}, strconv.Itoa(p.Part), p.Answer, s.session)
+	if err != nil {
+		return submitResult{}, err
+	}
+
+	v, err := input.ParseVerdict(page)
+	if err != nil {
+		return submitResult{}, err
+	}
+
+	return submitResult{
+		Status:  v.Status,
+		Message: v.Message,
+	}, nil
+}
+
+// toError converts error of method to error object of response.
+func toError(err error) *Error {
+	var rpcErr *Error
+
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+
+	var unlockErr *input.NotYetUnlockedError
+
+	code := CodeInternalError
+
+	switch {
+	case errors.Is(err, puzzles.ErrUnknownYear), errors.Is(err, puzzles.ErrUnknownDay),
+		errors.Is(err, input.ErrNotFound), errors.Is(err, fs.ErrNotExist):
+		code = CodeNotFound
+	case errors.Is(err, input.ErrUnauthorized), errors.Is(err, errNoSession):
+		code = CodeUnauthorized
+	case errors.As(err, &unlockErr):
+		code = CodeNotUnlocked
+	case errors.Is(err, errSolverFailed):
+		code = CodeSolverFailed
+	}
+
+	return newError(code, "%s", err.Error())
+}
@@ -0,0 +1,244 @@
+// Package rpc serves JSON-RPC 2.0 over a stream, e.g. stdin and stdout of editor plugin,
+// so solutions could be run without parsing CLI output. Each message is a single line of JSON,
+// batches are supported. Methods:
+//
+//	listSolvers {"year"?}                                           - registered puzzles: [{"year", "day", "title", "tags"}]
+//	solve       {"year", "day", "input"?, "path"?, "parts"?, "metrics"?} - result as returned by serve API
+//	fetchInput  {"year", "day"}                                      - puzzle input: {"input"}
+//	submit      {"year", "day", "part", "answer"}                    - verdict: {"status", "message"}
+//
+// Solve takes input as a string (e.g. editor buffer) or a file path, and fetches it when neither is passed.
+// While request is in progress, server sends progress notifications: {"id", "stage", "message", ...}.
+// Requests are handled concurrently, so responses could come in a different order.
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Version is a version of JSON-RPC protocol.
+const Version = "2.0"
+
+// Methods of the server.
+const (
+	MethodListSolvers = "listSolvers"
+	MethodSolve       = "solve"
+	MethodFetchInput  = "fetchInput"
+	MethodSubmit      = "submit"
+	// MethodProgress is a notification sent by server while request is in progress.
+	MethodProgress = "progress"
+)
+
+// Error codes: the standard ones of JSON-RPC 2.0 and ones of the application.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+
+	CodeSolverFailed = -32000
+	CodeNotFound     = -32001
+	CodeUnauthorized = -32002
+	CodeNotUnlocked  = -32003
+)
+
+// Error is an error object of response.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (%d)", e.Message, e.Code)
+}
+
+func newError(code int, format string, args ...any) *Error {
+	return &Error{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// request is a request or notification (without ID) of the client.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response holds either result or error. ID is null when request could not be parsed.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+type notification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+// Serve reads requests from r and writes responses and notifications to w until r is closed or ctx is done.
+// It returns after all requests in progress are answered.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	out := &writer{
+		mu:  sync.Mutex{},
+		enc: json.NewEncoder(w),
+	}
+
+	var wg sync.WaitGroup
+
+	defer wg.Wait()
+
+	br := bufio.NewReader(r)
+
+	for {
+		// Lines are not limited in size as input of the puzzle could be passed in request.
+		line, err := br.ReadBytes('\n')
+
+		if line = bytes.TrimSpace(line); len(line) != 0 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				if resp := s.handleMessage(ctx, out, line); resp != nil {
+					out.write(resp)
+				}
+			}()
+		}
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("read request: %w", err)
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// handleMessage handles single request or batch and returns what should be written back, nil for notifications.
+func (s *Server) handleMessage(ctx context.Context, out *writer, msg []byte) any {
+	if !json.Valid(msg) {
+		return errorResponse(nil, newError(CodeParseError, "invalid JSON"))
+	}
+
+	if msg[0] != '[' {
+		if resp := s.handle(ctx, out, msg); resp != nil {
+			return resp
+		}
+
+		return nil
+	}
+
+	var batch []json.RawMessage
+
+	if err := json.Unmarshal(msg, &batch); err != nil {
+		return errorResponse(nil, newError(CodeParseError, "parse batch: %v", err))
+	}
+
+	if len(batch) == 0 {
+		return errorResponse(nil, newError(CodeInvalidRequest, "empty batch"))
+	}
+
+	var res []*response
+
+	for _, m := range batch {
+		if resp := s.handle(ctx, out, m); resp != nil {
+			res = append(res, resp)
+		}
+	}
+
+	if len(res) == 0 {
+		return nil
+	}
+
+	return res
+}
+
+func (s *Server) handle(ctx context.Context, out *writer, msg []byte) *response {
+	var req request
+
+	if err := json.Unmarshal(msg, &req); err != nil {
+		// Message is valid JSON, but not a request object.
+		return errorResponse(nil, newError(CodeInvalidRequest, "parse request: %v", err))
+	}
+
+	if req.JSONRPC != Version || req.Method == "" {
+		return errorResponse(req.ID, newError(CodeInvalidRequest, "expected jsonrpc %q and method", Version))
+	}
+
+	// Notifications are not answered, progress of them is not reported either.
+	notify := func(progress Progress) {
+		if req.ID == nil {
+			return
+		}
+
+		progress.ID = req.ID
+
+		out.write(notification{
+			JSONRPC: Version,
+			Method:  MethodProgress,
+			Params:  progress,
+		})
+	}
+
+	res, err := s.call(ctx, req.Method, req.Params, notify)
+
+	if req.ID == nil {
+		return nil
+	}
+
+	if err != nil {
+		return errorResponse(req.ID, toError(err))
+	}
+
+	return &response{
+		JSONRPC: Version,
+		ID:      req.ID,
+		Result:  res,
+		Error:   nil,
+	}
+}
+
+func errorResponse(id json.RawMessage, err *Error) *response {
+	return &response{
+		JSONRPC: Version,
+		ID:      id,
+		Result:  nil,
+		Error:   err,
+	}
+}
+
+// writer writes messages one per line. Messages of concurrent requests are not interleaved.
+type writer struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (w *writer) write(v any) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Client has gone when output could not be written, there is no one to report error to.
+	_ = w.enc.Encode(v)
+}
@@ -0,0 +1,308 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/aocfake"
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/rpc"
+)
+
+// mockSolver answers part 1 with tally of input fields and panics on "panic" input.
+type mockSolver struct{}
+
+func (mockSolver) Year() string {
+	return "1992"
+}
+
+func (mockSolver) Day() string {
+	return "1"
+}
+
+func (mockSolver) Part1(in io.Reader) (string, error) {
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return "", err
+	}
+
+	if string(b) == "panic" {
+		panic("index out of range")
+	}
+
+	return tally(len(strings.Fields(string(b)))), nil
+}
+
+func (mockSolver) Part2(_ io.Reader) (string, error) {
+	return "p2", nil
+}
+
+func tally(n int) string {
+	return strings.Repeat("I", n)
+}
+
+type message struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpc.Error      `json:"error"`
+}
+
+func newServer(tb testing.TB, session string) *rpc.Server {
+	tb.Helper()
+
+	puzzles.Register(mockSolver{})
+
+	tb.Cleanup(func() {
+		puzzles.UnregisterAllSolvers(tb)
+	})
+
+	srv := aocfake.NewServer(tb, "testdata", aocfake.WithClock(func() time.Time {
+		return time.Date(1992, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}))
+
+	const timeout = 5 * time.Second
+
+	return rpc.NewServer(
+		rpc.WithFetcher(input.NewFetcher(http.DefaultClient, timeout, input.WithBaseURL(srv.URL))),
+		rpc.WithSubmitter(input.NewAnswerSubmitter(http.DefaultClient, timeout, input.WithBaseURL(srv.URL))),
+		rpc.WithSession(session),
+	)
+}
+
+// call sends requests to server and returns lines written back.
+func call(tb testing.TB, srv *rpc.Server, requests ...string) []string {
+	tb.Helper()
+
+	var out strings.Builder
+
+	err := srv.Serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")), &out)
+	require.NoError(tb, err)
+
+	return strings.Split(strings.TrimSpace(out.String()), "\n")
+}
+
+func decode(tb testing.TB, lines []string) []message {
+	tb.Helper()
+
+	res := make([]message, 0, len(lines))
+
+	for _, line := range lines {
+		var m message
+
+		require.NoError(tb, json.Unmarshal([]byte(line), &m), line)
+
+		res = append(res, m)
+	}
+
+	return res
+}
+
+// split returns the response and stages of progress notifications.
+func split(tb testing.TB, msgs []message) (message, []string) {
+	tb.Helper()
+
+	var stages []string
+
+	for _, m := range msgs[:len(msgs)-1] {
+		require.Equal(tb, rpc.MethodProgress, m.Method)
+
+		var p rpc.Progress
+
+		require.NoError(tb, json.Unmarshal(m.Params, &p))
+
+		stages = append(stages, p.Stage)
+	}
+
+	return msgs[len(msgs)-1], stages
+}
+
+func TestServer_methods(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(path, []byte("1\n2\n"), 0o600))
+
+	tests := []struct {
+		name       string
+		session    string
+		request    string
+		wantResult string
+		wantCode   int
+		wantStages []string
+	}{
+		{
+			name:       "list solvers",
+			session:    "",
+			request:    `{"jsonrpc":"2.0","id":1,"method":"listSolvers","params":{"year":"1992"}}`,
+			wantResult: `[{"year":"1992","day":"1","title":"","tags":[]}]`,
+			wantCode:   0,
+			wantStages: nil,
+		},
+		{
+			name:       "solve input",
+			session:    "",
+			request:    `{"jsonrpc":"2.0","id":1,"method":"solve","params":{"year":"1992","day":"1","input":"1 2 3"}}`,
+			wantResult: `{"year":"1992","day":"1","part1":"III","part2":"p2"}`,
+			wantCode:   0,
+			wantStages: []string{rpc.StageSolve, rpc.StagePart, rpc.StagePart},
+		},
+		{
+			name:       "solve path with parts",
+			session:    "",
+			request:    `{"jsonrpc":"2.0","id":1,"method":"solve","params":{"year":"1992","day":"1","path":"` + path + `","parts":[1]}}`,
+			wantResult: `{"year":"1992","day":"1","part1":"II","part2":""}`,
+			wantCode:   0,
+			wantStages: []string{rpc.StageSolve, rpc.StagePart},
+		},
+		{
+			name:       "solve fetched input",
+			session:    "alice-token",
+			request:    `{"jsonrpc":"2.0","id":1,"method":"solve","params":{"year":"1992","day":"1","parts":[2]}}`,
+			wantResult: `{"year":"1992","day":"1","part1":"","part2":"p2"}`,
+			wantCode:   0,
+			wantStages: []string{rpc.StageFetch, rpc.StageSolve, rpc.StagePart},
+		},
+		{
+			name:       "solve without session",
+			session:    "",
+			request:    `{"jsonrpc":"2.0","id":1,"method":"solve","params":{"year":"1992","day":"1"}}`,
+			wantResult: "",
+			wantCode:   rpc.CodeUnauthorized,
+			wantStages: nil,
+		},
+		{
+			name:       "solver panicked",
+			session:    "",
+			request:    `{"jsonrpc":"2.0","id":1,"method":"solve","params":{"year":"1992","day":"1","input":"panic"}}`,
+			wantResult: "",
+			wantCode:   rpc.CodeSolverFailed,
+			wantStages: []string{rpc.StageSolve},
+		},
+		{
+			name:       "unknown puzzle",
+			session:    "",
+			request:    `{"jsonrpc":"2.0","id":1,"method":"solve","params":{"year":"1992","day":"2","input":"1"}}`,
+			wantResult: "",
+			wantCode:   rpc.CodeNotFound,
+			wantStages: nil,
+		},
+		{
+			name:       "invalid part",
+			session:    "",
+			request:    `{"jsonrpc":"2.0","id":1,"method":"solve","params":{"year":"1992","day":"1","input":"1","parts":[3]}}`,
+			wantResult: "",
+			wantCode:   rpc.CodeInvalidParams,
+			wantStages: nil,
+		},
+		{
+			name:       "fetch input",
+			session:    "alice-token",
+			request:    `{"jsonrpc":"2.0","id":1,"method":"fetchInput","params":{"year":"1992","day":"1"}}`,
+			wantResult: `{"input":"1\n2\n3\n"}`,
+			wantCode:   0,
+			wantStages: []string{rpc.StageFetch},
+		},
+		{
+			name:       "submit",
+			session:    "alice-token",
+			request:    `{"jsonrpc":"2.0","id":1,"method":"submit","params":{"year":"1992","day":"1","part":1,"answer":"3"}}`,
+			wantResult: `{"status":"right","message":"That's the right answer! You are one gold star closer to saving your vacation."}`,
+			wantCode:   0,
+			wantStages: []string{rpc.StageSubmit},
+		},
+		{
+			name:       "unknown method",
+			session:    "",
+			request:    `{"jsonrpc":"2.0","id":1,"method":"run"}`,
+			wantResult: "",
+			wantCode:   rpc.CodeMethodNotFound,
+			wantStages: nil,
+		},
+		{
+			name:       "invalid request",
+			session:    "",
+			request:    `{"id":1,"method":"listSolvers"}`,
+			wantResult: "",
+			wantCode:   rpc.CodeInvalidRequest,
+			wantStages: nil,
+		},
+		{
+			name:       "not an object",
+			session:    "",
+			request:    `1`,
+			wantResult: "",
+			wantCode:   rpc.CodeInvalidRequest,
+			wantStages: nil,
+		},
+		{
+			name:       "parse error",
+			session:    "",
+			request:    `{"jsonrpc":"2.0","id":1,`,
+			wantResult: "",
+			wantCode:   rpc.CodeParseError,
+			wantStages: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, stages := split(t, decode(t, call(t, newServer(t, tt.session), tt.request)))
+
+			assert.Equal(t, tt.wantStages, stages)
+
+			if tt.wantCode != 0 {
+				require.NotNil(t, resp.Error)
+				assert.Equal(t, tt.wantCode, resp.Error.Code, resp.Error.Message)
+
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			assert.Equal(t, "1", string(resp.ID))
+			assert.JSONEq(t, tt.wantResult, string(resp.Result))
+		})
+	}
+}
+
+func TestServer_batch(t *testing.T) {
+	srv := newServer(t, "")
+
+	msgs := call(t, srv,
+		`[{"jsonrpc":"2.0","id":"a","method":"listSolvers","params":{"year":"1993"}},`+
+			`{"jsonrpc":"2.0","method":"solve","params":{"year":"1992","day":"1","input":"1"}},`+
+			`{"jsonrpc":"2.0","id":"b","method":"run"}]`,
+		`{"jsonrpc":"2.0","method":"listSolvers"}`,
+	)
+
+	require.Len(t, msgs, 1, "notifications are not answered and their progress is not reported")
+
+	var batch []message
+
+	require.NoError(t, json.Unmarshal([]byte(msgs[0]), &batch), "batch is answered with array")
+	require.Len(t, batch, 2)
+
+	assert.Equal(t, `"a"`, string(batch[0].ID))
+	assert.JSONEq(t, `[]`, string(batch[0].Result))
+	assert.Equal(t, `"b"`, string(batch[1].ID))
+	require.NotNil(t, batch[1].Error)
+	assert.Equal(t, rpc.CodeMethodNotFound, batch[1].Error.Code)
+
+	msgs = call(t, srv, `[1]`)
+	require.Len(t, msgs, 1)
+
+	require.NoError(t, json.Unmarshal([]byte(msgs[0]), &batch))
+	require.Len(t, batch, 1)
+	require.NotNil(t, batch[0].Error)
+	assert.Equal(t, rpc.CodeInvalidRequest, batch[0].Error.Code, "batch element that is not an object is invalid request")
+}
@@ -0,0 +1,252 @@
+package server
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+// contentTypeMetrics is a content type of Prometheus text exposition format.
+const contentTypeMetrics = "text/plain; version=0.0.4; charset=utf-8"
+
+// durationBuckets are upper bounds of solve duration histogram in seconds: from fast solutions to ones close to timeout.
+var durationBuckets = []float64{0.0001, 0.001, 0.01, 0.1, 0.5, 1, 5, 10, 30}
+
+type dayKey struct {
+	year string
+	day  string
+}
+
+type partKey struct {
+	dayKey
+	part string
+}
+
+type histogram struct {
+	// counts are not cumulative, they are summed on write.
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (h *histogram) observe(v float64) {
+	i, _ := slices.BinarySearch(durationBuckets, v)
+	if i < len(durationBuckets) {
+		h.counts[i]++
+	}
+
+	h.count++
+	h.sum += v
+}
+
+// collector accumulates metrics of solves fed by puzzles.Observer and writes them in Prometheus text format.
+type collector struct {
+	mu        sync.Mutex
+	solves    map[partKey]uint64
+	errs      map[partKey]uint64
+	durations map[partKey]*histogram
+	timeouts  map[dayKey]uint64
+	rejected  uint64
+}
+
+func newCollector() *collector {
+	return &collector{
+		mu:        sync.Mutex{},
+		solves:    make(map[partKey]uint64),
+		errs:      make(map[partKey]uint64),
+		durations: make(map[partKey]*histogram),
+		timeouts:  make(map[dayKey]uint64),
+		rejected:  0,
+	}
+}
+
+// observePart records part solved. Parts not implemented are not solves, so they are skipped.
+func (c *collector) observePart(m puzzles.PartMetric) {
+	if errors.Is(m.Err, puzzles.ErrNotImplemented) {
+		return
+	}
+
+	key := partKey{
+		dayKey: dayKey{
+			year: m.Year,
+			day:  m.Day,
+		},
+		part: m.Part,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.solves[key]++
+
+	if m.Err != nil {
+		c.errs[key]++
+	}
+
+	h, ok := c.durations[key]
+	if !ok {
+		h = &histogram{
+			counts: make([]uint64, len(durationBuckets)),
+			count:  0,
+			sum:    0,
+		}
+
+		c.durations[key] = h
+	}
+
+	h.observe(m.Elapsed.Seconds())
+}
+
+// panicked records solve of the part failed with panic, it is not seen by observer.
+func (c *collector) panicked(year, day, part string) {
+	key := partKey{
+		dayKey: dayKey{
+			year: year,
+			day:  day,
+		},
+		part: part,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.solves[key]++
+	c.errs[key]++
+}
+
+func (c *collector) timeout(year, day string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.timeouts[dayKey{
+		year: year,
+		day:  day,
+	}]++
+}
+
+func (c *collector) reject() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rejected++
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", contentTypeMetrics)
+
+	// Headers are sent, so error could not be reported to client.
+	_ = c.write(w)
+}
+
+// write writes metrics in Prometheus text exposition format.
+func (c *collector) write(w io.Writer) error {
+	var sb strings.Builder
+
+	c.mu.Lock()
+
+	parts := sortedKeys(c.solves, comparePartKeys)
+
+	writeHeader(&sb, "aoc_solves_total", "counter", "Number of puzzle parts solved, including failed ones.")
+
+	for _, k := range parts {
+		fmt.Fprintf(&sb, "aoc_solves_total{%s} %d\n", k.labels(), c.solves[k])
+	}
+
+	writeHeader(&sb, "aoc_solve_errors_total", "counter", "Number of puzzle parts failed with error or panic.")
+
+	for _, k := range parts {
+		fmt.Fprintf(&sb, "aoc_solve_errors_total{%s} %d\n", k.labels(), c.errs[k])
+	}
+
+	writeHeader(&sb, "aoc_solve_duration_seconds", "histogram", "Time spent by solver of puzzle part.")
+
+	for _, k := range sortedKeys(c.durations, comparePartKeys) {
+		h := c.durations[k]
+
+		var cumulative uint64
+
+		for i, le := range durationBuckets {
+			cumulative += h.counts[i]
+
+			fmt.Fprintf(&sb, "aoc_solve_duration_seconds_bucket{%s,le=%q} %d\n",
+				k.labels(), strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+		}
+
+		fmt.Fprintf(&sb, "aoc_solve_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", k.labels(), h.count)
+		fmt.Fprintf(&sb, "aoc_solve_duration_seconds_sum{%s} %s\n", k.labels(), strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&sb, "aoc_solve_duration_seconds_count{%s} %d\n", k.labels(), h.count)
+	}
+
+	writeHeader(&sb, "aoc_solve_timeouts_total", "counter", "Number of puzzle solves exceeded timeout.")
+
+	for _, k := range sortedKeys(c.timeouts, compareDayKeys) {
+		fmt.Fprintf(&sb, "aoc_solve_timeouts_total{%s} %d\n", k.labels(), c.timeouts[k])
+	}
+
+	writeHeader(&sb, "aoc_solves_rejected_total", "counter", "Number of puzzle solves rejected as all slots were busy.")
+	fmt.Fprintf(&sb, "aoc_solves_rejected_total %d\n", c.rejected)
+
+	c.mu.Unlock()
+
+	hits, misses := input.CacheStats()
+
+	writeHeader(&sb, "aoc_input_cache_hits_total", "counter", "Number of puzzle inputs found in cache.")
+	fmt.Fprintf(&sb, "aoc_input_cache_hits_total %d\n", hits)
+
+	writeHeader(&sb, "aoc_input_cache_misses_total", "counter", "Number of puzzle inputs not found in cache and fetched.")
+	fmt.Fprintf(&sb, "aoc_input_cache_misses_total %d\n", misses)
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	return nil
+}
+
+func writeHeader(sb *strings.Builder, name, typ, help string) {
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func (k dayKey) labels() string {
+	return fmt.Sprintf(`year="%s",day="%s"`, labelEscaper.Replace(k.year), labelEscaper.Replace(k.day))
+}
+
+func (k partKey) labels() string {
+	return fmt.Sprintf(`%s,part="%s"`, k.dayKey.labels(), labelEscaper.Replace(k.part))
+}
+
+func sortedKeys[K comparable, V any](m map[K]V, compare func(a, b K) int) []K {
+	keys := make([]K, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.SortFunc(keys, compare)
+
+	return keys
+}
+
+func compareDayKeys(a, b dayKey) int {
+	return cmp.Or(compareNumbers(a.year, b.year), compareNumbers(a.day, b.day))
+}
+
+func comparePartKeys(a, b partKey) int {
+	return cmp.Or(compareDayKeys(a.dayKey, b.dayKey), compareNumbers(a.part, b.part))
+}
+
+// compareNumbers compares numbers in decimal strings, so day 2 goes before day 10.
+func compareNumbers(a, b string) int {
+	return cmp.Or(cmp.Compare(len(a), len(b)), cmp.Compare(a, b))
+}
@@ -0,0 +1,363 @@
+// Package server exposes registered solvers over HTTP API:
+//
+//	GET  /healthz                 - health check: {"status": "ok"}
+//	GET  /years                   - years of registered solvers: ["2015", ...]
+//	GET  /years/{year}/days       - days of registered solvers of the year: ["1", ...]
+//	POST /solve/{year}/{day}      - solves input passed as request body, returns result with metrics;
+//	                                with empty body input of the session is fetched, when fetcher is set
+//	GET  /metrics                 - metrics of solves in Prometheus text exposition format
+//
+// Solve accepts metrics query parameter with comma separated metrics: elapsed (default), bench, none.
+// Errors are returned as {"error": "..."} with matching status code.
+//
+// Number of solves running at once, input size and solve time are limited, so bad input could not wedge the server.
+// Solvers could not be interrupted, so solver exceeded timeout keeps its slot until it returns, and requests
+// waiting for a free slot longer than timeout are rejected with 503.
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+const (
+	// DefaultTimeout is a default time limit of one solve.
+	DefaultTimeout = 30 * time.Second
+	// DefaultMaxInputSize is a default limit of input size in bytes.
+	DefaultMaxInputSize = 4 << 20
+
+	metricElapsed = "elapsed"
+	metricBench   = "bench"
+	metricNone    = "none"
+)
+
+var (
+	// ErrTimeout returns when solver exceeded timeout.
+	ErrTimeout = errors.New("solve timeout")
+	// ErrBusy returns when there is no free slot to solve.
+	ErrBusy = errors.New("too many solves in progress")
+	// ErrSolverPanic returns when solver panicked.
+	ErrSolverPanic = errors.New("solver panicked")
+	// ErrInputTooLarge returns when input exceeds size limit.
+	ErrInputTooLarge = errors.New("input too large")
+)
+
+// Option configures Handler.
+type Option func(h *Handler)
+
+// WithConcurrency sets number of solves running at once.
+func WithConcurrency(n int) Option {
+	return func(h *Handler) {
+		if n > 0 {
+			h.slots = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithTimeout sets time limit of one solve, including waiting for a free slot.
+func WithTimeout(d time.Duration) Option {
+	return func(h *Handler) {
+		if d > 0 {
+			h.timeout = d
+		}
+	}
+}
+
+// WithMaxInputSize sets limit of input size in bytes.
+func WithMaxInputSize(n int64) Option {
+	return func(h *Handler) {
+		if n > 0 {
+			h.maxInputSize = n
+		}
+	}
+}
+
+// WithFetcher sets inputs fetcher and session of the account used to solve requests without input.
+func WithFetcher(f input.Fetcher, session string) Option {
+	return func(h *Handler) {
+		h.fetcher = f
+		h.session = session
+	}
+}
+
+// Handler serves HTTP API of registered solvers.
+type Handler struct {
+	mux          *http.ServeMux
+	slots        chan struct{}
+	timeout      time.Duration
+	maxInputSize int64
+	metrics      *collector
+	fetcher      input.Fetcher
+	session      string
+}
+
+// NewHandler creates Handler.
+func NewHandler(opts ...Option) *Handler {
+	h := Handler{
+		mux:          http.NewServeMux(),
+		slots:        make(chan struct{}, runtime.NumCPU()),
+		timeout:      DefaultTimeout,
+		maxInputSize: DefaultMaxInputSize,
+		metrics:      newCollector(),
+		fetcher:      nil,
+		session:      "",
+	}
+
+	for _, opt := range opts {
+		opt(&h)
+	}
+
+	h.mux.HandleFunc("GET /healthz", h.serveHealth)
+	h.mux.HandleFunc("GET /years", h.serveYears)
+	h.mux.HandleFunc("GET /years/{year}/days", h.serveDays)
+	h.mux.HandleFunc("POST /solve/{year}/{day}", h.serveSolve)
+	h.mux.Handle("GET /metrics", h.metrics)
+
+	return &h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) serveHealth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (h *Handler) serveYears(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, puzzles.GetYears())
+}
+
+func (h *Handler) serveDays(w http.ResponseWriter, r *http.Request) {
+	days := puzzles.DaysByYear(r.PathValue("year"))
+	if len(days) == 0 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s: %w", r.PathValue("year"), puzzles.ErrUnknownYear))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, days)
+}
+
+func (h *Handler) serveSolve(w http.ResponseWriter, r *http.Request) {
+	s, err := puzzles.GetSolver(r.PathValue("year"), r.PathValue("day"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+
+		return
+	}
+
+	opts, err := runOptions(r.URL.Query().Get("metrics"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	in, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxInputSize))
+	if err != nil {
+		var mbe *http.MaxBytesError
+		if errors.As(err, &mbe) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("input exceeds %d bytes", mbe.Limit))
+
+			return
+		}
+
+		writeError(w, http.StatusBadRequest, fmt.Errorf("read input: %w", err))
+
+		return
+	}
+
+	// Fetch is limited by fetcher, timeout of the handler limits only solve.
+	if len(in) == 0 && h.fetcher != nil && h.session != "" {
+		in, err = h.fetcher.Fetch(r.Context(), input.Date{Year: s.Year(), Day: s.Day()}, h.session)
+		if err != nil {
+			writeError(w, fetchStatus(err), fmt.Errorf("fetch input: %w", err))
+
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	res, err := h.solve(ctx, s, in, opts)
+	if err != nil {
+		writeError(w, ErrorStatus(err), err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// Solve solves input of the puzzle within limits of the handler, as POST /solve does,
+// so other front ends share the limits and metrics with API.
+func (h *Handler) Solve(ctx context.Context, year, day string, in []byte, opts ...puzzles.RunOption) (puzzles.Result, error) {
+	s, err := puzzles.GetSolver(year, day)
+	if err != nil {
+		return puzzles.Result{}, err
+	}
+
+	if int64(len(in)) > h.maxInputSize {
+		return puzzles.Result{}, fmt.Errorf("%w: exceeds %d bytes", ErrInputTooLarge, h.maxInputSize)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	return h.solve(ctx, s, in, opts)
+}
+
+// MaxInputSize returns limit of input size in bytes.
+func (h *Handler) MaxInputSize() int64 {
+	return h.maxInputSize
+}
+
+// solve runs solver in a free slot. Slot is released when solver returns, even after timeout.
+func (h *Handler) solve(ctx context.Context, s puzzles.Solver, in []byte, opts []puzzles.RunOption) (puzzles.Result, error) {
+	select {
+	case h.slots <- struct{}{}:
+	case <-ctx.Done():
+		h.metrics.reject()
+
+		return puzzles.Result{}, ErrBusy
+	}
+
+	// Part is observed when solved, so panicked part is the one after the last observed.
+	var solved int
+
+	opts = append(opts, puzzles.WithObserver(func(m puzzles.PartMetric) {
+		solved++
+
+		h.metrics.observePart(m)
+	}))
+
+	type outcome struct {
+		res puzzles.Result
+		err error
+	}
+
+	done := make(chan outcome, 1)
+
+	go func() {
+		defer func() {
+			<-h.slots
+		}()
+
+		// Solver could panic on malformed input, it should not crash the server.
+		defer func() {
+			if p := recover(); p != nil {
+				h.metrics.panicked(s.Year(), s.Day(), strconv.Itoa(solved+1))
+
+				done <- outcome{
+					res: puzzles.Result{},
+					err: fmt.Errorf("%w: %v", ErrSolverPanic, p),
+				}
+			}
+		}()
+
+		res, err := puzzles.Solve(s, bytes.NewReader(in), opts...)
+
+		done <- outcome{
+			res: res,
+			err: err,
+		}
+	}()
+
+	select {
+	case o := <-done:
+		return o.res, o.err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			h.metrics.timeout(s.Year(), s.Day())
+
+			return puzzles.Result{}, fmt.Errorf("%w after %s", ErrTimeout, h.timeout)
+		}
+
+		return puzzles.Result{}, ctx.Err()
+	}
+}
+
+// runOptions parses metrics query parameter.
+func runOptions(metrics string) ([]puzzles.RunOption, error) {
+	if metrics == "" {
+		metrics = metricElapsed
+	}
+
+	var opts []puzzles.RunOption
+
+	for _, m := range strings.Split(metrics, ",") {
+		switch strings.TrimSpace(m) {
+		case metricElapsed:
+			opts = append(opts, puzzles.WithElapsed())
+		case metricBench:
+			opts = append(opts, puzzles.WithBenchmark())
+		case metricNone:
+		default:
+			return nil, fmt.Errorf("unknown metric %q, expected %s, %s or %s", m, metricElapsed, metricBench, metricNone)
+		}
+	}
+
+	return opts, nil
+}
+
+// ErrorStatus returns HTTP status of error of Handler.Solve, so other front ends answer with the same status as API.
+func ErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrInputTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrBusy):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrTimeout):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		// Client closed request, status is not seen by anyone.
+		return http.StatusRequestTimeout
+	default:
+		return http.StatusUnprocessableEntity
+	}
+}
+
+// fetchStatus returns status code of response to request failed to fetch input.
+func fetchStatus(err error) int {
+	var unlockErr *input.NotYetUnlockedError
+
+	switch {
+	case errors.Is(err, input.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, input.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.As(err, &unlockErr):
+		return http.StatusTooEarly
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	// Headers are sent, so error could not be reported to client.
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,340 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/server"
+)
+
+// mockSolver answers with sum of input lengths, blocks on "sleep" input and panics on "panic" input.
+type mockSolver struct {
+	year string
+	day  string
+}
+
+func (m mockSolver) Year() string {
+	return m.year
+}
+
+func (m mockSolver) Day() string {
+	return m.day
+}
+
+func (m mockSolver) Part1(in io.Reader) (string, error) {
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return "", err
+	}
+
+	switch string(b) {
+	case "sleep":
+		time.Sleep(200 * time.Millisecond)
+	case "panic":
+		panic("index out of range")
+	case "fail":
+		return "", errors.New("wrong input")
+	}
+
+	return string(b), nil
+}
+
+func (m mockSolver) Part2(_ io.Reader) (string, error) {
+	return "", puzzles.ErrNotImplemented
+}
+
+// fetcherMock answers with day of the puzzle, or with err when set.
+type fetcherMock struct {
+	calls int
+	err   error
+}
+
+func (f *fetcherMock) Fetch(_ context.Context, d input.Date, _ string) ([]byte, error) {
+	f.calls++
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return []byte(d.Day), nil
+}
+
+func newServer(tb testing.TB, opts ...server.Option) *httptest.Server {
+	tb.Helper()
+
+	for _, day := range []string{"1", "2"} {
+		puzzles.Register(mockSolver{
+			year: "1992",
+			day:  day,
+		})
+	}
+
+	srv := httptest.NewServer(server.NewHandler(opts...))
+
+	tb.Cleanup(func() {
+		srv.Close()
+		puzzles.UnregisterAllSolvers(tb)
+	})
+
+	return srv
+}
+
+func do(tb testing.TB, method, url, body string) (int, string) {
+	tb.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(tb, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(tb, err)
+
+	defer func() {
+		require.NoError(tb, resp.Body.Close())
+	}()
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(tb, err)
+
+	return resp.StatusCode, strings.TrimSpace(string(b))
+}
+
+func TestHandler(t *testing.T) {
+	srv := newServer(t, server.WithMaxInputSize(10), server.WithTimeout(100*time.Millisecond))
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "health",
+			method:     http.MethodGet,
+			path:       "/healthz",
+			body:       "",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"status":"ok"}`,
+		},
+		{
+			name:       "years",
+			method:     http.MethodGet,
+			path:       "/years",
+			body:       "",
+			wantStatus: http.StatusOK,
+			wantBody:   `["1992"]`,
+		},
+		{
+			name:       "days",
+			method:     http.MethodGet,
+			path:       "/years/1992/days",
+			body:       "",
+			wantStatus: http.StatusOK,
+			wantBody:   `["1","2"]`,
+		},
+		{
+			name:       "days of unknown year",
+			method:     http.MethodGet,
+			path:       "/years/1991/days",
+			body:       "",
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"error":"1991: unknown puzzle year"}`,
+		},
+		{
+			name:       "solve",
+			method:     http.MethodPost,
+			path:       "/solve/1992/1?metrics=none",
+			body:       "42",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"year":"1992","day":"1","part1":"42","part2":""}`,
+		},
+		{
+			name:       "solve unknown day",
+			method:     http.MethodPost,
+			path:       "/solve/1992/3",
+			body:       "42",
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"error":"3: unknown puzzle day"}`,
+		},
+		{
+			name:       "solve with GET",
+			method:     http.MethodGet,
+			path:       "/solve/1992/1",
+			body:       "",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "Method Not Allowed",
+		},
+		{
+			name:       "unknown metric",
+			method:     http.MethodPost,
+			path:       "/solve/1992/1?metrics=memory",
+			body:       "42",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"unknown metric \"memory\", expected elapsed, bench or none"}`,
+		},
+		{
+			name:       "input too large",
+			method:     http.MethodPost,
+			path:       "/solve/1992/1",
+			body:       "12345678901",
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `{"error":"input exceeds 10 bytes"}`,
+		},
+		{
+			name:       "solver failed",
+			method:     http.MethodPost,
+			path:       "/solve/1992/1",
+			body:       "fail",
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"error":"failed to add answers: failed to solve Part1: wrong input"}`,
+		},
+		{
+			name:       "solver panicked",
+			method:     http.MethodPost,
+			path:       "/solve/1992/1",
+			body:       "panic",
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"error":"solver panicked: index out of range"}`,
+		},
+		{
+			name:       "timeout",
+			method:     http.MethodPost,
+			path:       "/solve/1992/2",
+			body:       "sleep",
+			wantStatus: http.StatusGatewayTimeout,
+			wantBody:   `{"error":"solve timeout after 100ms"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := do(t, tt.method, srv.URL+tt.path, tt.body)
+
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
+
+func TestHandler_elapsed(t *testing.T) {
+	srv := newServer(t)
+
+	status, body := do(t, http.MethodPost, srv.URL+"/solve/1992/1", "42")
+	require.Equal(t, http.StatusOK, status, body)
+
+	var res struct {
+		Part1   string `json:"part1"`
+		Metrics struct {
+			ElapsedNS *int64 `json:"elapsed_ns"`
+		} `json:"metrics"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(body), &res))
+
+	assert.Equal(t, "42", res.Part1)
+	assert.NotNil(t, res.Metrics.ElapsedNS, "elapsed metric is on by default")
+}
+
+func TestHandler_busy(t *testing.T) {
+	srv := newServer(t, server.WithConcurrency(1), server.WithTimeout(50*time.Millisecond))
+
+	// Solver exceeded timeout holds the only slot until it returns.
+	status, _ := do(t, http.MethodPost, srv.URL+"/solve/1992/1", "sleep")
+	require.Equal(t, http.StatusGatewayTimeout, status)
+
+	status, body := do(t, http.MethodPost, srv.URL+"/solve/1992/1", "42")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, `{"error":"too many solves in progress"}`, body)
+
+	status, _ = do(t, http.MethodGet, srv.URL+"/healthz", "")
+	assert.Equal(t, http.StatusOK, status, "server is responsive while solver is stuck")
+
+	time.Sleep(200 * time.Millisecond)
+
+	status, _ = do(t, http.MethodPost, srv.URL+"/solve/1992/1", "42")
+	assert.Equal(t, http.StatusOK, status, "slot is released when solver returns")
+}
+
+func TestHandler_metrics(t *testing.T) {
+	srv := newServer(t, server.WithTimeout(50*time.Millisecond))
+
+	for _, in := range []string{"42", "42", "fail", "panic"} {
+		do(t, http.MethodPost, srv.URL+"/solve/1992/1", in)
+	}
+
+	status, _ := do(t, http.MethodPost, srv.URL+"/solve/1992/2", "sleep")
+	require.Equal(t, http.StatusGatewayTimeout, status)
+
+	// Solver exceeded timeout is observed when it returns.
+	time.Sleep(200 * time.Millisecond)
+
+	status, body := do(t, http.MethodGet, srv.URL+"/metrics", "")
+	require.Equal(t, http.StatusOK, status)
+
+	for _, line := range []string{
+		"# TYPE aoc_solves_total counter",
+		`aoc_solves_total{year="1992",day="1",part="1"} 4`,
+		`aoc_solves_total{year="1992",day="2",part="1"} 1`,
+		`aoc_solve_errors_total{year="1992",day="1",part="1"} 2`,
+		`aoc_solve_errors_total{year="1992",day="2",part="1"} 0`,
+		"# TYPE aoc_solve_duration_seconds histogram",
+		`aoc_solve_duration_seconds_bucket{year="1992",day="1",part="1",le="+Inf"} 3`,
+		`aoc_solve_duration_seconds_count{year="1992",day="1",part="1"} 3`,
+		`aoc_solve_duration_seconds_bucket{year="1992",day="2",part="1",le="0.1"} 0`,
+		`aoc_solve_duration_seconds_bucket{year="1992",day="2",part="1",le="0.5"} 1`,
+		`aoc_solve_timeouts_total{year="1992",day="2"} 1`,
+		"aoc_solves_rejected_total 0",
+		"# TYPE aoc_input_cache_hits_total counter",
+		"# TYPE aoc_input_cache_misses_total counter",
+	} {
+		assert.Contains(t, strings.Split(body, "\n"), line)
+	}
+
+	assert.NotContains(t, body, `part="2"`, "not implemented parts are not solves")
+}
+
+func TestHandler_fetch(t *testing.T) {
+	f := &fetcherMock{}
+
+	srv := newServer(t, server.WithFetcher(input.NewCachedFetcher(f, t.TempDir(), nil), "session"))
+
+	hits, misses := input.CacheStats()
+
+	for range 2 {
+		status, body := do(t, http.MethodPost, srv.URL+"/solve/1992/2?metrics=none", "")
+		require.Equal(t, http.StatusOK, status, body)
+		assert.Equal(t, `{"year":"1992","day":"2","part1":"2","part2":""}`, body)
+	}
+
+	assert.Equal(t, 1, f.calls, "input is fetched once and then read from cache")
+
+	status, body := do(t, http.MethodPost, srv.URL+"/solve/1992/1?metrics=none", "42")
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Equal(t, 1, f.calls, "passed input is not fetched")
+
+	status, body = do(t, http.MethodGet, srv.URL+"/metrics", "")
+	require.Equal(t, http.StatusOK, status)
+
+	lines := strings.Split(body, "\n")
+	assert.Contains(t, lines, fmt.Sprintf("aoc_input_cache_hits_total %d", hits+1))
+	assert.Contains(t, lines, fmt.Sprintf("aoc_input_cache_misses_total %d", misses+1))
+
+	f.err = input.ErrUnauthorized
+
+	status, _ = do(t, http.MethodPost, srv.URL+"/solve/1992/1", "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
@@ -0,0 +1,67 @@
+package session
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// Redacted replaces secrets in output.
+const Redacted = "[REDACTED]"
+
+var (
+	secretsMu sync.RWMutex
+	secrets   = make(map[string]struct{})
+)
+
+// Redact registers secret to be replaced in output of writers created by NewRedactingWriter.
+func Redact(secret string) {
+	if secret == "" {
+		return
+	}
+
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+
+	secrets[secret] = struct{}{}
+}
+
+// RedactString replaces registered secrets in s.
+func RedactString(s string) string {
+	return string(redact([]byte(s)))
+}
+
+func redact(p []byte) []byte {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+
+	for secret := range secrets {
+		p = bytes.ReplaceAll(p, []byte(secret), []byte(Redacted))
+	}
+
+	return p
+}
+
+type redactingWriter struct {
+	w io.Writer
+}
+
+// NewRedactingWriter returns writer that replaces registered secrets before writing to w.
+// Close is a no-op, so it is safe to wrap os.Stderr.
+func NewRedactingWriter(w io.Writer) io.WriteCloser {
+	return redactingWriter{
+		w: w,
+	}
+}
+
+func (r redactingWriter) Write(p []byte) (int, error) {
+	if _, err := r.w.Write(redact(p)); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (r redactingWriter) Close() error {
+	return nil
+}
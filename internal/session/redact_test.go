@@ -0,0 +1,35 @@
+package session_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/session"
+)
+
+func TestRedactingWriter(t *testing.T) {
+	const secret = "53616c7465645f5f-redact-test"
+
+	var buf strings.Builder
+
+	w := session.NewRedactingWriter(&buf)
+
+	n, err := w.Write([]byte("session=" + secret + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, len("session="+secret+"\n"), n)
+	assert.Equal(t, "session="+secret+"\n", buf.String(), "not registered secret is kept")
+
+	buf.Reset()
+
+	session.Redact(secret)
+
+	_, err = w.Write([]byte("session=" + secret + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "session="+session.Redacted+"\n", buf.String())
+
+	assert.Equal(t, "token "+session.Redacted, session.RedactString("token "+secret))
+	assert.NoError(t, w.Close())
+}
@@ -0,0 +1,176 @@
+// Package session manages Advent of Code sessions: named profiles storage, validation and redaction from logs.
+package session
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	dirPerms  fs.FileMode = 0o700
+	filePerms fs.FileMode = 0o600
+)
+
+var (
+	// ErrProfileNotFound returns when there is no profile with passed name.
+	ErrProfileNotFound = errors.New("session profile not found")
+	// ErrEmptyToken returns when session token is empty.
+	ErrEmptyToken = errors.New("empty session token")
+)
+
+// Store holds named session profiles, e.g. work and personal accounts.
+// It is persisted as YAML file readable only by owner, as it contains secrets.
+type Store struct {
+	path string
+
+	// Default is a name of profile used when no profile is passed explicitly.
+	Default string `yaml:"default,omitempty"`
+	// Profiles holds session tokens by profile name.
+	Profiles map[string]string `yaml:"profiles"`
+}
+
+// DefaultPath returns default path to sessions file: $XDG_CONFIG_HOME/aoc-cli/sessions.yaml.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("get user config dir: %w", err)
+	}
+
+	return filepath.Join(dir, "aoc-cli", "sessions.yaml"), nil
+}
+
+// Load reads store from file. Empty store is returned when file not exist.
+func Load(path string) (*Store, error) {
+	s := Store{
+		path:     path,
+		Default:  "",
+		Profiles: make(map[string]string),
+	}
+
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &s, nil
+		}
+
+		return nil, fmt.Errorf("read sessions file: %w", err)
+	}
+
+	if err = yaml.Unmarshal(content, &s); err != nil {
+		return nil, fmt.Errorf("decode sessions file: %w", err)
+	}
+
+	if s.Profiles == nil {
+		s.Profiles = make(map[string]string)
+	}
+
+	for _, token := range s.Profiles {
+		Redact(token)
+	}
+
+	return &s, nil
+}
+
+// Save writes store to file with 0600 permissions.
+func (s *Store) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), dirPerms); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+
+	content, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("encode sessions: %w", err)
+	}
+
+	if err = os.WriteFile(s.path, content, filePerms); err != nil {
+		return fmt.Errorf("write sessions file: %w", err)
+	}
+
+	// WriteFile does not change permissions of existing file.
+	if err = os.Chmod(s.path, filePerms); err != nil {
+		return fmt.Errorf("set sessions file permissions: %w", err)
+	}
+
+	return nil
+}
+
+// Path returns path to the store file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Set adds or replaces profile. The first added profile becomes default.
+func (s *Store) Set(name, token string) error {
+	if token == "" {
+		return ErrEmptyToken
+	}
+
+	s.Profiles[name] = token
+
+	if s.Default == "" {
+		s.Default = name
+	}
+
+	Redact(token)
+
+	return nil
+}
+
+// Get returns token of profile. Default profile is used when name is empty.
+func (s *Store) Get(name string) (string, error) {
+	if name == "" {
+		name = s.Default
+	}
+
+	token, ok := s.Profiles[name]
+	if !ok {
+		return "", fmt.Errorf("%q: %w", name, ErrProfileNotFound)
+	}
+
+	return token, nil
+}
+
+// SetDefault makes profile default.
+func (s *Store) SetDefault(name string) error {
+	if _, ok := s.Profiles[name]; !ok {
+		return fmt.Errorf("%q: %w", name, ErrProfileNotFound)
+	}
+
+	s.Default = name
+
+	return nil
+}
+
+// Remove deletes profile.
+func (s *Store) Remove(name string) error {
+	if _, ok := s.Profiles[name]; !ok {
+		return fmt.Errorf("%q: %w", name, ErrProfileNotFound)
+	}
+
+	delete(s.Profiles, name)
+
+	if s.Default == name {
+		s.Default = ""
+	}
+
+	return nil
+}
+
+// Names returns sorted list of profile names.
+func (s *Store) Names() []string {
+	list := make([]string, 0, len(s.Profiles))
+
+	for name := range s.Profiles {
+		list = append(list, name)
+	}
+
+	sort.Strings(list)
+
+	return list
+}
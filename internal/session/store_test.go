@@ -0,0 +1,70 @@
+package session_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/session"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aoc-cli", "sessions.yaml")
+
+	s, err := session.Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, s.Names())
+
+	_, err = s.Get("")
+	require.ErrorIs(t, err, session.ErrProfileNotFound)
+
+	require.ErrorIs(t, s.Set("work", ""), session.ErrEmptyToken)
+	require.NoError(t, s.Set("work", "work-token"))
+	require.NoError(t, s.Set("personal", "personal-token"))
+
+	assert.Equal(t, "work", s.Default, "first profile should become default")
+	assert.Equal(t, []string{"personal", "work"}, s.Names())
+
+	require.NoError(t, s.SetDefault("personal"))
+	require.ErrorIs(t, s.SetDefault("unknown"), session.ErrProfileNotFound)
+
+	require.NoError(t, s.Save())
+
+	stat, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), stat.Mode().Perm())
+
+	loaded, err := session.Load(path)
+	require.NoError(t, err)
+
+	token, err := loaded.Get("")
+	require.NoError(t, err)
+	assert.Equal(t, "personal-token", token)
+
+	token, err = loaded.Get("work")
+	require.NoError(t, err)
+	assert.Equal(t, "work-token", token)
+
+	require.NoError(t, loaded.Remove("personal"))
+	require.ErrorIs(t, loaded.Remove("personal"), session.ErrProfileNotFound)
+	assert.Empty(t, loaded.Default)
+}
+
+func TestStore_permissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte("profiles: {}\n"), 0o644))
+
+	s, err := session.Load(path)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Set("work", "token"))
+	require.NoError(t, s.Save())
+
+	stat, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), stat.Mode().Perm())
+}
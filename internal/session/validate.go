@@ -0,0 +1,94 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/obalunenko/advent-of-code/internal/htmlnode"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+// ErrInvalidSession returns when session is expired or invalid.
+var ErrInvalidSession = fmt.Errorf("invalid session: %w", input.ErrUnauthorized)
+
+// Account is an Advent of Code account session belongs to.
+type Account struct {
+	// Name is a display name, e.g. "(anonymous user #123)" for anonymous accounts.
+	Name string
+	// Stars is a number of stars obtained in the event.
+	Stars int
+	// Event is a year of the event page used for validation.
+	Event string
+}
+
+// Validate checks session by requesting the authenticated event calendar page
+// and returns account the session belongs to.
+func Validate(ctx context.Context, f input.CalendarFetcher, token string) (Account, error) {
+	if token == "" {
+		return Account{}, ErrEmptyToken
+	}
+
+	Redact(token)
+
+	year := input.LatestEvent(time.Now())
+
+	page, err := f.FetchCalendar(ctx, year, token)
+	if err != nil {
+		return Account{}, fmt.Errorf("fetch calendar: %w", err)
+	}
+
+	acc, err := ParseAccount(page)
+	if err != nil {
+		return Account{}, err
+	}
+
+	acc.Event = year
+
+	return acc, nil
+}
+
+// ParseAccount extracts logged-in account from adventofcode.com page:
+// <div class="user">Name <span class="star-count">42*</span></div>.
+func ParseAccount(page []byte) (Account, error) {
+	doc, err := html.Parse(bytes.NewReader(page))
+	if err != nil {
+		return Account{}, fmt.Errorf("parse page: %w", err)
+	}
+
+	user := htmlnode.Find(doc, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.DataAtom == atom.Div && htmlnode.HasClass(n, "user")
+	})
+	if user == nil {
+		return Account{}, ErrInvalidSession
+	}
+
+	var (
+		acc  Account
+		name strings.Builder
+	)
+
+	for c := user.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && htmlnode.HasClass(c, "star-count") {
+			acc.Stars, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(htmlnode.Text(c)), "*"))
+
+			continue
+		}
+
+		if c.Type == html.ElementNode && htmlnode.HasClass(c, "supporter-badge") {
+			continue
+		}
+
+		name.WriteString(htmlnode.Text(c))
+	}
+
+	acc.Name = strings.TrimSpace(name.String())
+
+	return acc, nil
+}
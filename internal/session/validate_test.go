@@ -0,0 +1,63 @@
+package session_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/session"
+)
+
+type calendarFetcherFunc func(ctx context.Context, year, session string) ([]byte, error)
+
+func (f calendarFetcherFunc) FetchCalendar(ctx context.Context, year, session string) ([]byte, error) {
+	return f(ctx, year, session)
+}
+
+const (
+	loggedIn = `<header><div><h1 class="title-global"><a href="/">Advent of Code</a></h1>` +
+		`<div class="user">Alice <span class="supporter-badge">(AoC++)</span> <span class="star-count">42*</span></div>` +
+		`</div></header>`
+	loggedOut = `<header><div><h1 class="title-global"><a href="/">Advent of Code</a></h1>` +
+		`<ul><li><a href="/2023/auth/login">[Log In]</a></li></ul></div></header>`
+)
+
+func TestParseAccount(t *testing.T) {
+	acc, err := session.ParseAccount([]byte(loggedIn))
+	require.NoError(t, err)
+
+	assert.Equal(t, session.Account{Name: "Alice", Stars: 42, Event: ""}, acc)
+
+	_, err = session.ParseAccount([]byte(loggedOut))
+	assert.ErrorIs(t, err, session.ErrInvalidSession)
+	assert.ErrorIs(t, err, input.ErrUnauthorized)
+}
+
+func TestValidate(t *testing.T) {
+	var gotSession string
+
+	f := calendarFetcherFunc(func(_ context.Context, _, sess string) ([]byte, error) {
+		gotSession = sess
+
+		if sess == "valid" {
+			return []byte(loggedIn), nil
+		}
+
+		return []byte(loggedOut), nil
+	})
+
+	acc, err := session.Validate(context.Background(), f, "valid")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", acc.Name)
+	assert.NotEmpty(t, acc.Event)
+	assert.Equal(t, "valid", gotSession)
+
+	_, err = session.Validate(context.Background(), f, "expired")
+	require.ErrorIs(t, err, session.ErrInvalidSession)
+
+	_, err = session.Validate(context.Background(), f, "")
+	require.ErrorIs(t, err, session.ErrEmptyToken)
+}
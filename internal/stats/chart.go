@@ -0,0 +1,159 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obalunenko/advent-of-code/internal/leaderboard"
+)
+
+const (
+	barWidth = 40
+
+	barPart1 = '#'
+	barPart2 = '='
+
+	over24h = ">24h"
+)
+
+// Summary of the year.
+type Summary struct {
+	Stars    int
+	Score    int
+	BestRank int
+}
+
+// Summary returns totals of the year.
+func (s Stats) Summary() Summary {
+	var sum Summary
+
+	for _, d := range s.Days {
+		for _, p := range []Part{d.Part1, d.Part2} {
+			if !p.Solved {
+				continue
+			}
+
+			sum.Stars++
+			sum.Score += p.Score
+
+			if sum.BestRank == 0 || p.Rank < sum.BestRank {
+				sum.BestRank = p.Rank
+			}
+		}
+	}
+
+	return sum
+}
+
+// Render writes ASCII bar charts of solve times and ranks per day of the year.
+// Part one bars are drawn with '#', part two bars with '='.
+func Render(w io.Writer, s Stats) error {
+	sum := s.Summary()
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s: %d stars, %d points, best rank %d\n\n", s.Year, sum.Stars, sum.Score, sum.BestRank)
+
+	sb.WriteString("Solve time (# part 1, = part 2)\n")
+
+	// Parts solved after 24 hours have no exact time, so they are drawn as full bars and not used for scale.
+	maxTime := maxOf(s, func(p Part) int {
+		return int(p.Time / time.Second)
+	})
+
+	for _, d := range s.Days {
+		writeBars(&sb, d, maxTime, func(p Part) (int, string) {
+			if p.Over24h {
+				return max(maxTime, 1), over24h
+			}
+
+			return int(p.Time / time.Second), leaderboard.FormatDuration(p.Time)
+		})
+	}
+
+	// Ranks differ by orders of magnitude, so log scale is used.
+	sb.WriteString("\nRank, log scale (# part 1, = part 2)\n")
+
+	maxRank := maxOf(s, func(p Part) int {
+		return logScale(p.Rank)
+	})
+
+	for _, d := range s.Days {
+		writeBars(&sb, d, maxRank, func(p Part) (int, string) {
+			return logScale(p.Rank), strconv.Itoa(p.Rank)
+		})
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("write chart: %w", err)
+	}
+
+	return nil
+}
+
+func maxOf(s Stats, value func(p Part) int) int {
+	var res int
+
+	for _, d := range s.Days {
+		for _, p := range []Part{d.Part1, d.Part2} {
+			if p.Solved && value(p) > res {
+				res = value(p)
+			}
+		}
+	}
+
+	return res
+}
+
+func writeBars(sb *strings.Builder, d Day, maxValue int, value func(p Part) (int, string)) {
+	for i, p := range []Part{d.Part1, d.Part2} {
+		label := "   "
+		if i == 0 {
+			label = fmt.Sprintf("%2d ", d.Day)
+		}
+
+		sym := barPart1
+		if i == 1 {
+			sym = barPart2
+		}
+
+		if !p.Solved {
+			fmt.Fprintf(sb, "%s|%s -\n", label, strings.Repeat(" ", barWidth))
+
+			continue
+		}
+
+		v, text := value(p)
+
+		fmt.Fprintf(sb, "%s|%-*s %s\n", label, barWidth, strings.Repeat(string(sym), barLen(v, maxValue)), text)
+	}
+}
+
+// barLen scales value to the bar width. Non-zero values always get at least one symbol.
+func barLen(v, maxValue int) int {
+	if maxValue == 0 || v <= 0 {
+		return 0
+	}
+
+	n := v * barWidth / maxValue
+	if n == 0 {
+		n = 1
+	}
+
+	return n
+}
+
+// logScale maps value to log10 scale, keeping value 1 visible.
+func logScale(v int) int {
+	const precision = 100
+
+	if v <= 0 {
+		return 0
+	}
+
+	return int(math.Log10(float64(v))*precision) + 1
+}
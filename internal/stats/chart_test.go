@@ -0,0 +1,24 @@
+package stats_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/stats"
+)
+
+func TestRender(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, stats.Render(&buf, testStats()))
+
+	want, err := os.ReadFile(filepath.Join("testdata", "chart.txt"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(want), buf.String())
+}
@@ -0,0 +1,179 @@
+// Package stats parses personal leaderboard statistics page of adventofcode.com (/{year}/leaderboard/self)
+// with solve times and ranks of each puzzle part, stores them locally and renders them as ASCII charts.
+package stats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/obalunenko/advent-of-code/internal/htmlnode"
+)
+
+// ErrNoStats returns when page has no statistics table, e.g. session is invalid.
+var ErrNoStats = errors.New("no personal statistics found on page")
+
+// Stats holds personal statistics of the year.
+type Stats struct {
+	Year string `json:"year"`
+	// Days sorted by day number.
+	Days []Day `json:"days"`
+}
+
+// Day holds statistics of both parts of the puzzle.
+type Day struct {
+	Day   int  `json:"day"`
+	Part1 Part `json:"part1"`
+	Part2 Part `json:"part2"`
+}
+
+// Part holds statistics of the puzzle part.
+type Part struct {
+	Solved bool `json:"solved"`
+	// Time since puzzle unlock. Zero when solved more than 24 hours after unlock.
+	Time time.Duration `json:"time"`
+	// Over24h is set when part was solved more than 24 hours after unlock, AoC does not report exact time then.
+	Over24h bool `json:"over_24h"`
+	Rank    int  `json:"rank"`
+	Score   int  `json:"score"`
+}
+
+var (
+	titleRe = regexp.MustCompile(`Advent of Code (\d{4})`)
+	rowRe   = regexp.MustCompile(`^\s*(\d+)\s+(\S+)\s+(\S+)\s+(\S+)(?:\s+(\S+)\s+(\S+)\s+(\S+))?\s*$`)
+)
+
+// Parse parses personal statistics page. When year is empty, it is taken from the page title.
+func Parse(r io.Reader, year string) (Stats, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return Stats{}, fmt.Errorf("parse page: %w", err)
+	}
+
+	if year == "" {
+		if title := htmlnode.Find(doc, htmlnode.Element(atom.Title)); title != nil {
+			if m := titleRe.FindStringSubmatch(htmlnode.Text(title)); m != nil {
+				year = m[1]
+			}
+		}
+	}
+
+	pre := htmlnode.Find(doc, htmlnode.Element(atom.Pre))
+	if pre == nil {
+		return Stats{}, ErrNoStats
+	}
+
+	days, err := parseTable(htmlnode.Text(pre))
+	if err != nil {
+		return Stats{}, err
+	}
+
+	return Stats{
+		Year: year,
+		Days: days,
+	}, nil
+}
+
+func parseTable(table string) ([]Day, error) {
+	var days []Day
+
+	sc := bufio.NewScanner(strings.NewReader(table))
+
+	for sc.Scan() {
+		m := rowRe.FindStringSubmatch(sc.Text())
+		if m == nil {
+			continue
+		}
+
+		day, err := strconv.Atoi(m[1])
+		if err != nil {
+			return nil, fmt.Errorf("parse day %q: %w", m[1], err)
+		}
+
+		d := Day{
+			Day:   day,
+			Part1: Part{},
+			Part2: Part{},
+		}
+
+		if d.Part1, err = parsePart(m[2], m[3], m[4]); err != nil {
+			return nil, fmt.Errorf("day %d part 1: %w", day, err)
+		}
+
+		if m[5] != "" {
+			if d.Part2, err = parsePart(m[5], m[6], m[7]); err != nil {
+				return nil, fmt.Errorf("day %d part 2: %w", day, err)
+			}
+		}
+
+		days = append(days, d)
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("scan table: %w", err)
+	}
+
+	if len(days) == 0 {
+		return nil, ErrNoStats
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Day < days[j].Day
+	})
+
+	return days, nil
+}
+
+func parsePart(t, rank, score string) (Part, error) {
+	const notSolved = "-"
+
+	if t == notSolved {
+		return Part{}, nil
+	}
+
+	p := Part{
+		Solved:  true,
+		Time:    0,
+		Over24h: false,
+		Rank:    0,
+		Score:   0,
+	}
+
+	var err error
+
+	if t == ">24h" {
+		p.Over24h = true
+	} else if p.Time, err = parseClock(t); err != nil {
+		return Part{}, err
+	}
+
+	if p.Rank, err = strconv.Atoi(rank); err != nil {
+		return Part{}, fmt.Errorf("parse rank %q: %w", rank, err)
+	}
+
+	if p.Score, err = strconv.Atoi(score); err != nil {
+		return Part{}, fmt.Errorf("parse score %q: %w", score, err)
+	}
+
+	return p, nil
+}
+
+// parseClock parses time in hh:mm:ss format.
+func parseClock(s string) (time.Duration, error) {
+	var h, m, sec int
+
+	if _, err := fmt.Sscanf(s, "%d:%d:%d", &h, &m, &sec); err != nil {
+		return 0, fmt.Errorf("parse time %q: %w", s, err)
+	}
+
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec)*time.Second, nil
+}
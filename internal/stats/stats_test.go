@@ -0,0 +1,84 @@
+package stats_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/common/utils"
+	"github.com/obalunenko/advent-of-code/internal/stats"
+)
+
+func part(t time.Duration, rank, score int) stats.Part {
+	return stats.Part{
+		Solved:  true,
+		Time:    t,
+		Over24h: false,
+		Rank:    rank,
+		Score:   score,
+	}
+}
+
+func clock(h, m, s int) time.Duration {
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+}
+
+func testStats() stats.Stats {
+	return stats.Stats{
+		Year: "2021",
+		Days: []stats.Day{
+			{Day: 1, Part1: part(clock(0, 2, 30), 150, 0), Part2: part(clock(0, 5, 0), 120, 0)},
+			{Day: 2, Part1: part(clock(0, 4, 5), 95, 6), Part2: part(clock(0, 6, 0), 80, 21)},
+			{Day: 3, Part1: part(clock(0, 10, 15), 2500, 0), Part2: part(clock(0, 45, 30), 3100, 0)},
+			{Day: 5, Part1: part(clock(1, 2, 3), 4321, 0), Part2: part(clock(1, 10, 0), 3500, 0)},
+			{
+				Day: 6,
+				Part1: stats.Part{
+					Solved:  true,
+					Time:    0,
+					Over24h: true,
+					Rank:    70211,
+					Score:   0,
+				},
+				Part2: stats.Part{},
+			},
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	got, err := stats.Parse(utils.ReaderFromFile(t, filepath.Join("testdata", "self.html")), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, testStats(), got)
+}
+
+func TestParse_noStats(t *testing.T) {
+	_, err := stats.Parse(utils.ReaderFromFile(t, filepath.Join("testdata", "login.html")), "2021")
+	assert.ErrorIs(t, err, stats.ErrNoStats)
+}
+
+func TestStats_Summary(t *testing.T) {
+	assert.Equal(t, stats.Summary{
+		Stars:    9,
+		Score:    27,
+		BestRank: 80,
+	}, testStats().Summary())
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, stats.Save(dir, testStats()))
+
+	got, err := stats.Load(dir, "2021")
+	require.NoError(t, err)
+
+	assert.Equal(t, testStats(), got)
+
+	_, err = stats.Load(dir, "2020")
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package stats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	log "github.com/obalunenko/logger"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+)
+
+const (
+	dirPerms  = 0o700
+	filePerms = 0o600
+)
+
+// DefaultDir returns directory for stored statistics in user cache dir.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("get user cache dir: %w", err)
+	}
+
+	return filepath.Join(dir, "aoc-cli", "stats"), nil
+}
+
+// Save stores statistics in dir as <year>.json.
+func Save(dir string, s Stats) error {
+	if err := os.MkdirAll(dir, dirPerms); err != nil {
+		return fmt.Errorf("create dir: %w", err)
+	}
+
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, s.Year+".json"), content, filePerms); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	return nil
+}
+
+// Load reads statistics of the year stored in dir.
+func Load(dir, year string) (Stats, error) {
+	content, err := os.ReadFile(filepath.Join(dir, year+".json"))
+	if err != nil {
+		return Stats{}, fmt.Errorf("read: %w", err)
+	}
+
+	var s Stats
+
+	if err = json.Unmarshal(content, &s); err != nil {
+		return Stats{}, fmt.Errorf("decode: %w", err)
+	}
+
+	return s, nil
+}
+
+// Client gets personal statistics from adventofcode.com and stores them locally.
+type Client struct {
+	f   input.StatsFetcher
+	dir string
+}
+
+// NewClient constructor for Client. Statistics are stored in passed dir.
+func NewClient(f input.StatsFetcher, dir string) *Client {
+	return &Client{
+		f:   f,
+		dir: dir,
+	}
+}
+
+// Get fetches statistics of the year and stores them. When fetch fails, stored statistics are returned if any.
+func (c *Client) Get(ctx context.Context, year, session string) (Stats, error) {
+	s, err := c.fetch(ctx, year, session)
+	if err != nil {
+		stored, lerr := Load(c.dir, year)
+		if lerr != nil {
+			return Stats{}, err
+		}
+
+		log.WithError(ctx, err).WithField("year", year).Warn("Failed to fetch statistics, stored ones are used")
+
+		return stored, nil
+	}
+
+	if err = Save(c.dir, s); err != nil {
+		log.WithError(ctx, err).Warn("Failed to store statistics")
+	}
+
+	return s, nil
+}
+
+func (c *Client) fetch(ctx context.Context, year, session string) (Stats, error) {
+	page, err := c.f.FetchStats(ctx, year, session)
+	if err != nil {
+		return Stats{}, fmt.Errorf("fetch statistics: %w", err)
+	}
+
+	s, err := Parse(bytes.NewReader(page), year)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	return s, nil
+}
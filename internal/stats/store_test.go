@@ -0,0 +1,52 @@
+package stats_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/aocfake"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/input"
+	"github.com/obalunenko/advent-of-code/internal/stats"
+)
+
+func TestClient_Get(t *testing.T) {
+	fixtures := t.TempDir()
+
+	page, err := os.ReadFile(filepath.Join("testdata", "self.html"))
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(fixtures, "2021", "leaderboard"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(fixtures, "2021", "leaderboard", "self"), page, 0o600))
+
+	srv := aocfake.NewServer(t, fixtures)
+
+	dir := t.TempDir()
+
+	client := stats.NewClient(input.NewStatsFetcher(http.DefaultClient, 5*time.Second, input.WithBaseURL(srv.URL)), dir)
+
+	got, err := client.Get(context.Background(), "2021", "token")
+	require.NoError(t, err)
+
+	assert.Equal(t, testStats(), got)
+
+	stored, err := stats.Load(dir, "2021")
+	require.NoError(t, err)
+
+	assert.Equal(t, testStats(), stored)
+
+	// Without session fake server redirects to login page, stored statistics are used.
+	got, err = client.Get(context.Background(), "2021", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, testStats(), got)
+
+	_, err = client.Get(context.Background(), "2020", "")
+	assert.ErrorIs(t, err, stats.ErrNoStats)
+}
@@ -0,0 +1,183 @@
+// Package vault encrypts puzzle inputs, so they could be stored in the repository without publishing them.
+//
+// Inputs are encrypted with AES-256-GCM. The key is a hex encoded 32 bytes value passed via AOC_VAULT_KEY env
+// or read from the file set in AOC_VAULT_KEY_FILE.
+package vault
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// EnvKey env variable name for hex encoded key.
+	EnvKey = "AOC_VAULT_KEY"
+	// EnvKeyFile env variable name for path to file with hex encoded key.
+	EnvKeyFile = "AOC_VAULT_KEY_FILE"
+	// Ext is an extension of encrypted files, e.g. input.txt.enc.
+	Ext = ".enc"
+
+	// KeySize is a size of the key in bytes.
+	KeySize = 32
+)
+
+// header marks encrypted content and its format version.
+var header = []byte("AOCVAULT1")
+
+var (
+	// ErrNoKey returns when key is not configured.
+	ErrNoKey = errors.New("vault key is not set")
+	// ErrInvalidKey returns when key could not be decoded or has wrong size.
+	ErrInvalidKey = errors.New("invalid vault key")
+	// ErrDecrypt returns when content is not encrypted by vault or key does not match.
+	ErrDecrypt = errors.New("failed to decrypt")
+)
+
+// Vault encrypts and decrypts content.
+type Vault struct {
+	aead cipher.AEAD
+}
+
+// New creates Vault from raw key of KeySize bytes.
+func New(key []byte) (*Vault, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w: want %d bytes, got %d", ErrInvalidKey, KeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create gcm: %w", err)
+	}
+
+	return &Vault{
+		aead: aead,
+	}, nil
+}
+
+// Parse creates Vault from hex encoded key.
+func Parse(key string) (*Vault, error) {
+	raw, err := hex.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	return New(raw)
+}
+
+// FromEnv creates Vault with the key from EnvKey or from the file set in EnvKeyFile.
+// Returns ErrNoKey when none of them is set.
+func FromEnv() (*Vault, error) {
+	if key := os.Getenv(EnvKey); key != "" {
+		return Parse(key)
+	}
+
+	fpath := os.Getenv(EnvKeyFile)
+	if fpath == "" {
+		return nil, ErrNoKey
+	}
+
+	key, err := os.ReadFile(filepath.Clean(fpath))
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+
+	return Parse(string(key))
+}
+
+// GenerateKey returns new random hex encoded key.
+func GenerateKey() (string, error) {
+	key := make([]byte, KeySize)
+
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("generate key: %w", err)
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
+// Seal encrypts content.
+func (v *Vault) Seal(plain []byte) ([]byte, error) {
+	nonce := make([]byte, v.aead.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(header)+len(nonce)+len(plain)+v.aead.Overhead())
+
+	out = append(out, header...)
+	out = append(out, nonce...)
+
+	return v.aead.Seal(out, nonce, plain, header), nil
+}
+
+// Open decrypts content encrypted by Seal.
+func (v *Vault) Open(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, header) {
+		return nil, fmt.Errorf("%w: not a vault content", ErrDecrypt)
+	}
+
+	data = data[len(header):]
+
+	ns := v.aead.NonceSize()
+	if len(data) < ns {
+		return nil, fmt.Errorf("%w: content is too short", ErrDecrypt)
+	}
+
+	plain, err := v.aead.Open(nil, data[:ns], data[ns:], header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecrypt, err)
+	}
+
+	return plain, nil
+}
+
+// EncryptFile encrypts file at fpath and writes it next to the original with Ext appended.
+// Returns path of encrypted file.
+func (v *Vault) EncryptFile(fpath string) (string, error) {
+	plain, err := os.ReadFile(filepath.Clean(fpath))
+	if err != nil {
+		return "", fmt.Errorf("read file: %w", err)
+	}
+
+	sealed, err := v.Seal(plain)
+	if err != nil {
+		return "", err
+	}
+
+	out := fpath + Ext
+
+	if err = os.WriteFile(out, sealed, 0o600); err != nil {
+		return "", fmt.Errorf("write encrypted file: %w", err)
+	}
+
+	return out, nil
+}
+
+// ReadFile reads and decrypts file at fpath.
+func (v *Vault) ReadFile(fpath string) ([]byte, error) {
+	sealed, err := os.ReadFile(filepath.Clean(fpath))
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	plain, err := v.Open(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fpath, err)
+	}
+
+	return plain, nil
+}
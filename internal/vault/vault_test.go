@@ -0,0 +1,172 @@
+package vault_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/vault"
+)
+
+const testKey = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+func newVault(tb testing.TB, key string) *vault.Vault {
+	tb.Helper()
+
+	v, err := vault.Parse(key)
+	require.NoError(tb, err)
+
+	return v
+}
+
+func TestVault_SealOpen(t *testing.T) {
+	v := newVault(t, testKey)
+
+	plain := []byte("1,2,3\n")
+
+	sealed, err := v.Seal(plain)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(sealed), string(plain))
+
+	got, err := v.Open(sealed)
+	require.NoError(t, err)
+
+	assert.Equal(t, plain, got)
+
+	another, err := v.Seal(plain)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, sealed, another, "nonce must be random")
+}
+
+func TestVault_Open_errors(t *testing.T) {
+	v := newVault(t, testKey)
+
+	sealed, err := v.Seal([]byte("secret"))
+	require.NoError(t, err)
+
+	otherKey, err := vault.GenerateKey()
+	require.NoError(t, err)
+
+	tampered := append([]byte(nil), sealed...)
+	tampered[len(tampered)-1] ^= 0xff
+
+	tests := []struct {
+		name  string
+		vault *vault.Vault
+		data  []byte
+	}{
+		{
+			name:  "wrong key",
+			vault: newVault(t, otherKey),
+			data:  sealed,
+		},
+		{
+			name:  "tampered",
+			vault: v,
+			data:  tampered,
+		},
+		{
+			name:  "not encrypted",
+			vault: v,
+			data:  []byte("1,2,3"),
+		},
+		{
+			name:  "truncated",
+			vault: v,
+			data:  sealed[:12],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.vault.Open(tt.data)
+			assert.ErrorIs(t, err, vault.ErrDecrypt)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "valid",
+			key:     testKey + "\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "not hex",
+			key:  "not a key",
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, vault.ErrInvalidKey)
+			},
+		},
+		{
+			name: "short",
+			key:  "0001",
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, vault.ErrInvalidKey)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := vault.Parse(tt.key)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv(vault.EnvKey, "")
+	t.Setenv(vault.EnvKeyFile, "")
+
+	_, err := vault.FromEnv()
+	require.ErrorIs(t, err, vault.ErrNoKey)
+
+	keyFile := filepath.Join(t.TempDir(), "vault.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(testKey+"\n"), 0o600))
+
+	t.Setenv(vault.EnvKeyFile, keyFile)
+
+	fromFile, err := vault.FromEnv()
+	require.NoError(t, err)
+
+	t.Setenv(vault.EnvKey, testKey)
+
+	fromEnv, err := vault.FromEnv()
+	require.NoError(t, err)
+
+	sealed, err := fromFile.Seal([]byte("secret"))
+	require.NoError(t, err)
+
+	got, err := fromEnv.Open(sealed)
+	require.NoError(t, err)
+
+	assert.Equal(t, "secret", string(got))
+}
+
+func TestVault_EncryptFile(t *testing.T) {
+	v := newVault(t, testKey)
+
+	fpath := filepath.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(fpath, []byte("1,2,3"), 0o600))
+
+	out, err := v.EncryptFile(fpath)
+	require.NoError(t, err)
+
+	assert.Equal(t, fpath+vault.Ext, out)
+
+	got, err := v.ReadFile(out)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1,2,3", string(got))
+}
@@ -0,0 +1,119 @@
+package watch
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const indent = "    "
+
+// Render writes outcome of round compared with the previous one: changed answers and timings.
+// Prev is nil for the first round. Changed are paths of files triggered the round.
+func Render(w io.Writer, prev *Round, cur Round, changed []string) error {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "[%s]", cur.Time.Format(time.TimeOnly))
+
+	if len(changed) != 0 {
+		names := make([]string, 0, len(changed))
+
+		for _, p := range changed {
+			names = append(names, filepath.Base(p))
+		}
+
+		fmt.Fprintf(&sb, " changed: %s", strings.Join(names, ", "))
+	}
+
+	sb.WriteString("\n")
+
+	switch {
+	case cur.BuildOutput != "":
+		sb.WriteString("build: FAIL\n")
+		writeIndented(&sb, cur.BuildOutput)
+	default:
+		sb.WriteString("build: ok\n")
+
+		if cur.TestsOutput != "" {
+			fmt.Fprintf(&sb, "examples: FAIL (%s)\n", round(cur.TestsElapsed))
+			writeIndented(&sb, cur.TestsOutput)
+		} else {
+			fmt.Fprintf(&sb, "examples: ok (%s)\n", round(cur.TestsElapsed))
+		}
+
+		if !cur.Solved {
+			sb.WriteString("input: FAIL\n")
+			writeIndented(&sb, cur.SolveOutput)
+
+			break
+		}
+
+		// Answers are compared with the last solved round.
+		if prev != nil && !prev.Solved {
+			prev = nil
+		}
+
+		var prevPart1, prevPart2 *string
+
+		if prev != nil {
+			prevPart1, prevPart2 = &prev.Part1, &prev.Part2
+		}
+
+		fmt.Fprintf(&sb, "part 1: %s\n", answerDiff(prevPart1, cur.Part1))
+		fmt.Fprintf(&sb, "part 2: %s\n", answerDiff(prevPart2, cur.Part2))
+
+		fmt.Fprintf(&sb, "elapsed: %s", round(cur.Elapsed))
+
+		if prev != nil && prev.Elapsed != 0 {
+			delta := cur.Elapsed - prev.Elapsed
+
+			fmt.Fprintf(&sb, " (was %s, %+.0f%%)", round(prev.Elapsed), float64(delta)/float64(prev.Elapsed)*100)
+		}
+
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	return nil
+}
+
+func answerDiff(prev *string, cur string) string {
+	if cur == "" {
+		cur = "-"
+	}
+
+	switch {
+	case prev == nil:
+		return cur
+	case *prev == cur || (*prev == "" && cur == "-"):
+		return cur + " (unchanged)"
+	case *prev == "":
+		return cur + " (was -)"
+	default:
+		return fmt.Sprintf("%s (was %s)", cur, *prev)
+	}
+}
+
+func writeIndented(sb *strings.Builder, s string) {
+	for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		sb.WriteString(indent + line + "\n")
+	}
+}
+
+func round(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(time.Microsecond)
+	default:
+		return d
+	}
+}
@@ -0,0 +1,164 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cliPkg is a path of aoc-cli main package relative to the repository root.
+const cliPkg = "./cmd/aoc-cli"
+
+// Runner rebuilds and runs solution of the puzzle in the repository at Root.
+type Runner struct {
+	// Root is a path of the repository root.
+	Root string
+	// Dir is a path of puzzle package directory, e.g. internal/puzzles/solutions/2021/day05.
+	Dir  string
+	Year string
+	Day  string
+	// Args are extra arguments of solve command, e.g. --profile work.
+	Args []string
+	// Env is an extra environment of solve command, e.g. AOC_SESSION=<token>.
+	Env []string
+}
+
+// Round is an outcome of one run.
+type Round struct {
+	Time time.Time
+	// BuildOutput holds compiler errors, empty when build succeeded.
+	BuildOutput string
+	// TestsOutput holds output of failed tests of the package, empty when tests passed.
+	TestsOutput  string
+	TestsElapsed time.Duration
+	// Solved reports whether solution was run on the real input, SolveOutput holds the error otherwise.
+	Solved      bool
+	SolveOutput string
+	Part1       string
+	Part2       string
+	Elapsed     time.Duration
+}
+
+// Run builds aoc-cli with the solution, runs tests of the puzzle package with examples and solves
+// the real input by the built binary. Tests and solving are skipped when build failed.
+func (r Runner) Run(ctx context.Context) Round {
+	round := Round{
+		Time:         time.Now(),
+		BuildOutput:  "",
+		TestsOutput:  "",
+		TestsElapsed: 0,
+		Solved:       false,
+		SolveOutput:  "",
+		Part1:        "",
+		Part2:        "",
+		Elapsed:      0,
+	}
+
+	tmp, err := os.MkdirTemp("", "aoc-watch-")
+	if err != nil {
+		round.BuildOutput = err.Error()
+
+		return round
+	}
+
+	defer func() {
+		_ = os.RemoveAll(tmp)
+	}()
+
+	bin := filepath.Join(tmp, "aoc-cli")
+
+	if out, err := r.command(ctx, "go", "build", "-o", bin, cliPkg).CombinedOutput(); err != nil {
+		round.BuildOutput = output(out, err)
+
+		return round
+	}
+
+	start := time.Now()
+
+	out, err := r.command(ctx, "go", "test", "-count=1", "./"+filepath.ToSlash(r.Dir)).CombinedOutput()
+	if err != nil {
+		round.TestsOutput = output(out, err)
+	}
+
+	round.TestsElapsed = time.Since(start)
+
+	args := append([]string{"-q", "solve", "--elapsed", "--format", "json"}, r.Args...)
+	args = append(args, r.Year+"/"+r.Day)
+
+	var stderr bytes.Buffer
+
+	cmd := r.command(ctx, bin, args...)
+	cmd.Env = append(os.Environ(), r.Env...)
+	cmd.Stderr = &stderr
+
+	out, err = cmd.Output()
+	if err != nil {
+		round.SolveOutput = output(stderr.Bytes(), err)
+
+		return round
+	}
+
+	if err = round.parse(out); err != nil {
+		round.SolveOutput = err.Error()
+
+		return round
+	}
+
+	round.Solved = true
+
+	return round
+}
+
+func (r Runner) command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = r.Root
+
+	return cmd
+}
+
+// parse parses output of solve command in JSON format.
+func (r *Round) parse(out []byte) error {
+	var results []struct {
+		Result struct {
+			Part1   string `json:"part1"`
+			Part2   string `json:"part2"`
+			Metrics struct {
+				ElapsedNS int64 `json:"elapsed_ns"`
+			} `json:"metrics"`
+		} `json:"result"`
+		Error string `json:"error"`
+	}
+
+	if err := json.Unmarshal(out, &results); err != nil {
+		return fmt.Errorf("decode solve output: %w", err)
+	}
+
+	if len(results) != 1 {
+		return fmt.Errorf("expected 1 result of solve, got %d", len(results))
+	}
+
+	res := results[0]
+	if res.Error != "" {
+		return errors.New(res.Error)
+	}
+
+	r.Part1, r.Part2 = res.Result.Part1, res.Result.Part2
+	r.Elapsed = time.Duration(res.Result.Metrics.ElapsedNS)
+
+	return nil
+}
+
+func output(out []byte, err error) string {
+	if s := strings.TrimSpace(string(out)); s != "" {
+		return s
+	}
+
+	return err.Error()
+}
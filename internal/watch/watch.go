@@ -0,0 +1,122 @@
+// Package watch re-runs puzzle solution on changes of its package: files are polled, so no file system
+// notifications support is needed, and solution is rebuilt and run by go tool in subprocesses.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultInterval is a default interval of files polling.
+const DefaultInterval = 500 * time.Millisecond
+
+// fileState is a state of file used to detect changes.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// Snapshot holds states of files by path.
+type Snapshot map[string]fileState
+
+// Take returns snapshot of regular files in dirs and their subdirectories.
+func Take(dirs ...string) (Snapshot, error) {
+	s := make(Snapshot)
+
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			// Editors write swap and backup files, e.g. .solution.go.swp and solution.go~.
+			if !d.Type().IsRegular() || isTemp(d.Name()) {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			s[path] = fileState{
+				size:    info.Size(),
+				modTime: info.ModTime(),
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walk %s: %w", dir, err)
+		}
+	}
+
+	return s, nil
+}
+
+// Changed returns sorted paths of files created, changed or removed since prev snapshot.
+func (s Snapshot) Changed(prev Snapshot) []string {
+	var changed []string
+
+	for path, st := range s {
+		if pst, ok := prev[path]; !ok || pst != st {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range prev {
+		if _, ok := s[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	slices.Sort(changed)
+
+	return changed
+}
+
+func isTemp(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~")
+}
+
+// Poll calls fn right away and then each time files in dirs are changed, until ctx is canceled.
+// Files are checked every interval; changes made while fn runs trigger the next call.
+// Passed changed paths are empty on the first call.
+func Poll(ctx context.Context, interval time.Duration, dirs []string, fn func(ctx context.Context, changed []string)) error {
+	prev, err := Take(dirs...)
+	if err != nil {
+		return err
+	}
+
+	fn(ctx, nil)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		cur, err := Take(dirs...)
+		if err != nil {
+			return err
+		}
+
+		changed := cur.Changed(prev)
+		if len(changed) == 0 {
+			continue
+		}
+
+		prev = cur
+
+		fn(ctx, changed)
+	}
+}
@@ -0,0 +1,124 @@
+package watch_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/watch"
+)
+
+func writeFile(tb testing.TB, path, content string) {
+	tb.Helper()
+
+	require.NoError(tb, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	require.NoError(tb, os.WriteFile(path, []byte(content), os.ModePerm))
+}
+
+func TestSnapshot_Changed(t *testing.T) {
+	dir := t.TempDir()
+
+	solution := filepath.Join(dir, "solution.go")
+	input := filepath.Join(dir, "testdata", "input.txt")
+	example := filepath.Join(dir, "testdata", "example_1.txt")
+
+	writeFile(t, solution, "package day05")
+	writeFile(t, input, "1,2")
+
+	prev, err := watch.Take(dir)
+	require.NoError(t, err)
+
+	writeFile(t, solution, "package day05\n")
+	writeFile(t, example, "1")
+	writeFile(t, filepath.Join(dir, ".solution.go.swp"), "swap")
+	writeFile(t, filepath.Join(dir, "solution.go~"), "backup")
+	require.NoError(t, os.Remove(input))
+
+	cur, err := watch.Take(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{solution, example, input}, cur.Changed(prev))
+	assert.Empty(t, cur.Changed(cur))
+}
+
+func TestPoll(t *testing.T) {
+	dir := t.TempDir()
+	solution := filepath.Join(dir, "solution.go")
+
+	writeFile(t, solution, "package day05")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var calls [][]string
+
+	err := watch.Poll(ctx, 10*time.Millisecond, []string{dir}, func(_ context.Context, changed []string) {
+		calls = append(calls, changed)
+
+		if len(calls) == 1 {
+			writeFile(t, solution, "package day05\n\nfunc init() {}\n")
+
+			return
+		}
+
+		cancel()
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{nil, {solution}}, calls)
+}
+
+func TestRender(t *testing.T) {
+	start := time.Date(2021, 12, 5, 10, 0, 0, 0, time.UTC)
+
+	first := watch.Round{
+		Time:         start,
+		BuildOutput:  "",
+		TestsOutput:  "",
+		TestsElapsed: 2 * time.Second,
+		Solved:       true,
+		SolveOutput:  "",
+		Part1:        "5306",
+		Part2:        "",
+		Elapsed:      20 * time.Millisecond,
+	}
+
+	var sb strings.Builder
+
+	require.NoError(t, watch.Render(&sb, nil, first, nil))
+	assert.Equal(t, "[10:00:00]\nbuild: ok\nexamples: ok (2s)\npart 1: 5306\npart 2: -\nelapsed: 20ms\n\n", sb.String())
+
+	second := first
+	second.Time = start.Add(time.Minute)
+	second.TestsOutput = "--- FAIL: TestPart2\nFAIL"
+	second.Part2 = "17787"
+	second.Elapsed = 10 * time.Millisecond
+
+	sb.Reset()
+	require.NoError(t, watch.Render(&sb, &first, second, []string{"/repo/day05/solution.go"}))
+	assert.Equal(t, "[10:01:00] changed: solution.go\nbuild: ok\nexamples: FAIL (2s)\n"+
+		"    --- FAIL: TestPart2\n    FAIL\n"+
+		"part 1: 5306 (unchanged)\npart 2: 17787 (was -)\nelapsed: 10ms (was 20ms, -50%)\n\n", sb.String())
+
+	broken := watch.Round{
+		Time:         start,
+		BuildOutput:  "solution.go:3:1: syntax error",
+		TestsOutput:  "",
+		TestsElapsed: 0,
+		Solved:       false,
+		SolveOutput:  "",
+		Part1:        "",
+		Part2:        "",
+		Elapsed:      0,
+	}
+
+	sb.Reset()
+	require.NoError(t, watch.Render(&sb, &second, broken, nil))
+	assert.Equal(t, "[10:00:00]\nbuild: FAIL\n    solution.go:3:1: syntax error\n\n", sb.String())
+}
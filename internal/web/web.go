@@ -0,0 +1,341 @@
+// Package web serves browser UI for picking registered puzzles, solving input pasted or uploaded
+// and reading puzzle description. Pages, styles and descriptions are embedded, so UI works offline.
+// Puzzles are solved by server.Handler, so they share limits and metrics with API which is served as well.
+package web
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/russross/blackfriday/v2"
+
+	"github.com/obalunenko/advent-of-code/internal/puzzles"
+	"github.com/obalunenko/advent-of-code/internal/puzzles/solutions"
+	"github.com/obalunenko/advent-of-code/internal/server"
+)
+
+const (
+	metricElapsed = "elapsed"
+	metricBench   = "bench"
+
+	// formOverhead is allowed size of form besides input: other fields and multipart headers.
+	formOverhead = 64 << 10
+)
+
+var (
+	//go:embed templates/*.html
+	templatesFS embed.FS
+
+	//go:embed static
+	staticFS embed.FS
+)
+
+// Handler serves web UI.
+type Handler struct {
+	mux   *http.ServeMux
+	api   *server.Handler
+	pages *template.Template
+	years []yearView
+}
+
+// NewHandler creates Handler solving puzzles by api. Requests not handled by UI are passed to api.
+func NewHandler(api *server.Handler) (*Handler, error) {
+	pages, err := template.New("").Funcs(template.FuncMap{
+		"has": slices.Contains[[]string],
+	}).ParseFS(templatesFS, "templates/*.html")
+	if err != nil {
+		return nil, fmt.Errorf("parse templates: %w", err)
+	}
+
+	static, err := fs.Sub(staticFS, "static")
+	if err != nil {
+		return nil, fmt.Errorf("static files: %w", err)
+	}
+
+	h := Handler{
+		mux:   http.NewServeMux(),
+		api:   api,
+		pages: pages,
+		years: makeYears(),
+	}
+
+	h.mux.HandleFunc("GET /{$}", h.serveIndex)
+	h.mux.HandleFunc("GET /puzzles/{year}/{day}", h.servePuzzle)
+	h.mux.HandleFunc("POST /puzzles/{year}/{day}", h.serveRun)
+	h.mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServerFS(static)))
+	h.mux.Handle("/", api)
+
+	return &h, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+type yearView struct {
+	Year string
+	Days []dayView
+}
+
+type dayView struct {
+	Year  string
+	Day   string
+	Title string
+}
+
+// resultView is a result of run shown under the form.
+type resultView struct {
+	Part1   string
+	Part2   string
+	Elapsed string
+	Bench   string
+}
+
+type pageData struct {
+	Years   []yearView
+	Puzzle  *dayView
+	Spec    template.HTML
+	Input   string
+	Metrics []string
+	Result  *resultView
+	Error   string
+}
+
+// makeYears lists registered puzzles with titles, latest year first.
+func makeYears() []yearView {
+	years := puzzles.GetYears()
+
+	res := make([]yearView, 0, len(years))
+
+	for _, year := range slices.Backward(years) {
+		days := puzzles.DaysByYear(year)
+
+		yv := yearView{
+			Year: year,
+			Days: make([]dayView, 0, len(days)),
+		}
+
+		for _, day := range days {
+			yv.Days = append(yv.Days, dayView{
+				Year:  year,
+				Day:   day,
+				Title: solutions.Title(year, day),
+			})
+		}
+
+		res = append(res, yv)
+	}
+
+	return res
+}
+
+func (h *Handler) serveIndex(w http.ResponseWriter, _ *http.Request) {
+	h.render(w, http.StatusOK, "index.html", pageData{
+		Years:   h.years,
+		Puzzle:  nil,
+		Spec:    "",
+		Input:   "",
+		Metrics: nil,
+		Result:  nil,
+		Error:   "",
+	})
+}
+
+func (h *Handler) servePuzzle(w http.ResponseWriter, r *http.Request) {
+	data, ok := h.puzzlePage(w, r)
+	if !ok {
+		return
+	}
+
+	data.Metrics = []string{metricElapsed}
+
+	h.render(w, http.StatusOK, "puzzle.html", data)
+}
+
+func (h *Handler) serveRun(w http.ResponseWriter, r *http.Request) {
+	data, ok := h.puzzlePage(w, r)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 2*h.api.MaxInputSize()+formOverhead)
+
+	in, err := readInput(r)
+	if err != nil {
+		data.Error = err.Error()
+
+		status := http.StatusBadRequest
+		if errors.Is(err, server.ErrInputTooLarge) {
+			status = server.ErrorStatus(err)
+		}
+
+		h.render(w, status, "puzzle.html", data)
+
+		return
+	}
+
+	data.Input = string(in)
+	data.Metrics = r.Form["metrics"]
+
+	var opts []puzzles.RunOption
+
+	if slices.Contains(data.Metrics, metricElapsed) {
+		opts = append(opts, puzzles.WithElapsed())
+	}
+
+	if slices.Contains(data.Metrics, metricBench) {
+		opts = append(opts, puzzles.WithBenchmark())
+	}
+
+	res, err := h.api.Solve(r.Context(), data.Puzzle.Year, data.Puzzle.Day, in, opts...)
+	if err != nil {
+		data.Error = err.Error()
+
+		h.render(w, server.ErrorStatus(err), "puzzle.html", data)
+
+		return
+	}
+
+	data.Result = makeResult(res)
+
+	h.render(w, http.StatusOK, "puzzle.html", data)
+}
+
+// puzzlePage returns page of requested puzzle, or renders not found page.
+func (h *Handler) puzzlePage(w http.ResponseWriter, r *http.Request) (pageData, bool) {
+	year, day := r.PathValue("year"), r.PathValue("day")
+
+	data := pageData{
+		Years:   h.years,
+		Puzzle:  nil,
+		Spec:    "",
+		Input:   "",
+		Metrics: nil,
+		Result:  nil,
+		Error:   "",
+	}
+
+	if _, err := puzzles.GetSolver(year, day); err != nil {
+		data.Error = fmt.Sprintf("%s/%s: %v", year, day, err)
+
+		h.render(w, http.StatusNotFound, "index.html", data)
+
+		return pageData{}, false
+	}
+
+	data.Puzzle = &dayView{
+		Year:  year,
+		Day:   day,
+		Title: solutions.Title(year, day),
+	}
+
+	if spec, err := solutions.Spec(year, day); err == nil {
+		data.Spec = renderSpec(spec)
+	}
+
+	return data, true
+}
+
+// readInput returns uploaded file when it is chosen, text of the input field otherwise.
+func readInput(r *http.Request) ([]byte, error) {
+	if err := r.ParseMultipartForm(formOverhead); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		var mbe *http.MaxBytesError
+		if errors.As(err, &mbe) {
+			return nil, fmt.Errorf("%w: form exceeds %d bytes", server.ErrInputTooLarge, mbe.Limit)
+		}
+
+		return nil, fmt.Errorf("parse form: %w", err)
+	}
+
+	f, _, err := r.FormFile("file")
+	if err != nil && !errors.Is(err, http.ErrMissingFile) && !errors.Is(err, http.ErrNotMultipart) {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	if f != nil {
+		defer func() {
+			_ = f.Close()
+		}()
+
+		in, err := io.ReadAll(f)
+		if err != nil {
+			return nil, fmt.Errorf("read file: %w", err)
+		}
+
+		if len(in) != 0 {
+			return in, nil
+		}
+	}
+
+	// Browsers submit text fields with CRLF line breaks, while puzzle inputs use LF.
+	in := strings.ReplaceAll(r.FormValue("input"), "\r\n", "\n")
+	if in == "" {
+		return nil, errors.New("input is empty: paste it or choose a file")
+	}
+
+	return []byte(in), nil
+}
+
+func makeResult(res puzzles.Result) *resultView {
+	rv := resultView{
+		Part1:   res.Part1,
+		Part2:   res.Part2,
+		Elapsed: "",
+		Bench:   "",
+	}
+
+	if d, ok := res.Elapsed(); ok {
+		rv.Elapsed = d.String()
+	}
+
+	if b, ok := res.Benchmark(); ok {
+		rv.Bench = fmt.Sprintf("%d runs, %d ns/op, %d bytes/op, %d allocs/op",
+			b.N, b.NsPerOp(), b.AllocedBytesPerOp(), b.AllocsPerOp())
+	}
+
+	return &rv
+}
+
+// renderSpec converts spec.md to HTML. Raw HTML of markdown is skipped, so only markdown markup is rendered.
+func renderSpec(spec []byte) template.HTML {
+	renderer := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		AbsolutePrefix:             "",
+		FootnoteAnchorPrefix:       "",
+		FootnoteReturnLinkContents: "",
+		HeadingIDPrefix:            "",
+		HeadingIDSuffix:            "",
+		HeadingLevelOffset:         1,
+		Title:                      "",
+		CSS:                        "",
+		Icon:                       "",
+		Flags:                      blackfriday.CommonHTMLFlags | blackfriday.SkipHTML | blackfriday.Safelink,
+	})
+
+	//nolint:gosec // HTML is rendered from embedded spec.md with raw HTML skipped.
+	return template.HTML(blackfriday.Run(spec, blackfriday.WithRenderer(renderer)))
+}
+
+func (h *Handler) render(w http.ResponseWriter, status int, page string, data pageData) {
+	var buf bytes.Buffer
+
+	if err := h.pages.ExecuteTemplate(&buf, page, data); err != nil {
+		http.Error(w, fmt.Sprintf("render %s: %v", page, err), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+
+	// Headers are sent, so error could not be reported to client.
+	_, _ = buf.WriteTo(w)
+}
@@ -0,0 +1,212 @@
+package web_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obalunenko/advent-of-code/internal/server"
+	"github.com/obalunenko/advent-of-code/internal/web"
+)
+
+const example = "199\r\n200\r\n208\r\n210\r\n200\r\n207\r\n240\r\n269\r\n260\r\n263\r\n"
+
+func newServer(tb testing.TB) *httptest.Server {
+	tb.Helper()
+
+	h, err := web.NewHandler(server.NewHandler(server.WithMaxInputSize(1 << 10)))
+	require.NoError(tb, err)
+
+	srv := httptest.NewServer(h)
+
+	tb.Cleanup(srv.Close)
+
+	return srv
+}
+
+func read(tb testing.TB, resp *http.Response) (int, string) {
+	tb.Helper()
+
+	defer func() {
+		require.NoError(tb, resp.Body.Close())
+	}()
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(tb, err)
+
+	return resp.StatusCode, string(b)
+}
+
+func TestHandler_pages(t *testing.T) {
+	srv := newServer(t)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		want       []string
+	}{
+		{
+			name:       "index",
+			path:       "/",
+			wantStatus: http.StatusOK,
+			want:       []string{`<summary>2021</summary>`, `href="/puzzles/2021/1"`, "Sonar Sweep"},
+		},
+		{
+			name:       "puzzle",
+			path:       "/puzzles/2021/1",
+			wantStatus: http.StatusOK,
+			want: []string{
+				"<h1>2021 day 1: Sonar Sweep</h1>",
+				`<details open>`,
+				`class="selected"`,
+				`value="elapsed" checked`,
+				"<h2>&mdash; Day 1: Sonar Sweep &mdash;</h2>",
+			},
+		},
+		{
+			name:       "unknown puzzle",
+			path:       "/puzzles/2021/26",
+			wantStatus: http.StatusNotFound,
+			want:       []string{`<p class="error">2021/26: 26: unknown puzzle day</p>`},
+		},
+		{
+			name:       "styles",
+			path:       "/static/style.css",
+			wantStatus: http.StatusOK,
+			want:       []string{"body {"},
+		},
+		{
+			name:       "api",
+			path:       "/healthz",
+			wantStatus: http.StatusOK,
+			want:       []string{`{"status":"ok"}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + tt.path)
+			require.NoError(t, err)
+
+			status, body := read(t, resp)
+
+			assert.Equal(t, tt.wantStatus, status)
+
+			for _, s := range tt.want {
+				assert.Contains(t, body, s)
+			}
+		})
+	}
+}
+
+func TestHandler_run(t *testing.T) {
+	srv := newServer(t)
+
+	upload := func(t *testing.T, file string) (string, io.Reader) {
+		t.Helper()
+
+		var buf bytes.Buffer
+
+		w := multipart.NewWriter(&buf)
+
+		fw, err := w.CreateFormFile("file", "input.txt")
+		require.NoError(t, err)
+
+		_, err = fw.Write([]byte(file))
+		require.NoError(t, err)
+
+		require.NoError(t, w.WriteField("input", "ignored when file is chosen"))
+		require.NoError(t, w.WriteField("metrics", "elapsed"))
+		require.NoError(t, w.Close())
+
+		return w.FormDataContentType(), &buf
+	}
+
+	form := func(_ *testing.T, values url.Values) (string, io.Reader) {
+		return "application/x-www-form-urlencoded", strings.NewReader(values.Encode())
+	}
+
+	tests := []struct {
+		name       string
+		body       func(t *testing.T) (string, io.Reader)
+		wantStatus int
+		want       []string
+		notWant    []string
+	}{
+		{
+			name: "text input",
+			body: func(t *testing.T) (string, io.Reader) {
+				return form(t, url.Values{"input": {example}})
+			},
+			wantStatus: http.StatusOK,
+			want:       []string{"<dt>Part 1</dt><dd>7</dd>", "<dt>Part 2</dt><dd>5</dd>"},
+			notWant:    []string{"<dt>Elapsed</dt>", `value="elapsed" checked`},
+		},
+		{
+			name: "file input",
+			body: func(t *testing.T) (string, io.Reader) {
+				return upload(t, strings.ReplaceAll(example, "\r\n", "\n"))
+			},
+			wantStatus: http.StatusOK,
+			want:       []string{"<dt>Part 1</dt><dd>7</dd>", "<dt>Elapsed</dt>", `value="elapsed" checked`},
+			notWant:    []string{"ignored when file is chosen"},
+		},
+		{
+			name: "empty input",
+			body: func(t *testing.T) (string, io.Reader) {
+				return form(t, url.Values{"input": {""}})
+			},
+			wantStatus: http.StatusBadRequest,
+			want:       []string{`<p class="error">input is empty: paste it or choose a file</p>`},
+			notWant:    []string{"<dt>Part 1</dt>"},
+		},
+		{
+			name: "input too large",
+			body: func(t *testing.T) (string, io.Reader) {
+				return form(t, url.Values{"input": {strings.Repeat("1\n", 1<<10)}})
+			},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			want:       []string{`<p class="error">input too large: exceeds 1024 bytes</p>`},
+			notWant:    []string{"<dt>Part 1</dt>"},
+		},
+		{
+			name: "solver failed",
+			body: func(t *testing.T) (string, io.Reader) {
+				return form(t, url.Values{"input": {"not a number"}})
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			want:       []string{`<p class="error">`},
+			notWant:    []string{"<dt>Part 1</dt>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, body := tt.body(t)
+
+			resp, err := http.Post(srv.URL+"/puzzles/2021/1", contentType, body)
+			require.NoError(t, err)
+
+			status, got := read(t, resp)
+
+			assert.Equal(t, tt.wantStatus, status)
+
+			for _, s := range tt.want {
+				assert.Contains(t, got, s)
+			}
+
+			for _, s := range tt.notWant {
+				assert.NotContains(t, got, s)
+			}
+		})
+	}
+}
@@ -9,9 +9,8 @@ SCRIPTS_DIR="${REPO_ROOT}/scripts"
 
 source "${SCRIPTS_DIR}/helpers-source.sh"
 
-cd ${REPO_ROOT}/internal/puzzles/solutions || exit 1
+cd ${REPO_ROOT} || exit 1
 
-go test -v -run Test_createNewFromTemplate
+go run ./cmd/aoc-cli new --root "${REPO_ROOT}" "${AOC_PUZZLE_URL}"
 
 echo "${SCRIPT_NAME} done."
-
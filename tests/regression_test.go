@@ -4,11 +4,13 @@
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/obalunenko/getenv"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/obalunenko/advent-of-code/internal/aocfake"
 	"github.com/obalunenko/advent-of-code/internal/command"
 	"github.com/obalunenko/advent-of-code/internal/puzzles"
 	_ "github.com/obalunenko/advent-of-code/internal/puzzles/solutions" // register puzzles solvers.
@@ -28,6 +30,13 @@ type testcase struct {
 
 const (
 	regressionEnabled = "AOC_REGRESSION_ENABLED"
+	// fakeFixtures is a directory of aocfake fixtures. When set, inputs are served by local fake server
+	// instead of adventofcode.com.
+	fakeFixtures = "AOC_FAKE_FIXTURES"
+	// fakeSession is used with fake server when session is not set.
+	fakeSession = "fake"
+	// solveTimeout is a time limit for solving one puzzle.
+	solveTimeout = 10 * time.Minute
 )
 
 // Regression tests for all puzzles. Check that answers still correct.
@@ -36,12 +45,25 @@ func TestRun(t *testing.T) {
 		t.Skipf("%s disabled", regressionEnabled)
 	}
 
+	ctx := context.Background()
+
 	session := getenv.EnvOrDefault(puzzles.AOCSession, "")
+
+	if dir := getenv.EnvOrDefault(fakeFixtures, ""); dir != "" {
+		srv := aocfake.NewServer(t, dir)
+
+		ctx = command.ContextWithBaseURL(ctx, srv.URL)
+
+		if session == "" {
+			session = fakeSession
+		}
+	}
+
 	if session == "" {
 		t.Fatalf("%s not set", puzzles.AOCSession)
 	}
 
-	ctx := command.ContextWithSession(context.Background(), session)
+	ctx = command.ContextWithSession(ctx, session)
 
 	var tests []testcase
 
@@ -56,24 +78,51 @@ func TestRun(t *testing.T) {
 	tests = append(tests, testcases2022(t)...)
 	tests = append(tests, testcases2023(t)...)
 
+	rep := newReporter(t)
+
 	for i := range tests {
 		tt := tests[i]
 
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := command.Run(ctx, tt.args.year, tt.args.name)
 			if tt.wantErr {
+				_, err := command.Run(ctx, tt.args.year, tt.args.name)
 				require.Error(t, err)
 
 				return
 			}
 
-			require.NoError(t, err)
+			rep.start(t, tt.args.year, tt.args.name)
+
+			got := solve(t, ctx, tt.args.year, tt.args.name)
+
+			rep.finish(t, got, command.Answers{
+				Part1: tt.want.Part1,
+				Part2: tt.want.Part2,
+			})
 
-			assert.Equal(t, tt.want, got)
+			require.NoError(t, got.Err)
+
+			assert.Equal(t, tt.want, got.Result)
 		})
 	}
 }
 
+// solve runs puzzle solving measuring time spent on each part.
+func solve(tb testing.TB, ctx context.Context, year, day string) command.BatchResult {
+	tb.Helper()
+
+	s, err := puzzles.GetSolver(year, day)
+	require.NoError(tb, err)
+
+	results, err := command.RunBatch(ctx, []puzzles.Solver{s}, command.WithWorkers(1), command.WithTimeout(solveTimeout))
+	require.NoError(tb, err)
+
+	res, ok := <-results
+	require.True(tb, ok, "no result")
+
+	return res
+}
+
 func invalid() []testcase {
 	return []testcase{
 		{
@@ -0,0 +1,102 @@
+package tests_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/obalunenko/getenv"
+
+	"github.com/obalunenko/advent-of-code/internal/command"
+	"github.com/obalunenko/advent-of-code/internal/report"
+)
+
+const (
+	// reportJUnit is a path of JUnit XML report of regression run.
+	reportJUnit = "AOC_REPORT_JUNIT"
+	// reportEvents is a path of NDJSON events of regression run.
+	reportEvents = "AOC_REPORT_EVENTS"
+)
+
+// reporter writes regression results to reports requested by env.
+type reporter struct {
+	mu     sync.Mutex
+	cases  []report.Case
+	events *report.EventWriter
+}
+
+func newReporter(tb testing.TB) *reporter {
+	tb.Helper()
+
+	r := reporter{
+		mu:     sync.Mutex{},
+		cases:  nil,
+		events: nil,
+	}
+
+	if fpath := getenv.EnvOrDefault(reportEvents, ""); fpath != "" {
+		f := createReport(tb, fpath)
+
+		r.events = report.NewEventWriter(f)
+	}
+
+	if fpath := getenv.EnvOrDefault(reportJUnit, ""); fpath != "" {
+		tb.Cleanup(func() {
+			f := createReport(tb, fpath)
+
+			if err := report.WriteJUnit(f, "regression", r.cases); err != nil {
+				tb.Errorf("write junit report: %v", err)
+			}
+		})
+	}
+
+	return &r
+}
+
+func createReport(tb testing.TB, fpath string) *os.File {
+	tb.Helper()
+
+	f, err := os.Create(filepath.Clean(fpath))
+	if err != nil {
+		tb.Fatalf("create report: %v", err)
+	}
+
+	tb.Cleanup(func() {
+		if err = f.Close(); err != nil {
+			tb.Errorf("close report: %v", err)
+		}
+	})
+
+	return f
+}
+
+func (r *reporter) start(tb testing.TB, year, day string) {
+	tb.Helper()
+
+	if r.events == nil {
+		return
+	}
+
+	if err := r.events.Start(year, day); err != nil {
+		tb.Errorf("write event: %v", err)
+	}
+}
+
+func (r *reporter) finish(tb testing.TB, res command.BatchResult, expected command.Answers) {
+	tb.Helper()
+
+	cases := res.Cases(expected)
+
+	r.mu.Lock()
+	r.cases = append(r.cases, cases...)
+	r.mu.Unlock()
+
+	if r.events == nil {
+		return
+	}
+
+	if err := r.events.Finish(res.Year, res.Day, res.Elapsed, cases); err != nil {
+		tb.Errorf("write event: %v", err)
+	}
+}